@@ -16,6 +16,7 @@ import (
 
 	"github.com/redpanda-data/console/backend/pkg/config"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -304,6 +305,10 @@ const (
 	// If the Condition is False, the resource SHOULD be considered to be in the process of reconciling and not a
 	// representation of actual state.
 	ReadyCondition = "Ready"
+
+	// WaitingForClusterCondition is set while the Redpanda cluster the Topic
+	// points at is not ready yet.
+	WaitingForClusterCondition = "WaitingForCluster"
 )
 
 const (
@@ -329,6 +334,10 @@ const (
 	//
 	// More information about the reason of failure MAY be available as additional metadata in an attached message.
 	FailedReason string = "Failed"
+
+	// WaitingForClusterReason indicates the reconciliation waits for the
+	// Redpanda cluster to become ready.
+	WaitingForClusterReason string = "WaitingForCluster"
 )
 
 // TopicProgressing resets any failures and registers progress toward
@@ -351,6 +360,27 @@ func TopicFailed(topic *Topic) *Topic {
 	return setCondition(FailedReason, "Topic reconciliation failed", metav1.ConditionFalse, topic)
 }
 
+// TopicWaitingForCluster registers that the given Topic waits for its
+// Redpanda cluster by setting the WaitingForClusterCondition to 'True' and
+// the meta.ReadyCondition to 'False' for WaitingForClusterReason.
+func TopicWaitingForCluster(topic *Topic, message string) *Topic {
+	apimeta.SetStatusCondition(&topic.Status.Conditions, metav1.Condition{
+		Type:               WaitingForClusterCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             WaitingForClusterReason,
+		Message:            message,
+		ObservedGeneration: topic.Generation,
+	})
+	return setCondition(WaitingForClusterReason, message, metav1.ConditionFalse, topic)
+}
+
+// TopicClusterReady removes the WaitingForClusterCondition from the given
+// Topic.
+func TopicClusterReady(topic *Topic) *Topic {
+	apimeta.RemoveStatusCondition(&topic.Status.Conditions, WaitingForClusterCondition)
+	return topic
+}
+
 func setCondition(reason, message string, status metav1.ConditionStatus, topic *Topic) *Topic {
 	condition := metav1.Condition{
 		Type:               ReadyCondition,
@@ -0,0 +1,30 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Hub = &Redpanda{}
+
+// Hub marks v1alpha1 as the storage version every other Redpanda version
+// converts through.
+func (*Redpanda) Hub() {}
+
+// SetupWebhookWithManager registers the conversion webhook for the Redpanda
+// type. The webhook is served on /convert once a spoke version is registered
+// in the manager scheme.
+func (in *Redpanda) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(in).
+		Complete()
+}
@@ -0,0 +1,269 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha1
+
+import "github.com/fluxcd/pkg/apis/meta"
+
+// These constants define the reasons of the Ready condition of a Redpanda.
+// They are stable, automation may branch on them.
+const (
+	// ClusterDeployedReason means the Redpanda has been reconciled
+	// successfully.
+	ClusterDeployedReason string = "RedpandaClusterDeployed"
+	// ProgressingReason means the reconciliation of the Redpanda is in
+	// progress.
+	ProgressingReason string = meta.ProgressingReason
+	// ReconcileTimeoutReason means the reconciliation did not complete within
+	// the reconcile timeout.
+	ReconcileTimeoutReason string = "ReconcileTimeout"
+	// InvalidRequeueIntervalReason means spec.requeueInterval cannot be
+	// parsed.
+	InvalidRequeueIntervalReason string = "InvalidRequeueInterval"
+	// CertificateFailedReason means the certificates of the cluster could not
+	// be reconciled.
+	CertificateFailedReason string = "CertificateFailed"
+	// RegistrySecretNotFoundReason means the Secret with the credentials of
+	// the chart registry does not exist.
+	RegistrySecretNotFoundReason string = "RegistrySecretNotFound"
+	// CABundleInvalidReason means the Secret with the CA bundle of the chart
+	// repository does not exist or does not hold a valid CA bundle.
+	CABundleInvalidReason string = "CABundleInvalid"
+	// LocalChartInvalidReason means the chart of localChartPath does not
+	// exist, is not a valid redpanda chart or local charts are disabled.
+	LocalChartInvalidReason string = "LocalChartInvalid"
+	// ChartUnverifiedReason means verify is invalid or the signature of the
+	// chart could not be verified. It is also the reason of the
+	// ChartUnverified condition.
+	ChartUnverifiedReason string = "ChartUnverified"
+	// ReplicaLimitExceededReason means the requested broker count exceeds
+	// the maximum replicas. It is also the reason of the ReplicaLimitExceeded
+	// condition.
+	ReplicaLimitExceededReason string = "ReplicaLimitExceeded"
+	// ReleaseNameCollisionReason means another Redpanda of the namespace
+	// deploys the same Helm release or chart resources of the same name. It
+	// is also the reason of the ReleaseNameCollision condition.
+	ReleaseNameCollisionReason string = "ReleaseNameCollision"
+	// VolumeShrinkRejectedReason means the requested storage size is smaller
+	// than the size of the broker PVCs. It is also a reason of the
+	// VolumeExpansion condition.
+	VolumeShrinkRejectedReason string = "VolumeShrinkRejected"
+	// InvalidVolumeSizeReason means the requested storage size is not a
+	// valid quantity. It is also a reason of the VolumeExpansion condition.
+	InvalidVolumeSizeReason string = "InvalidVolumeSize"
+	// RolloutInProgressReason means the broker StatefulSet is not fully
+	// rolled out and ready.
+	RolloutInProgressReason string = "RolloutInProgress"
+	// ConsoleNotReadyReason means the Console Deployment is not fully rolled
+	// out and available.
+	ConsoleNotReadyReason string = "ConsoleNotReady"
+	// ConsoleTLSSecretsFailedReason means the Secrets of consoleTLSSecrets
+	// could not be copied into the namespace of the Redpanda.
+	ConsoleTLSSecretsFailedReason string = "ConsoleTLSSecretsFailed"
+	// HelmRepositoryNotFoundReason means the existing HelmRepository referred
+	// to by the chartRef does not exist.
+	HelmRepositoryNotFoundReason string = "HelmRepositoryNotFound"
+	// ArtifactFailedReason means the HelmRepository or the HelmRelease is not
+	// ready, the message tells which one. It is also the reason of the
+	// HelmRepositoryNotReady condition.
+	ArtifactFailedReason string = "ArtifactFailed"
+	// WaitingForSecretReason means a Secret the values refer to does not
+	// exist yet.
+	WaitingForSecretReason string = "WaitingForSecret"
+	// WaitingForCertificateReason means a cert-manager Certificate issuing a
+	// Secret the values refer to is not ready yet.
+	WaitingForCertificateReason string = "WaitingForCertificate"
+	// SuspendedOnCreateReason means the HelmRelease has been created
+	// suspended.
+	SuspendedOnCreateReason string = "SuspendedOnCreate"
+	// UnsupportedChartVersionReason means the chart version is not supported
+	// by the operator.
+	UnsupportedChartVersionReason string = "UnsupportedChartVersion"
+	// ValuesInvalidReason means the chart cannot be rendered with the values
+	// of the Redpanda.
+	ValuesInvalidReason string = "ValuesInvalid"
+	// ExternalNotReadyReason means the external Service has no ready
+	// endpoints, see the ExternalReady condition.
+	ExternalNotReadyReason string = "ExternalNotReady"
+	// DualOwnershipReason means the v1 Cluster the Redpanda migrates from is
+	// still reconciled, see the DualOwnership condition.
+	DualOwnershipReason string = "DualOwnership"
+	// HookFailedReason means the post-ready hook failed with the
+	// fail-closed hook failure policy.
+	HookFailedReason string = "HookFailed"
+	// TeardownInProgressReason means the resources of the cluster are being
+	// deleted, it is also the reason of the Teardown condition.
+	TeardownInProgressReason string = "TeardownInProgress"
+	// TeardownCompletedReason means the resources of the cluster have been
+	// deleted, it is also the reason of the Teardown condition.
+	TeardownCompletedReason string = "TeardownCompleted"
+)
+
+// These constants define the reasons of the other conditions of a Redpanda,
+// named after the condition they are set on.
+const (
+	// HelmReleaseExistsReason is the reason of the MigrationConflict
+	// condition, a HelmRelease of the same name not managed by the operator
+	// exists.
+	HelmReleaseExistsReason string = "HelmReleaseExists"
+	// MigrationSelfReferenceReason is the reason of the MigrationConflict
+	// condition, spec.migration references a resource generated for the
+	// Redpanda itself.
+	MigrationSelfReferenceReason string = "MigrationSelfReference"
+	// SecretNotFoundReason is the reason of the WaitingForSecret condition.
+	SecretNotFoundReason string = "SecretNotFound"
+	// CertificateNotReadyReason is the reason of the WaitingForCertificate
+	// condition.
+	CertificateNotReadyReason string = "CertificateNotReady"
+	// HelmReleaseDeletingReason is the reason of the ReleaseUninstalling
+	// condition, the HelmRelease is being deleted before being recreated.
+	HelmReleaseDeletingReason string = "HelmReleaseDeleting"
+	// ResourcesRemainingReason is the reason of the DeletionBlocked
+	// condition, resources of the cluster still exist.
+	ResourcesRemainingReason string = "ResourcesRemaining"
+	// FinalizerTimeoutReason is the reason of the ForcedFinalization
+	// condition, the deletion did not complete within the finalizer timeout.
+	FinalizerTimeoutReason string = "FinalizerTimeout"
+	// ClusterStillReconcilingReason is the reason of the DualOwnership
+	// condition.
+	ClusterStillReconcilingReason string = "ClusterStillReconciling"
+	// OwnerReferenceMissingReason is the reason of the OwnershipLost
+	// condition.
+	OwnerReferenceMissingReason string = "OwnerReferenceMissing"
+	// UpgradeNotApprovedReason is the reason of the PendingApproval
+	// condition.
+	UpgradeNotApprovedReason string = "UpgradeNotApproved"
+	// DowngradeNotAllowedReason is the reason of the DowngradeBlocked
+	// condition.
+	DowngradeNotAllowedReason string = "DowngradeNotAllowed"
+	// VolumeExpansionInProgressReason is the reason of the VolumeExpansion
+	// condition while broker PVCs are resized.
+	VolumeExpansionInProgressReason string = "VolumeExpansionInProgress"
+	// VolumeExpansionNotSupportedReason is the reason of the VolumeExpansion
+	// condition when the storage class of broker PVCs does not allow volume
+	// expansion.
+	VolumeExpansionNotSupportedReason string = "VolumeExpansionNotSupported"
+	// AcceptedByAnnotationReason is the reason of the UnsupportedChartVersion
+	// condition when the chart version is accepted with an annotation.
+	AcceptedByAnnotationReason string = "AcceptedByAnnotation"
+	// RenderFailedReason is the reason of the ValuesInvalid condition.
+	RenderFailedReason string = "RenderFailed"
+	// GracePeriodReason is the reason of the ReplicaMismatch condition
+	// during its grace period.
+	GracePeriodReason string = "GracePeriod"
+	// ReplicaMismatchReason is the reason of the ReplicaMismatch condition.
+	ReplicaMismatchReason string = "ReplicaMismatch"
+	// EndpointsReadyReason is the reason of the ExternalReady condition when
+	// the external Service has ready endpoints.
+	EndpointsReadyReason string = "EndpointsReady"
+	// NoReadyEndpointsReason is the reason of the ExternalReady condition
+	// when the external Service has no ready endpoints.
+	NoReadyEndpointsReason string = "NoReadyEndpoints"
+	// DependencyVersionSkewReason is the reason of the DependencyVersionSkew
+	// condition when a deployed subchart version violates its constraint.
+	DependencyVersionSkewReason string = "DependencyVersionSkew"
+	// InvalidDependencyConstraintReason is the reason of the
+	// DependencyVersionSkew condition when a constraint cannot be parsed.
+	InvalidDependencyConstraintReason string = "InvalidDependencyConstraint"
+	// LicenseNotLoadedReason is the reason of the LicenseInvalid condition
+	// when no license is loaded.
+	LicenseNotLoadedReason string = "LicenseNotLoaded"
+	// LicenseExpiredReason is the reason of the LicenseInvalid condition when
+	// the license expired.
+	LicenseExpiredReason string = "LicenseExpired"
+	// LicenseExpiringReason is the reason of the LicenseExpiringSoon
+	// condition.
+	LicenseExpiringReason string = "LicenseExpiring"
+	// ArtifactStaleReason is the reason of the StaleArtifact condition.
+	ArtifactStaleReason string = "ArtifactStale"
+	// ReplicasExternallyManagedReason is the reason of the
+	// ReplicasExternallyManaged condition.
+	ReplicasExternallyManagedReason string = "ReplicasExternallyManaged"
+)
+
+// These constants define the reasons of the events of a Redpanda that do not
+// share the reason of a condition. Like the condition reasons they are
+// stable, the event policies of the operator are keyed by them.
+const (
+	// HelmRepositoryCreatedReason means the HelmRepository was created.
+	HelmRepositoryCreatedReason string = "HelmRepositoryCreated"
+	// HelmRepositoryUpdatedReason means the HelmRepository was updated.
+	HelmRepositoryUpdatedReason string = "HelmRepositoryUpdated"
+	// HelmRepositoryFailedReason means the HelmRepository could not be
+	// retrieved, created or updated.
+	HelmRepositoryFailedReason string = "HelmRepositoryFailed"
+	// HelmRepositoryAuthFailedReason means the HelmRepository failed to
+	// authenticate to the chart repository.
+	HelmRepositoryAuthFailedReason string = "HelmRepositoryAuthFailed"
+	// HelmRepositoryReadyReason means the HelmRepository became ready.
+	HelmRepositoryReadyReason string = "HelmRepositoryReady"
+	// FallbackRepositoryDeletedReason means the HelmRepository of a removed
+	// fallback URL was deleted.
+	FallbackRepositoryDeletedReason string = "FallbackRepositoryDeleted"
+	// HelmReleaseCreatedReason means the HelmRelease was created.
+	HelmReleaseCreatedReason string = "HelmReleaseCreated"
+	// HelmReleaseUpdatedReason means the HelmRelease was updated.
+	HelmReleaseUpdatedReason string = "HelmReleaseUpdated"
+	// HelmReleaseFailedReason means the HelmRelease could not be generated,
+	// created or updated.
+	HelmReleaseFailedReason string = "HelmReleaseFailed"
+	// HelmReleaseReadyReason means the HelmRelease became ready.
+	HelmReleaseReadyReason string = "HelmReleaseReady"
+	// HelmReleaseResumedReason means the HelmRelease suspended on creation
+	// was resumed.
+	HelmReleaseResumedReason string = "HelmReleaseResumed"
+	// HelmReleaseRecreatedReason means the HelmRelease was deleted to be
+	// created again.
+	HelmReleaseRecreatedReason string = "HelmReleaseRecreated"
+	// DestructiveActionBlockedReason means safe mode turned a deletion into
+	// a dry run.
+	DestructiveActionBlockedReason string = "DestructiveActionBlocked"
+	// OwnerReferenceReassertedReason means the owner reference of the
+	// HelmRelease was set again.
+	OwnerReferenceReassertedReason string = "OwnerReferenceReasserted"
+	// HelmMetadataRepairedReason means the Helm labels and annotations of an
+	// adopted resource were repaired.
+	HelmMetadataRepairedReason string = "HelmMetadataRepaired"
+	// ServiceSelectorRepairedReason means the selector of the internal
+	// Service was repaired.
+	ServiceSelectorRepairedReason string = "ServiceSelectorRepaired"
+	// DeletedAheadOfReleaseReason means a resource of the release was
+	// deleted before the HelmRelease, see spec.chartRef.preDeleteKinds.
+	DeletedAheadOfReleaseReason string = "DeletedAheadOfRelease"
+	// ConsoleTLSSecretsSyncedReason means a copy of a console TLS secret was
+	// created, updated or deleted.
+	ConsoleTLSSecretsSyncedReason string = "ConsoleTLSSecretsSynced"
+	// NetworkPolicyCreatedReason means the NetworkPolicy was created.
+	NetworkPolicyCreatedReason string = "NetworkPolicyCreated"
+	// NetworkPolicyUpdatedReason means the NetworkPolicy was updated.
+	NetworkPolicyUpdatedReason string = "NetworkPolicyUpdated"
+	// NetworkPolicyDeletedReason means the NetworkPolicy was deleted.
+	NetworkPolicyDeletedReason string = "NetworkPolicyDeleted"
+	// MigrationResourceAdoptedReason means a resource of the migrated
+	// Cluster or Console was updated to be adopted by the Helm release,
+	// emitted on that resource.
+	MigrationResourceAdoptedReason string = "MigrationResourceAdopted"
+	// MigrationResourceDeletedReason means a resource of the migrated
+	// Cluster or Console was deleted, emitted on that resource.
+	MigrationResourceDeletedReason string = "MigrationResourceDeleted"
+	// MigrationRerunReason means migration steps are run again as requested
+	// by the migration-rerun annotation.
+	MigrationRerunReason string = "MigrationRerun"
+	// InvalidMigrationRerunReason means the migration-rerun annotation lists
+	// unknown steps.
+	InvalidMigrationRerunReason string = "InvalidMigrationRerun"
+	// MigrationCompletedReason means the migration completed.
+	MigrationCompletedReason string = "MigrationCompleted"
+	// MigrationDisabledReason means spec.migration was disabled after the
+	// migration completed.
+	MigrationDisabledReason string = "MigrationDisabled"
+	// VolumeResizeRequestedReason means the resize of a broker PVC was
+	// requested.
+	VolumeResizeRequestedReason string = "VolumeResizeRequested"
+)
@@ -10,11 +10,13 @@
 package v1alpha1
 
 import (
-	"encoding/json"
 	"fmt"
+	"net"
+	"strings"
 
 	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
 	"github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,6 +41,178 @@ type ChartRef struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 	// Upgrade contains the details for handling upgrades including failures
 	Upgrade *HelmUpgrade `json:"upgrade,omitempty"`
+	// ValuesOverlays is an ordered list of ConfigMaps or Secrets holding chart
+	// values that are merged on top of ClusterSpec. Later overlays win over
+	// earlier ones. Maps are merged key by key, while arrays and scalars are
+	// replaced as a whole by the overlay that sets them.
+	// +optional
+	ValuesOverlays []ValuesOverlay `json:"valuesOverlays,omitempty"`
+	// Approval controls whether chart version changes are applied automatically
+	// or only once approved with the 'cluster.redpanda.com/approve-upgrade'
+	// annotation set to the new chart version. Value changes are always
+	// applied. Defaults to 'automatic'.
+	// +kubebuilder:validation:Enum=automatic;required
+	// +optional
+	Approval string `json:"approval,omitempty"`
+	// RepositoryURL overrides the chart repository URL. URLs with the 'oci://'
+	// scheme result in an OCI HelmRepository.
+	// +optional
+	RepositoryURL string `json:"repositoryURL,omitempty"`
+	// RegistrySecretRef references a Secret, in the namespace of the Redpanda,
+	// holding the credentials used by the source controller to authenticate
+	// against the chart repository or OCI registry.
+	// +optional
+	RegistrySecretRef *meta.LocalObjectReference `json:"registrySecretRef,omitempty"`
+	// CABundleSecretRef references a Secret, in the namespace of the Redpanda,
+	// holding a PEM encoded CA bundle under the 'ca.crt' key. The source
+	// controller trusts it when fetching the chart over HTTPS, for chart
+	// repositories and OCI registries with a certificate of a private CA.
+	// +optional
+	CABundleSecretRef *meta.LocalObjectReference `json:"caBundleSecretRef,omitempty"`
+	// Verify makes the source controller verify the signature of the chart,
+	// with the trusted public keys of the referenced Secret, before it is
+	// installed or upgraded. Only charts of OCI repositories can be verified,
+	// the repository URLs must use the 'oci://' scheme. A failed verification
+	// is reported with the ChartUnverified condition.
+	// +optional
+	Verify *helmv2beta1.HelmChartTemplateVerification `json:"verify,omitempty"`
+	// PostRenderers holds an array of Helm PostRenderers, which will be applied
+	// in order of their definition to the rendered chart.
+	// +optional
+	PostRenderers []helmv2beta1.PostRenderer `json:"postRenderers,omitempty"`
+	// DependsOn references HelmReleases that must be ready before the
+	// Redpanda HelmRelease is installed or upgraded, e.g. cert-manager.
+	// +optional
+	DependsOn []meta.NamespacedObjectReference `json:"dependsOn,omitempty"`
+	// WaitForSecrets lists Secrets, in the namespace of the Redpanda, that
+	// must exist before the HelmRelease is created or updated, e.g. Secrets
+	// materialized by the External Secrets Operator. Secrets referenced by
+	// ValuesOverlays are always waited for.
+	// +optional
+	WaitForSecrets []meta.LocalObjectReference `json:"waitForSecrets,omitempty"`
+	// SuspendOnCreate creates the HelmRelease suspended, so the rendered
+	// values can be inspected before anything is deployed. The HelmRelease is
+	// resumed once the 'cluster.redpanda.com/resume' annotation is set to
+	// "true" on the Redpanda.
+	// +optional
+	SuspendOnCreate bool `json:"suspendOnCreate,omitempty"`
+	// ExistingRepositoryName references a HelmRepository, in the namespace of
+	// the Redpanda, that is managed outside of the operator, e.g. shared and
+	// provisioned with GitOps. When set, the operator neither creates nor
+	// updates a HelmRepository and only waits for the referenced one to be
+	// ready. RepositoryURL, RegistrySecretRef and CABundleSecretRef are
+	// ignored.
+	// +optional
+	ExistingRepositoryName string `json:"existingRepositoryName,omitempty"`
+	// LocalChartPath is the path of a chart archive or directory, relative to
+	// the --local-charts-dir of the operator, e.g. a volume pre-staged for
+	// offline environments. The operator validates the chart and serves it
+	// from its own artifact storage, no remote chart repository is contacted.
+	// When set, RepositoryURL, RegistrySecretRef, CABundleSecretRef,
+	// FallbackRepositoryURLs and ExistingRepositoryName are ignored, and
+	// ChartVersion must be empty or the version of the local chart.
+	// +optional
+	LocalChartPath string `json:"localChartPath,omitempty"`
+	// ServiceAccountName is the ServiceAccount, in the namespace of the
+	// Redpanda, the HelmRelease is reconciled with, e.g. to restrict it with
+	// per-tenant RBAC. Defaults to the ServiceAccount of the operator.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// ValuesFrom holds references to ConfigMaps or Secrets, in the namespace
+	// of the Redpanda, passed to the HelmRelease as is. Unlike ValuesOverlays
+	// they are resolved by the helm controller, and a TargetPath sets a single
+	// nested value, e.g. 'config.cluster.auto_create_topics_enabled'.
+	// +optional
+	ValuesFrom []helmv2beta1.ValuesReference `json:"valuesFrom,omitempty"`
+	// ReleaseName is the name of the Helm release, required to adopt an
+	// existing release with a name different from the Redpanda. Defaults to
+	// the name of the Redpanda. It must be a valid DNS subdomain of at most
+	// 53 characters.
+	// +kubebuilder:validation:MaxLength=53
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+	// RequeueInterval is the interval the Redpanda is reconciled at while
+	// the HelmRepository, HelmRelease or referenced Secrets are not ready.
+	// Defaults to the interval of the operator, it must be at least 5s.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	RequeueInterval *metav1.Duration `json:"requeueInterval,omitempty"`
+	// RawValues is a YAML document of chart values the typed ClusterSpec
+	// cannot express. It is deep merged on top of the ClusterSpec,
+	// ValuesOverlays are merged on top of it. Nested maps are merged key by
+	// key, any other value, including lists, replaces the value of the
+	// ClusterSpec.
+	// +optional
+	RawValues string `json:"rawValues,omitempty"`
+	// FallbackRepositoryURLs are chart repository URLs, in order of
+	// preference, mirroring the chart of RepositoryURL. The HelmRelease fails
+	// over to the next URL when the HelmRepository in use has not been ready
+	// for FailoverAfter, and fails back as soon as a preceding one is ready
+	// again. Ignored with ExistingRepositoryName.
+	// +optional
+	FallbackRepositoryURLs []string `json:"fallbackRepositoryURLs,omitempty"`
+	// FailoverAfter is the period the HelmRepository in use must not be
+	// ready before failing over to the next fallback URL. Defaults to 5m.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	FailoverAfter *metav1.Duration `json:"failoverAfter,omitempty"`
+	// WaitForExternalEndpoints reports the Redpanda ready only once the
+	// external Service of the chart has ready endpoints, as recorded by the
+	// ExternalReady condition. Ignored when external access is disabled in
+	// the values.
+	// +optional
+	WaitForExternalEndpoints bool `json:"waitForExternalEndpoints,omitempty"`
+	// PreDeleteKinds are kinds of chart resources deleted, one kind at a time
+	// in order, before the HelmRelease is deleted, e.g. [Service,
+	// StatefulSet] to stop routing to brokers before they terminate. Helm
+	// deletes the remaining resources.
+	// +optional
+	PreDeleteKinds []PreDeleteKind `json:"preDeleteKinds,omitempty"`
+	// DependencyVersions pins the versions of the subcharts, e.g.
+	// {console: "~0.7"}, as semver constraints by dependency name. The chart
+	// bundles its subcharts, so a constraint cannot select a subchart version
+	// but the versions deployed, read from the helm.sh/chart label of the
+	// resources of the release, are checked against it. Violations are
+	// reported with the DependencyVersionSkew condition.
+	// +optional
+	DependencyVersions map[string]string `json:"dependencyVersions,omitempty"`
+}
+
+// GetRepositoryURL returns RepositoryURL or the default Redpanda chart repository.
+func (in *ChartRef) GetRepositoryURL() string {
+	if in.RepositoryURL == "" {
+		return RedpandaChartRepository
+	}
+	return in.RepositoryURL
+}
+
+// PreDeleteKind is a kind of chart resource that can be deleted ahead of
+// the HelmRelease.
+// +kubebuilder:validation:Enum=Service;Ingress;StatefulSet;Deployment;PodDisruptionBudget
+type PreDeleteKind string
+
+// ValuesOverlay references a ConfigMap or Secret, in the namespace of the
+// Redpanda resource, containing chart values in YAML format.
+type ValuesOverlay struct {
+	// Kind of the values referent, either ConfigMap or Secret.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+	// Name of the values referent.
+	Name string `json:"name"`
+	// ValuesKey is the data key where the values YAML can be found, defaults
+	// to 'values.yaml'.
+	// +optional
+	ValuesKey string `json:"valuesKey,omitempty"`
+}
+
+// GetValuesKey returns the configured data key or the default 'values.yaml'.
+func (in *ValuesOverlay) GetValuesKey() string {
+	if in.ValuesKey == "" {
+		return "values.yaml"
+	}
+	return in.ValuesKey
 }
 
 // RedpandaSpec defines the desired state of Redpanda
@@ -51,6 +225,71 @@ type RedpandaSpec struct {
 	// flux controller can import resources.
 	// Doc: https://docs.redpanda.com/current/upgrade/migrate/kubernetes/operator/
 	Migration *Migration `json:"migration,omitempty"`
+	// TLS configures certificates managed by the operator instead of the chart.
+	// +optional
+	TLS *RedpandaTLS `json:"tls,omitempty"`
+	// NetworkPolicy makes the operator restrict the access to the Kafka and
+	// Admin API ports of the brokers. When not set no NetworkPolicy is
+	// managed.
+	// +optional
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+	// ConsoleTLSSecrets are Secrets in other namespaces, e.g. the certificate
+	// referenced by the secretName of a console.ingress.tls entry, copied
+	// under the same name into the namespace of the Redpanda, where Console
+	// is deployed. The copies are owned by the Redpanda and follow changes of
+	// their source. Requires the operator to run with --allow-console-any-ns.
+	// +optional
+	ConsoleTLSSecrets []corev1.SecretReference `json:"consoleTLSSecrets,omitempty"`
+}
+
+// NetworkPolicy restricts the access to the Kafka and Admin API ports of the
+// brokers with a NetworkPolicy managed by the operator. Other ports of the
+// brokers stay open.
+type NetworkPolicy struct {
+	// AllowedNamespaces are namespaces whose Pods may connect to the Kafka and
+	// Admin API ports, in addition to the namespace of the Redpanda.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedCIDRs are IP blocks that may connect to the Kafka and Admin API
+	// ports, e.g. for clients outside of the Kubernetes cluster.
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
+// RedpandaTLS configures TLS certificates that are reconciled by the operator.
+type RedpandaTLS struct {
+	// CertManager makes the operator create a cert-manager Certificate, and an
+	// Issuer when no IssuerRef is given, and feed the resulting Secret into
+	// the chart values.
+	// +optional
+	CertManager *CertManagerTLS `json:"certManager,omitempty"`
+}
+
+// CertManagerTLS defines a certificate issued by cert-manager on behalf of a
+// Redpanda resource.
+type CertManagerTLS struct {
+	// CertName is the name of the chart certificate, a key of tls.certs in the
+	// values, that uses the issued Secret. Defaults to 'external'.
+	// +optional
+	CertName string `json:"certName,omitempty"`
+	// IssuerRef references an existing Issuer or ClusterIssuer. When not set a
+	// self-signed Issuer is created.
+	// +optional
+	IssuerRef *IssuerRef `json:"issuerRef,omitempty"`
+	// DNSNames are the subject alternative names of the certificate.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// Duration is the requested lifetime of the certificate.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// GetCertName returns the configured chart certificate name or the default 'external'.
+func (in *CertManagerTLS) GetCertName() string {
+	if in.CertName == "" {
+		return "external"
+	}
+	return in.CertName
 }
 
 // Migration can configure old Cluster and Console custom resource that will be disabled.
@@ -89,12 +328,32 @@ type RedpandaStatus struct {
 	// +optional
 	HelmRelease string `json:"helmRelease,omitempty"`
 
+	// LastHandledRecreateToken is the token of the recreate-helmrelease
+	// annotation the HelmRelease was last recreated for.
+	// +optional
+	LastHandledRecreateToken string `json:"lastHandledRecreateToken,omitempty"`
+
+	// TargetNamespace is the namespace the Helm release is installed in, as
+	// resolved from the HelmRelease.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// ReleaseName is the name of the Helm release, as resolved from the
+	// HelmRelease.
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+
 	// +optional
 	HelmReleaseReady *bool `json:"helmReleaseReady,omitempty"`
 
 	// +optional
 	HelmRepository string `json:"helmRepository,omitempty"`
 
+	// ActiveHelmRepository is the HelmRepository the HelmRelease fetches the
+	// chart from, it differs from HelmRepository after a failover.
+	// +optional
+	ActiveHelmRepository string `json:"activeHelmRepository,omitempty"`
+
 	// +optional
 	HelmRepositoryReady *bool `json:"helmRepositoryReady,omitempty"`
 
@@ -108,6 +367,87 @@ type RedpandaStatus struct {
 
 	// +optional
 	InstallFailures int64 `json:"installFailures,omitempty"`
+
+	// Summary is a short human readable description of the Redpanda state,
+	// computed on every reconcile.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// LastReconcileTime is the time of the last successful reconciliation.
+	// Reconciliations that change nothing else in the status refresh it at
+	// most every five minutes.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// LastReconcileError is the error of the latest reconciliation attempt,
+	// empty when it succeeded.
+	// +optional
+	LastReconcileError string `json:"lastReconcileError,omitempty"`
+	// RequeueInterval is the effective interval the Redpanda is reconciled
+	// at while its dependencies are not ready.
+	// +optional
+	RequeueInterval *metav1.Duration `json:"requeueInterval,omitempty"`
+	// ReadyDuration is the time the Redpanda took from its creation to
+	// become ready for the first time.
+	// +optional
+	ReadyDuration *metav1.Duration `json:"readyDuration,omitempty"`
+
+	// DesiredReplicas is the number of brokers requested by the chart values.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// ObservedReplicas is the number of ready brokers of the StatefulSet.
+	// +optional
+	ObservedReplicas int32 `json:"observedReplicas,omitempty"`
+
+	// RerunMigrationSteps are the migration steps of the migration-rerun
+	// annotation that have been rerun. They are cleared with the annotation.
+	// +optional
+	RerunMigrationSteps []string `json:"rerunMigrationSteps,omitempty"`
+
+	// UnreconciledFields are the clusterSpec fields that are set but do not
+	// take effect, because rawValues, a values overlay or the operator
+	// overrides them in the chart values, or because the chart version does
+	// not know them.
+	// +optional
+	UnreconciledFields []string `json:"unreconciledFields,omitempty"`
+
+	// License reflects the license loaded in the cluster, as reported by the
+	// Admin API. Only set when the operator checks licenses.
+	// +optional
+	License *RedpandaLicenseStatus `json:"license,omitempty"`
+
+	// Migration reflects the progress of the migration of the Cluster and
+	// Console set in Spec.Migration.
+	// +optional
+	Migration *RedpandaMigrationStatus `json:"migration,omitempty"`
+}
+
+// RedpandaLicenseStatus describes the license loaded in a Redpanda cluster.
+type RedpandaLicenseStatus struct {
+	// Loaded is true when a license is loaded in the cluster.
+	Loaded bool `json:"loaded"`
+	// Organization the license was issued to.
+	// +optional
+	Organization string `json:"organization,omitempty"`
+	// Type of the license, e.g. 'enterprise'.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Expiration is the time the license expires at.
+	// +optional
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+}
+
+// RedpandaMigrationStatus describes the migration of a v1 Cluster and Console
+// to a Redpanda.
+type RedpandaMigrationStatus struct {
+	// Completed is true once every migration step succeeded. The migration is
+	// then not run again, unless steps are rerun with the migration-rerun
+	// annotation.
+	Completed bool `json:"completed"`
+	// CompletionTime is the time the migration completed at.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 type RemediationStrategy string
@@ -125,8 +465,12 @@ type HelmUpgrade struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=redpandas
 // +kubebuilder:resource:shortName=rp
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Chart Version",type="string",JSONPath=".spec.chartRef.chartVersion",description=""
+// +kubebuilder:printcolumn:name="HelmRelease",type="string",JSONPath=".status.helmRelease",description=""
+// +kubebuilder:printcolumn:name="Summary",type="string",JSONPath=".status.summary",description="",priority=1
 type Redpanda struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -156,7 +500,19 @@ func (in *Redpanda) GetHelmReleaseName() string {
 	return in.Name
 }
 
+// GetReleaseName returns the name of the Helm release, ChartRef.ReleaseName or
+// the name of the Redpanda.
+func (in *Redpanda) GetReleaseName() string {
+	if in.Spec.ChartRef.ReleaseName != "" {
+		return in.Spec.ChartRef.ReleaseName
+	}
+	return in.Name
+}
+
 func (in *Redpanda) GetHelmRepositoryName() string {
+	if in.Spec.ChartRef.ExistingRepositoryName != "" && in.Spec.ChartRef.LocalChartPath == "" {
+		return in.Spec.ChartRef.ExistingRepositoryName
+	}
 	helmRepository := in.Spec.ChartRef.HelmRepositoryName
 	if helmRepository == "" {
 		helmRepository = "redpanda-repository"
@@ -164,23 +520,66 @@ func (in *Redpanda) GetHelmRepositoryName() string {
 	return helmRepository
 }
 
-func (in *Redpanda) ValuesJSON() (*apiextensionsv1.JSON, error) {
-	vyaml, err := json.Marshal(in.Spec.ClusterSpec)
-	if err != nil {
-		return nil, fmt.Errorf("could not convert spec to yaml: %w", err)
+// GetFallbackHelmRepositoryName returns the name of the HelmRepository of the
+// fallback chart repository URL at the given index.
+func (in *Redpanda) GetFallbackHelmRepositoryName(i int) string {
+	return fmt.Sprintf("%s-repository-fallback-%d", in.Name, i+1)
+}
+
+// GetActiveHelmRepositoryName returns the name of the HelmRepository the
+// HelmRelease fetches the chart from, the HelmRepository unless the chart
+// source failed over.
+func (in *Redpanda) GetActiveHelmRepositoryName() string {
+	if in.Status.ActiveHelmRepository != "" {
+		return in.Status.ActiveHelmRepository
 	}
-	values := &apiextensionsv1.JSON{Raw: vyaml}
+	return in.GetHelmRepositoryName()
+}
 
-	return values, nil
+// IsBrokerAddress reports whether the given Kafka broker address, as resolved
+// from fromNamespace, points at the internal Service of the Redpanda, either
+// at the Service itself or at one of the broker Pods behind it.
+func (in *Redpanda) IsBrokerAddress(address, fromNamespace string) bool {
+	// the chart names the Service after the Helm release
+	svc := in.GetReleaseName()
+	if in.Spec.ClusterSpec != nil && in.Spec.ClusterSpec.FullNameOverride != "" {
+		svc = in.Spec.ClusterSpec.FullNameOverride
+	}
+
+	host := address
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		host = h
+	}
+	parts := strings.Split(strings.TrimSuffix(host, "."), ".")
+	// only the Service or a single Pod segment may precede the Service name
+	for i := 0; i < len(parts) && i < 2; i++ {
+		if parts[i] != svc {
+			continue
+		}
+		if i+1 < len(parts) {
+			return parts[i+1] == in.Namespace
+		}
+		// short name, only resolvable from the same namespace
+		return fromNamespace == in.Namespace
+	}
+	return false
+}
+
+// ValuesJSON returns the ClusterSpec as chart values in the value schema of
+// ChartRef.ChartVersion, see VersionedValuesJSON.
+func (in *Redpanda) ValuesJSON() (*apiextensionsv1.JSON, error) {
+	values, _, err := in.VersionedValuesJSON()
+	return values, err
 }
 
 // RedpandaReady registers a successful reconciliation of the given HelmRelease.
 func RedpandaReady(rp *Redpanda) *Redpanda {
 	newCondition := metav1.Condition{
-		Type:    meta.ReadyCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  "RedpandaClusterDeployed",
-		Message: "Redpanda reconciliation succeeded",
+		Type:               meta.ReadyCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             ClusterDeployedReason,
+		Message:            "Redpanda reconciliation succeeded",
 	}
 	apimeta.SetStatusCondition(rp.GetConditions(), newCondition)
 	rp.Status.LastAppliedRevision = rp.Status.LastAttemptedRevision
@@ -190,10 +589,11 @@ func RedpandaReady(rp *Redpanda) *Redpanda {
 // RedpandaNotReady registers a failed reconciliation of the given Redpanda.
 func RedpandaNotReady(rp *Redpanda, reason, message string) *Redpanda {
 	newCondition := metav1.Condition{
-		Type:    meta.ReadyCondition,
-		Status:  metav1.ConditionFalse,
-		Reason:  reason,
-		Message: message,
+		Type:               meta.ReadyCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: rp.Generation,
+		Reason:             reason,
+		Message:            message,
 	}
 	apimeta.SetStatusCondition(rp.GetConditions(), newCondition)
 	return rp
@@ -205,10 +605,11 @@ func RedpandaNotReady(rp *Redpanda, reason, message string) *Redpanda {
 func RedpandaProgressing(rp *Redpanda) *Redpanda {
 	rp.Status.Conditions = []metav1.Condition{}
 	newCondition := metav1.Condition{
-		Type:    meta.ReadyCondition,
-		Status:  metav1.ConditionUnknown,
-		Reason:  meta.ProgressingReason,
-		Message: "Reconciliation in progress",
+		Type:               meta.ReadyCondition,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: rp.Generation,
+		Reason:             ProgressingReason,
+		Message:            "Reconciliation in progress",
 	}
 	apimeta.SetStatusCondition(rp.GetConditions(), newCondition)
 	return rp
@@ -0,0 +1,37 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestIsBrokerAddress(t *testing.T) {
+	rp := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+
+	assert.True(t, rp.IsBrokerAddress("redpanda.default.svc.cluster.local:9093", "other"))
+	assert.True(t, rp.IsBrokerAddress("redpanda-0.redpanda.default.svc:9093", "other"))
+	assert.True(t, rp.IsBrokerAddress("redpanda:9093", "default"))
+	assert.False(t, rp.IsBrokerAddress("redpanda:9093", "other"))
+	assert.False(t, rp.IsBrokerAddress("redpanda.other.svc:9093", "default"))
+
+	// the Service is named after the Helm release
+	rp.Spec.ChartRef.ReleaseName = "redpanda-prod"
+	assert.True(t, rp.IsBrokerAddress("redpanda-prod.default.svc:9093", "other"))
+	assert.False(t, rp.IsBrokerAddress("redpanda.default.svc:9093", "other"))
+
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{FullNameOverride: "panda"}
+	assert.True(t, rp.IsBrokerAddress("panda-1.panda.default.svc:9093", "other"))
+}
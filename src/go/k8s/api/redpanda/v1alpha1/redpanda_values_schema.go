@@ -0,0 +1,165 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// valuesSchema describes how the values of the ClusterSpec, which follows the
+// latest chart, map to the values of older chart versions.
+type valuesSchema struct {
+	// versions is the semver range of the chart versions the schema applies
+	// to.
+	versions string
+	// renamed maps the dot notation paths of values to the paths the chart
+	// versions expect them at.
+	renamed map[string]string
+	// dropped are the dot notation paths of values the chart versions do not
+	// know.
+	dropped []string
+}
+
+// valuesSchemas are the value schemas of the chart versions the ClusterSpec
+// does not match, every schema whose range contains the chart version is
+// applied in order.
+var valuesSchemas = []valuesSchema{
+	{
+		// the enterprise values replaced the license values in 5.0.0
+		versions: "<5.0.0",
+		renamed: map[string]string{
+			"enterprise.license":               "license_key",
+			"enterprise.licenseSecretRef.key":  "license_secret_ref.secret_key",
+			"enterprise.licenseSecretRef.name": "license_secret_ref.secret_name",
+		},
+		dropped: []string{"connectors"},
+	},
+}
+
+// VersionedValuesJSON returns the ClusterSpec as chart values in the value
+// schema of ChartRef.ChartVersion, along with the sorted dot notation paths
+// of the values that were dropped because the chart version does not know
+// them. Values are only translated when ChartVersion is an exact version, an
+// empty version or a version range targets the latest value schema.
+func (in *Redpanda) VersionedValuesJSON() (*apiextensionsv1.JSON, []string, error) {
+	raw, err := json.Marshal(in.Spec.ClusterSpec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not convert spec to yaml: %w", err)
+	}
+
+	version, err := semver.NewVersion(in.Spec.ChartRef.ChartVersion)
+	if err != nil || in.Spec.ClusterSpec == nil {
+		return &apiextensionsv1.JSON{Raw: raw}, nil, nil
+	}
+
+	values := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &values); err != nil {
+		return nil, nil, fmt.Errorf("could not unmarshal clusterSpec values: %w", err)
+	}
+	var dropped []string
+	applied := false
+	for i := range valuesSchemas {
+		constraint, err := semver.NewConstraint(valuesSchemas[i].versions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid values schema range %q: %w", valuesSchemas[i].versions, err)
+		}
+		if constraint.Check(version) {
+			dropped = append(dropped, valuesSchemas[i].apply(values)...)
+			applied = true
+		}
+	}
+	if !applied {
+		return &apiextensionsv1.JSON{Raw: raw}, nil, nil
+	}
+	// renamed values are translated even when nothing was dropped
+	sort.Strings(dropped)
+
+	if raw, err = json.Marshal(values); err != nil {
+		return nil, nil, fmt.Errorf("could not marshal clusterSpec values: %w", err)
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, dropped, nil
+}
+
+// apply translates values to the schema in place and returns the paths of
+// the values it dropped. A renamed value is dropped when its new path is
+// already set.
+func (s *valuesSchema) apply(values map[string]interface{}) []string {
+	var dropped []string
+	from := make([]string, 0, len(s.renamed))
+	for path := range s.renamed {
+		from = append(from, path)
+	}
+	sort.Strings(from)
+	for _, path := range from {
+		value, ok := removeValue(values, strings.Split(path, "."))
+		if !ok {
+			continue
+		}
+		if !setValue(values, strings.Split(s.renamed[path], "."), value) {
+			dropped = append(dropped, path)
+		}
+	}
+	for _, path := range s.dropped {
+		if _, ok := removeValue(values, strings.Split(path, ".")); ok {
+			dropped = append(dropped, path)
+		}
+	}
+	return dropped
+}
+
+// removeValue removes the value at the given path, along with the mappings
+// left empty, and returns it.
+func removeValue(values map[string]interface{}, path []string) (interface{}, bool) {
+	value, ok := values[path[0]]
+	if !ok {
+		return nil, false
+	}
+	if len(path) == 1 {
+		delete(values, path[0])
+		return value, true
+	}
+	nested, isMap := value.(map[string]interface{})
+	if !isMap {
+		return nil, false
+	}
+	value, ok = removeValue(nested, path[1:])
+	if ok && len(nested) == 0 {
+		delete(values, path[0])
+	}
+	return value, ok
+}
+
+// setValue sets the value at the given path, creating the missing mappings,
+// unless a value is already set there.
+func setValue(values map[string]interface{}, path []string, value interface{}) bool {
+	if len(path) == 1 {
+		if _, ok := values[path[0]]; ok {
+			return false
+		}
+		values[path[0]] = value
+		return true
+	}
+	existing, ok := values[path[0]]
+	if !ok {
+		existing = map[string]interface{}{}
+		values[path[0]] = existing
+	}
+	nested, isMap := existing.(map[string]interface{})
+	if !isMap {
+		return false
+	}
+	return setValue(nested, path[1:], value)
+}
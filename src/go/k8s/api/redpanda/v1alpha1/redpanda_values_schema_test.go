@@ -0,0 +1,114 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha1_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestVersionedValuesJSON(t *testing.T) {
+	clusterSpec := &v1alpha1.RedpandaClusterSpec{
+		NameOverride: "panda",
+		Enterprise: &v1alpha1.Enterprise{
+			License:          ptr.To("license"),
+			LicenseSecretRef: &v1alpha1.EnterpriseLicenseSecretRef{Name: "license", Key: "key"},
+		},
+		Connectors: &v1alpha1.RedpandaConnectors{Enabled: ptr.To(true)},
+	}
+	latest := map[string]interface{}{
+		"nameOverride": "panda",
+		"enterprise": map[string]interface{}{
+			"license":          "license",
+			"licenseSecretRef": map[string]interface{}{"name": "license", "key": "key"},
+		},
+		"connectors": map[string]interface{}{"enabled": true},
+	}
+
+	tests := []struct {
+		name            string
+		version         string
+		clusterSpec     *v1alpha1.RedpandaClusterSpec
+		expected        map[string]interface{}
+		expectedDropped []string
+	}{
+		{name: "latest chart", clusterSpec: clusterSpec, expected: latest},
+		{name: "version range", version: ">=4.0.0", clusterSpec: clusterSpec, expected: latest},
+		{name: "current chart", version: "5.7.1", clusterSpec: clusterSpec, expected: latest},
+		{
+			name:        "older chart",
+			version:     "4.0.54",
+			clusterSpec: clusterSpec,
+			expected: map[string]interface{}{
+				"nameOverride":       "panda",
+				"license_key":        "license",
+				"license_secret_ref": map[string]interface{}{"secret_name": "license", "secret_key": "key"},
+			},
+			expectedDropped: []string{"connectors"},
+		},
+		{
+			name:    "older chart with renamed values only",
+			version: "4.0.54",
+			clusterSpec: &v1alpha1.RedpandaClusterSpec{
+				NameOverride: "panda",
+				Enterprise:   &v1alpha1.Enterprise{License: ptr.To("license")},
+			},
+			expected: map[string]interface{}{
+				"nameOverride": "panda",
+				"license_key":  "license",
+			},
+		},
+		{
+			name:    "renamed value already set",
+			version: "4.0.54",
+			clusterSpec: &v1alpha1.RedpandaClusterSpec{
+				LicenseKey: ptr.To("legacy"),
+				Enterprise: &v1alpha1.Enterprise{License: ptr.To("license")},
+			},
+			expected:        map[string]interface{}{"license_key": "legacy"},
+			expectedDropped: []string{"enterprise.license"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &v1alpha1.Redpanda{Spec: v1alpha1.RedpandaSpec{
+				ChartRef:    v1alpha1.ChartRef{ChartVersion: tt.version},
+				ClusterSpec: tt.clusterSpec,
+			}}
+
+			values, dropped, err := rp.VersionedValuesJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDropped, dropped)
+
+			got := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(values.Raw, &got))
+			assert.Equal(t, tt.expected, got)
+
+			plain, err := rp.ValuesJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, string(values.Raw), string(plain.Raw))
+		})
+	}
+}
+
+func TestVersionedValuesJSONNilClusterSpec(t *testing.T) {
+	rp := &v1alpha1.Redpanda{Spec: v1alpha1.RedpandaSpec{ChartRef: v1alpha1.ChartRef{ChartVersion: "4.0.54"}}}
+
+	values, dropped, err := rp.VersionedValuesJSON()
+	require.NoError(t, err)
+	assert.Empty(t, dropped)
+	assert.Equal(t, "null", string(values.Raw))
+}
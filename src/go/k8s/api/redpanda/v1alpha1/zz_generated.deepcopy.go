@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	"github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/meta"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -121,6 +122,36 @@ func (in *CPU) DeepCopy() *CPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerTLS) DeepCopyInto(out *CertManagerTLS) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(IssuerRef)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerTLS.
+func (in *CertManagerTLS) DeepCopy() *CertManagerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
@@ -164,6 +195,75 @@ func (in *ChartRef) DeepCopyInto(out *ChartRef) {
 		*out = new(HelmUpgrade)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesOverlays != nil {
+		in, out := &in.ValuesOverlays, &out.ValuesOverlays
+		*out = make([]ValuesOverlay, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegistrySecretRef != nil {
+		in, out := &in.RegistrySecretRef, &out.RegistrySecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(v2beta1.HelmChartTemplateVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRenderers != nil {
+		in, out := &in.PostRenderers, &out.PostRenderers
+		*out = make([]v2beta1.PostRenderer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]meta.NamespacedObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.WaitForSecrets != nil {
+		in, out := &in.WaitForSecrets, &out.WaitForSecrets
+		*out = make([]meta.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]v2beta1.ValuesReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequeueInterval != nil {
+		in, out := &in.RequeueInterval, &out.RequeueInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FallbackRepositoryURLs != nil {
+		in, out := &in.FallbackRepositoryURLs, &out.FallbackRepositoryURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailoverAfter != nil {
+		in, out := &in.FailoverAfter, &out.FailoverAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PreDeleteKinds != nil {
+		in, out := &in.PreDeleteKinds, &out.PreDeleteKinds
+		*out = make([]PreDeleteKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependencyVersions != nil {
+		in, out := &in.DependencyVersions, &out.DependencyVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartRef.
@@ -935,6 +1035,31 @@ func (in *Monitoring) DeepCopy() *Monitoring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolume) DeepCopyInto(out *PersistentVolume) {
 	*out = *in
@@ -1581,6 +1706,25 @@ func (in *RedpandaImage) DeepCopy() *RedpandaImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedpandaLicenseStatus) DeepCopyInto(out *RedpandaLicenseStatus) {
+	*out = *in
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedpandaLicenseStatus.
+func (in *RedpandaLicenseStatus) DeepCopy() *RedpandaLicenseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedpandaLicenseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedpandaList) DeepCopyInto(out *RedpandaList) {
 	*out = *in
@@ -1638,6 +1782,25 @@ func (in *RedpandaMemory) DeepCopy() *RedpandaMemory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedpandaMigrationStatus) DeepCopyInto(out *RedpandaMigrationStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedpandaMigrationStatus.
+func (in *RedpandaMigrationStatus) DeepCopy() *RedpandaMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedpandaMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedpandaSpec) DeepCopyInto(out *RedpandaSpec) {
 	*out = *in
@@ -1652,6 +1815,21 @@ func (in *RedpandaSpec) DeepCopyInto(out *RedpandaSpec) {
 		*out = new(Migration)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RedpandaTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsoleTLSSecrets != nil {
+		in, out := &in.ConsoleTLSSecrets, &out.ConsoleTLSSecrets
+		*out = make([]v1.SecretReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedpandaSpec.
@@ -1685,6 +1863,40 @@ func (in *RedpandaStatus) DeepCopyInto(out *RedpandaStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RequeueInterval != nil {
+		in, out := &in.RequeueInterval, &out.RequeueInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReadyDuration != nil {
+		in, out := &in.ReadyDuration, &out.ReadyDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RerunMigrationSteps != nil {
+		in, out := &in.RerunMigrationSteps, &out.RerunMigrationSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreconciledFields != nil {
+		in, out := &in.UnreconciledFields, &out.UnreconciledFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.License != nil {
+		in, out := &in.License, &out.License
+		*out = new(RedpandaLicenseStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(RedpandaMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedpandaStatus.
@@ -1697,6 +1909,26 @@ func (in *RedpandaStatus) DeepCopy() *RedpandaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedpandaTLS) DeepCopyInto(out *RedpandaTLS) {
+	*out = *in
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedpandaTLS.
+func (in *RedpandaTLS) DeepCopy() *RedpandaTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(RedpandaTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Requests) DeepCopyInto(out *Requests) {
 	*out = *in
@@ -2542,3 +2774,18 @@ func (in *UsersItems) DeepCopy() *UsersItems {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValuesOverlay) DeepCopyInto(out *ValuesOverlay) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValuesOverlay.
+func (in *ValuesOverlay) DeepCopy() *ValuesOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ValuesOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,390 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+var _ conversion.Convertible = &Redpanda{}
+
+// ConvertTo converts this Redpanda to the Hub version (v1alpha1).
+func (in *Redpanda) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.Redpanda)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", dstRaw)
+	}
+
+	in.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	dst.Spec.ChartRef = v1alpha1.ChartRef{
+		ChartName:                in.Spec.ChartRef.ChartName,
+		ChartVersion:             in.Spec.ChartRef.ChartVersion,
+		HelmRepositoryName:       in.Spec.ChartRef.HelmRepositoryName,
+		Timeout:                  copyDuration(in.Spec.ChartRef.Timeout),
+		Approval:                 in.Spec.ChartRef.Approval,
+		RepositoryURL:            in.Spec.ChartRef.RepositoryURL,
+		RegistrySecretRef:        copyLocalObjectReference(in.Spec.ChartRef.RegistrySecretRef),
+		CABundleSecretRef:        copyLocalObjectReference(in.Spec.ChartRef.CABundleSecretRef),
+		Verify:                   in.Spec.ChartRef.Verify.DeepCopy(),
+		PostRenderers:            copyPostRenderers(in.Spec.ChartRef.PostRenderers),
+		DependsOn:                copyNamespacedObjectReferences(in.Spec.ChartRef.DependsOn),
+		WaitForSecrets:           copyLocalObjectReferences(in.Spec.ChartRef.WaitForSecrets),
+		SuspendOnCreate:          in.Spec.ChartRef.SuspendOnCreate,
+		ExistingRepositoryName:   in.Spec.ChartRef.ExistingRepositoryName,
+		LocalChartPath:           in.Spec.ChartRef.LocalChartPath,
+		ServiceAccountName:       in.Spec.ChartRef.ServiceAccountName,
+		ValuesFrom:               copyValuesReferences(in.Spec.ChartRef.ValuesFrom),
+		ReleaseName:              in.Spec.ChartRef.ReleaseName,
+		RequeueInterval:          copyDuration(in.Spec.ChartRef.RequeueInterval),
+		RawValues:                in.Spec.ChartRef.RawValues,
+		FallbackRepositoryURLs:   copyStrings(in.Spec.ChartRef.FallbackRepositoryURLs),
+		FailoverAfter:            copyDuration(in.Spec.ChartRef.FailoverAfter),
+		WaitForExternalEndpoints: in.Spec.ChartRef.WaitForExternalEndpoints,
+		DependencyVersions:       copyStringMap(in.Spec.ChartRef.DependencyVersions),
+	}
+	if u := in.Spec.ChartRef.Upgrade; u != nil {
+		dst.Spec.ChartRef.Upgrade = &v1alpha1.HelmUpgrade{
+			Remediation:    u.Remediation.DeepCopy(),
+			Force:          copyBool(u.Force),
+			PreserveValues: copyBool(u.PreserveValues),
+			CleanupOnFail:  copyBool(u.CleanupOnFail),
+		}
+	}
+	if in.Spec.ChartRef.ValuesOverlays != nil {
+		dst.Spec.ChartRef.ValuesOverlays = make([]v1alpha1.ValuesOverlay, len(in.Spec.ChartRef.ValuesOverlays))
+		for i, o := range in.Spec.ChartRef.ValuesOverlays {
+			dst.Spec.ChartRef.ValuesOverlays[i] = v1alpha1.ValuesOverlay{Kind: o.Kind, Name: o.Name, ValuesKey: o.ValuesKey}
+		}
+	}
+	if in.Spec.ChartRef.PreDeleteKinds != nil {
+		dst.Spec.ChartRef.PreDeleteKinds = make([]v1alpha1.PreDeleteKind, len(in.Spec.ChartRef.PreDeleteKinds))
+		for i, k := range in.Spec.ChartRef.PreDeleteKinds {
+			dst.Spec.ChartRef.PreDeleteKinds[i] = v1alpha1.PreDeleteKind(k)
+		}
+	}
+
+	dst.Spec.ClusterSpec = in.Spec.ClusterSpec.DeepCopy()
+
+	dst.Spec.Migration = nil
+	if m := in.Spec.Migration; m != nil {
+		dst.Spec.Migration = &v1alpha1.Migration{
+			Enabled:    m.Enabled,
+			ClusterRef: m.ClusterRef,
+			ConsoleRef: m.ConsoleRef,
+		}
+	}
+
+	dst.Spec.TLS = nil
+	if t := in.Spec.TLS; t != nil {
+		dst.Spec.TLS = &v1alpha1.RedpandaTLS{}
+		if cm := t.CertManager; cm != nil {
+			dst.Spec.TLS.CertManager = &v1alpha1.CertManagerTLS{
+				CertName: cm.CertName,
+				DNSNames: copyStrings(cm.DNSNames),
+				Duration: copyDuration(cm.Duration),
+			}
+			if cm.IssuerRef != nil {
+				dst.Spec.TLS.CertManager.IssuerRef = &v1alpha1.IssuerRef{Name: cm.IssuerRef.Name, Kind: cm.IssuerRef.Kind}
+			}
+		}
+	}
+
+	dst.Spec.NetworkPolicy = nil
+	if np := in.Spec.NetworkPolicy; np != nil {
+		dst.Spec.NetworkPolicy = &v1alpha1.NetworkPolicy{
+			AllowedNamespaces: copyStrings(np.AllowedNamespaces),
+			AllowedCIDRs:      copyStrings(np.AllowedCIDRs),
+		}
+	}
+
+	dst.Spec.ConsoleTLSSecrets = copySecretReferences(in.Spec.ConsoleTLSSecrets)
+
+	dst.Status = v1alpha1.RedpandaStatus{
+		ObservedGeneration:       in.Status.ObservedGeneration,
+		ReconcileRequestStatus:   in.Status.ReconcileRequestStatus,
+		Conditions:               copyConditions(in.Status.Conditions),
+		LastAppliedRevision:      in.Status.LastAppliedRevision,
+		LastAttemptedRevision:    in.Status.LastAttemptedRevision,
+		HelmRelease:              in.Status.HelmRelease,
+		LastHandledRecreateToken: in.Status.LastHandledRecreateToken,
+		TargetNamespace:          in.Status.TargetNamespace,
+		ReleaseName:              in.Status.ReleaseName,
+		HelmReleaseReady:         copyBool(in.Status.HelmReleaseReady),
+		HelmRepository:           in.Status.HelmRepository,
+		ActiveHelmRepository:     in.Status.ActiveHelmRepository,
+		HelmRepositoryReady:      copyBool(in.Status.HelmRepositoryReady),
+		UpgradeFailures:          in.Status.UpgradeFailures,
+		Failures:                 in.Status.Failures,
+		InstallFailures:          in.Status.InstallFailures,
+		Summary:                  in.Status.Summary,
+		LastReconcileTime:        in.Status.LastReconcileTime.DeepCopy(),
+		LastReconcileError:       in.Status.LastReconcileError,
+		RequeueInterval:          copyDuration(in.Status.RequeueInterval),
+		ReadyDuration:            copyDuration(in.Status.ReadyDuration),
+		DesiredReplicas:          in.Status.DesiredReplicas,
+		ObservedReplicas:         in.Status.ObservedReplicas,
+		RerunMigrationSteps:      copyStrings(in.Status.RerunMigrationSteps),
+		UnreconciledFields:       copyStrings(in.Status.UnreconciledFields),
+	}
+	if l := in.Status.License; l != nil {
+		dst.Status.License = &v1alpha1.RedpandaLicenseStatus{
+			Loaded:       l.Loaded,
+			Organization: l.Organization,
+			Type:         l.Type,
+			Expiration:   l.Expiration.DeepCopy(),
+		}
+	}
+	if m := in.Status.Migration; m != nil {
+		dst.Status.Migration = &v1alpha1.RedpandaMigrationStatus{
+			Completed:      m.Completed,
+			CompletionTime: m.CompletionTime.DeepCopy(),
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (in *Redpanda) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.Redpanda)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub type %T", srcRaw)
+	}
+
+	src.ObjectMeta.DeepCopyInto(&in.ObjectMeta)
+
+	in.Spec.ChartRef = ChartRef{
+		ChartName:                src.Spec.ChartRef.ChartName,
+		ChartVersion:             src.Spec.ChartRef.ChartVersion,
+		HelmRepositoryName:       src.Spec.ChartRef.HelmRepositoryName,
+		Timeout:                  copyDuration(src.Spec.ChartRef.Timeout),
+		Approval:                 src.Spec.ChartRef.Approval,
+		RepositoryURL:            src.Spec.ChartRef.RepositoryURL,
+		RegistrySecretRef:        copyLocalObjectReference(src.Spec.ChartRef.RegistrySecretRef),
+		CABundleSecretRef:        copyLocalObjectReference(src.Spec.ChartRef.CABundleSecretRef),
+		Verify:                   src.Spec.ChartRef.Verify.DeepCopy(),
+		PostRenderers:            copyPostRenderers(src.Spec.ChartRef.PostRenderers),
+		DependsOn:                copyNamespacedObjectReferences(src.Spec.ChartRef.DependsOn),
+		WaitForSecrets:           copyLocalObjectReferences(src.Spec.ChartRef.WaitForSecrets),
+		SuspendOnCreate:          src.Spec.ChartRef.SuspendOnCreate,
+		ExistingRepositoryName:   src.Spec.ChartRef.ExistingRepositoryName,
+		LocalChartPath:           src.Spec.ChartRef.LocalChartPath,
+		ServiceAccountName:       src.Spec.ChartRef.ServiceAccountName,
+		ValuesFrom:               copyValuesReferences(src.Spec.ChartRef.ValuesFrom),
+		ReleaseName:              src.Spec.ChartRef.ReleaseName,
+		RequeueInterval:          copyDuration(src.Spec.ChartRef.RequeueInterval),
+		RawValues:                src.Spec.ChartRef.RawValues,
+		FallbackRepositoryURLs:   copyStrings(src.Spec.ChartRef.FallbackRepositoryURLs),
+		FailoverAfter:            copyDuration(src.Spec.ChartRef.FailoverAfter),
+		WaitForExternalEndpoints: src.Spec.ChartRef.WaitForExternalEndpoints,
+		DependencyVersions:       copyStringMap(src.Spec.ChartRef.DependencyVersions),
+	}
+	if u := src.Spec.ChartRef.Upgrade; u != nil {
+		in.Spec.ChartRef.Upgrade = &HelmUpgrade{
+			Remediation:    u.Remediation.DeepCopy(),
+			Force:          copyBool(u.Force),
+			PreserveValues: copyBool(u.PreserveValues),
+			CleanupOnFail:  copyBool(u.CleanupOnFail),
+		}
+	}
+	if src.Spec.ChartRef.ValuesOverlays != nil {
+		in.Spec.ChartRef.ValuesOverlays = make([]ValuesOverlay, len(src.Spec.ChartRef.ValuesOverlays))
+		for i, o := range src.Spec.ChartRef.ValuesOverlays {
+			in.Spec.ChartRef.ValuesOverlays[i] = ValuesOverlay{Kind: o.Kind, Name: o.Name, ValuesKey: o.ValuesKey}
+		}
+	}
+	if src.Spec.ChartRef.PreDeleteKinds != nil {
+		in.Spec.ChartRef.PreDeleteKinds = make([]PreDeleteKind, len(src.Spec.ChartRef.PreDeleteKinds))
+		for i, k := range src.Spec.ChartRef.PreDeleteKinds {
+			in.Spec.ChartRef.PreDeleteKinds[i] = PreDeleteKind(k)
+		}
+	}
+
+	in.Spec.ClusterSpec = src.Spec.ClusterSpec.DeepCopy()
+
+	in.Spec.Migration = nil
+	if m := src.Spec.Migration; m != nil {
+		in.Spec.Migration = &Migration{
+			Enabled:    m.Enabled,
+			ClusterRef: m.ClusterRef,
+			ConsoleRef: m.ConsoleRef,
+		}
+	}
+
+	in.Spec.TLS = nil
+	if t := src.Spec.TLS; t != nil {
+		in.Spec.TLS = &RedpandaTLS{}
+		if cm := t.CertManager; cm != nil {
+			in.Spec.TLS.CertManager = &CertManagerTLS{
+				CertName: cm.CertName,
+				DNSNames: copyStrings(cm.DNSNames),
+				Duration: copyDuration(cm.Duration),
+			}
+			if cm.IssuerRef != nil {
+				in.Spec.TLS.CertManager.IssuerRef = &IssuerRef{Name: cm.IssuerRef.Name, Kind: cm.IssuerRef.Kind}
+			}
+		}
+	}
+
+	in.Spec.NetworkPolicy = nil
+	if np := src.Spec.NetworkPolicy; np != nil {
+		in.Spec.NetworkPolicy = &NetworkPolicy{
+			AllowedNamespaces: copyStrings(np.AllowedNamespaces),
+			AllowedCIDRs:      copyStrings(np.AllowedCIDRs),
+		}
+	}
+
+	in.Spec.ConsoleTLSSecrets = copySecretReferences(src.Spec.ConsoleTLSSecrets)
+
+	in.Status = RedpandaStatus{
+		ObservedGeneration:       src.Status.ObservedGeneration,
+		ReconcileRequestStatus:   src.Status.ReconcileRequestStatus,
+		Conditions:               copyConditions(src.Status.Conditions),
+		LastAppliedRevision:      src.Status.LastAppliedRevision,
+		LastAttemptedRevision:    src.Status.LastAttemptedRevision,
+		HelmRelease:              src.Status.HelmRelease,
+		LastHandledRecreateToken: src.Status.LastHandledRecreateToken,
+		TargetNamespace:          src.Status.TargetNamespace,
+		ReleaseName:              src.Status.ReleaseName,
+		HelmReleaseReady:         copyBool(src.Status.HelmReleaseReady),
+		HelmRepository:           src.Status.HelmRepository,
+		ActiveHelmRepository:     src.Status.ActiveHelmRepository,
+		HelmRepositoryReady:      copyBool(src.Status.HelmRepositoryReady),
+		UpgradeFailures:          src.Status.UpgradeFailures,
+		Failures:                 src.Status.Failures,
+		InstallFailures:          src.Status.InstallFailures,
+		Summary:                  src.Status.Summary,
+		LastReconcileTime:        src.Status.LastReconcileTime.DeepCopy(),
+		LastReconcileError:       src.Status.LastReconcileError,
+		RequeueInterval:          copyDuration(src.Status.RequeueInterval),
+		ReadyDuration:            copyDuration(src.Status.ReadyDuration),
+		DesiredReplicas:          src.Status.DesiredReplicas,
+		ObservedReplicas:         src.Status.ObservedReplicas,
+		RerunMigrationSteps:      copyStrings(src.Status.RerunMigrationSteps),
+		UnreconciledFields:       copyStrings(src.Status.UnreconciledFields),
+	}
+	if l := src.Status.License; l != nil {
+		in.Status.License = &RedpandaLicenseStatus{
+			Loaded:       l.Loaded,
+			Organization: l.Organization,
+			Type:         l.Type,
+			Expiration:   l.Expiration.DeepCopy(),
+		}
+	}
+	if m := src.Status.Migration; m != nil {
+		in.Status.Migration = &RedpandaMigrationStatus{
+			Completed:      m.Completed,
+			CompletionTime: m.CompletionTime.DeepCopy(),
+		}
+	}
+
+	return nil
+}
+
+func copyBool(in *bool) *bool {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func copyDuration(in *metav1.Duration) *metav1.Duration {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	return append([]string{}, in...)
+}
+
+func copyStringMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func copyConditions(in []metav1.Condition) []metav1.Condition {
+	if in == nil {
+		return nil
+	}
+	out := make([]metav1.Condition, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func copyLocalObjectReference(in *meta.LocalObjectReference) *meta.LocalObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func copyLocalObjectReferences(in []meta.LocalObjectReference) []meta.LocalObjectReference {
+	if in == nil {
+		return nil
+	}
+	return append([]meta.LocalObjectReference{}, in...)
+}
+
+func copyNamespacedObjectReferences(in []meta.NamespacedObjectReference) []meta.NamespacedObjectReference {
+	if in == nil {
+		return nil
+	}
+	return append([]meta.NamespacedObjectReference{}, in...)
+}
+
+func copyValuesReferences(in []helmv2beta1.ValuesReference) []helmv2beta1.ValuesReference {
+	if in == nil {
+		return nil
+	}
+	return append([]helmv2beta1.ValuesReference{}, in...)
+}
+
+func copySecretReferences(in []corev1.SecretReference) []corev1.SecretReference {
+	if in == nil {
+		return nil
+	}
+	return append([]corev1.SecretReference{}, in...)
+}
+
+func copyPostRenderers(in []helmv2beta1.PostRenderer) []helmv2beta1.PostRenderer {
+	if in == nil {
+		return nil
+	}
+	out := make([]helmv2beta1.PostRenderer, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package v1alpha2_test
+
+import (
+	"testing"
+	"time"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/meta"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha2"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+func testRedpanda() *v1alpha1.Redpanda {
+	return &v1alpha1.Redpanda{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "redpanda",
+			Namespace:   "default",
+			Labels:      map[string]string{"team": "streaming"},
+			Annotations: map[string]string{"cluster.redpanda.com/managed": "true"},
+		},
+		Spec: v1alpha1.RedpandaSpec{
+			ChartRef: v1alpha1.ChartRef{
+				ChartName:          "redpanda",
+				ChartVersion:       "5.7.1",
+				HelmRepositoryName: "redpanda-repository",
+				Timeout:            &metav1.Duration{Duration: 10 * time.Minute},
+				Upgrade: &v1alpha1.HelmUpgrade{
+					Force:         ptr.To(true),
+					CleanupOnFail: ptr.To(false),
+				},
+				ValuesOverlays: []v1alpha1.ValuesOverlay{
+					{Kind: "ConfigMap", Name: "overrides", ValuesKey: "prod.yaml"},
+				},
+				Approval:          "required",
+				RepositoryURL:     "oci://registry.example.com/charts",
+				RegistrySecretRef: &meta.LocalObjectReference{Name: "registry"},
+				CABundleSecretRef: &meta.LocalObjectReference{Name: "registry-ca"},
+				Verify:            &helmv2beta1.HelmChartTemplateVerification{Provider: "cosign", SecretRef: &meta.LocalObjectReference{Name: "cosign-pub"}},
+				LocalChartPath:    "redpanda-5.8.0.tgz",
+				DependsOn: []meta.NamespacedObjectReference{
+					{Name: "cert-manager", Namespace: "cert-manager"},
+				},
+				WaitForSecrets:  []meta.LocalObjectReference{{Name: "sasl"}},
+				SuspendOnCreate: true,
+				ValuesFrom: []helmv2beta1.ValuesReference{
+					{Kind: "Secret", Name: "license", ValuesKey: "license", TargetPath: "enterprise.license"},
+				},
+				ReleaseName:              "redpanda-prod",
+				RequeueInterval:          &metav1.Duration{Duration: 30 * time.Second},
+				RawValues:                "tuning:\n  tune_aio_events: true\n",
+				FallbackRepositoryURLs:   []string{"oci://mirror.example.com/charts"},
+				FailoverAfter:            &metav1.Duration{Duration: 10 * time.Minute},
+				WaitForExternalEndpoints: true,
+				PreDeleteKinds:           []v1alpha1.PreDeleteKind{"Service", "StatefulSet"},
+				DependencyVersions:       map[string]string{"console": "~0.7"},
+			},
+			ClusterSpec: &v1alpha1.RedpandaClusterSpec{
+				FullNameOverride: "panda",
+				ClusterDomain:    "cluster.local",
+				CommonLabels:     map[string]string{"env": "prod"},
+				Statefulset: &v1alpha1.Statefulset{
+					Replicas: ptr.To(5),
+				},
+				Console: &v1alpha1.RedpandaConsole{
+					Enabled: ptr.To(false),
+				},
+			},
+			Migration: &v1alpha1.Migration{
+				Enabled: true,
+				ClusterRef: vectorizedv1alpha1.NamespaceNameRef{
+					Name:      "cluster",
+					Namespace: "legacy",
+				},
+				ConsoleRef: vectorizedv1alpha1.NamespaceNameRef{
+					Name:      "console",
+					Namespace: "legacy",
+				},
+			},
+			TLS: &v1alpha1.RedpandaTLS{
+				CertManager: &v1alpha1.CertManagerTLS{
+					IssuerRef: &v1alpha1.IssuerRef{Name: "letsencrypt", Kind: "ClusterIssuer"},
+					DNSNames:  []string{"redpanda.example.com"},
+				},
+			},
+			NetworkPolicy: &v1alpha1.NetworkPolicy{
+				AllowedNamespaces: []string{"clients"},
+				AllowedCIDRs:      []string{"10.0.0.0/8"},
+			},
+			ConsoleTLSSecrets: []corev1.SecretReference{{Namespace: "cert-manager", Name: "console-tls"}},
+		},
+		Status: v1alpha1.RedpandaStatus{
+			ObservedGeneration:       3,
+			HelmRelease:              "redpanda",
+			LastHandledRecreateToken: "1",
+			HelmReleaseReady:         ptr.To(true),
+			ActiveHelmRepository:     "redpanda-repository-fallback-1",
+			TargetNamespace:          "default",
+			ReleaseName:              "redpanda-prod",
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "RedpandaClusterDeployed"},
+			},
+			Summary:             "5/5 brokers ready",
+			LastReconcileTime:   &metav1.Time{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			DesiredReplicas:     5,
+			ObservedReplicas:    5,
+			RerunMigrationSteps: []string{"statefulset"},
+			UnreconciledFields:  []string{"statefulset.replicas"},
+			RequeueInterval:     &metav1.Duration{Duration: 30 * time.Second},
+			ReadyDuration:       &metav1.Duration{Duration: 3 * time.Minute},
+			License: &v1alpha1.RedpandaLicenseStatus{
+				Loaded:       true,
+				Organization: "redpanda",
+				Type:         "enterprise",
+				Expiration:   &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			Migration: &v1alpha1.RedpandaMigrationStatus{
+				Completed:      true,
+				CompletionTime: &metav1.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+}
+
+func TestRedpandaConversionRoundTrip(t *testing.T) {
+	hub := testRedpanda()
+
+	spoke := &v1alpha2.Redpanda{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+
+	assert.Equal(t, hub.Spec.ChartRef.ChartVersion, spoke.Spec.ChartRef.ChartVersion)
+	assert.Equal(t, hub.Spec.ChartRef.Approval, spoke.Spec.ChartRef.Approval)
+	assert.Equal(t, []v1alpha2.ValuesOverlay{{Kind: "ConfigMap", Name: "overrides", ValuesKey: "prod.yaml"}}, spoke.Spec.ChartRef.ValuesOverlays)
+	assert.Equal(t, hub.Spec.ClusterSpec, spoke.Spec.ClusterSpec)
+	assert.Equal(t, hub.Spec.Migration.ClusterRef, spoke.Spec.Migration.ClusterRef)
+	assert.Equal(t, &v1alpha2.IssuerRef{Name: "letsencrypt", Kind: "ClusterIssuer"}, spoke.Spec.TLS.CertManager.IssuerRef)
+	assert.Equal(t, hub.Status.Summary, spoke.Status.Summary)
+
+	back := &v1alpha1.Redpanda{}
+	require.NoError(t, spoke.ConvertTo(back))
+
+	assert.Equal(t, hub.ObjectMeta, back.ObjectMeta)
+	assert.Equal(t, hub.Spec, back.Spec)
+	assert.Equal(t, hub.Status, back.Status)
+}
+
+// TestRedpandaConversionFuzzRoundTrip converts randomly filled hub Redpandas
+// to v1alpha2 and back, so that a field added to the hub without a conversion
+// is caught.
+func TestRedpandaConversionFuzzRoundTrip(t *testing.T) {
+	f := fuzz.New().NilChance(0.2).NumElements(1, 2)
+	for i := 0; i < 100; i++ {
+		hub := &v1alpha1.Redpanda{}
+		f.Fuzz(hub)
+
+		spoke := &v1alpha2.Redpanda{}
+		require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+
+		back := &v1alpha1.Redpanda{}
+		require.NoError(t, spoke.ConvertTo(back))
+
+		require.Equal(t, hub.ObjectMeta, back.ObjectMeta)
+		require.Equal(t, hub.Spec, back.Spec)
+		require.Equal(t, hub.Status, back.Status)
+	}
+}
+
+func TestRedpandaConversionDoesNotAlias(t *testing.T) {
+	hub := testRedpanda()
+
+	spoke := &v1alpha2.Redpanda{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+
+	spoke.Spec.ClusterSpec.FullNameOverride = "changed"
+	spoke.Spec.Migration.ClusterRef.Name = "changed"
+	spoke.Spec.ChartRef.ValuesOverlays[0].Name = "changed"
+	*spoke.Spec.ChartRef.Upgrade.Force = false
+	spoke.Status.Conditions[0].Reason = "changed"
+
+	assert.Equal(t, "panda", hub.Spec.ClusterSpec.FullNameOverride)
+	assert.Equal(t, "cluster", hub.Spec.Migration.ClusterRef.Name)
+	assert.Equal(t, "overrides", hub.Spec.ChartRef.ValuesOverlays[0].Name)
+	assert.True(t, *hub.Spec.ChartRef.Upgrade.Force)
+	assert.Equal(t, "RedpandaClusterDeployed", hub.Status.Conditions[0].Reason)
+}
+
+func TestRedpandaConversionEmptySpec(t *testing.T) {
+	hub := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: "empty"}}
+
+	spoke := &v1alpha2.Redpanda{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Nil(t, spoke.Spec.ClusterSpec)
+	assert.Nil(t, spoke.Spec.Migration)
+
+	back := &v1alpha1.Redpanda{}
+	require.NoError(t, spoke.ConvertTo(back))
+	assert.Equal(t, hub, back)
+}
@@ -181,6 +181,11 @@ type ClusterSpec struct {
 
 	// The name of the ServiceAccount to be used by the Redpanda pods
 	ServiceAccount *string `json:"serviceAccount,omitempty"`
+
+	// MetricsTimeout overrides the operator --metrics-timeout flag for this
+	// cluster when checking the metrics Admin API endpoint.
+	// +optional
+	MetricsTimeout *metav1.Duration `json:"metricsTimeout,omitempty"`
 }
 
 // RestartConfig contains strategies to configure how the cluster behaves when restarting, because of upgrades
@@ -528,6 +533,10 @@ type NodesList struct {
 	ExternalPandaproxy []string              `json:"externalPandaproxy,omitempty"`
 	PandaproxyIngress  *string               `json:"pandaproxyIngress,omitempty"`
 	SchemaRegistry     *SchemaRegistryStatus `json:"schemaRegistry,omitempty"`
+	// ExternalAdminByPod holds the external admin API address of each
+	// broker by Pod name. Unlike ExternalAdmin, it does not depend on the
+	// order in which the Pods are listed.
+	ExternalAdminByPod map[string]string `json:"externalAdminByPod,omitempty"`
 }
 
 //+kubebuilder:object:root=true
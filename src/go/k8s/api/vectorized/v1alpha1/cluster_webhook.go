@@ -18,6 +18,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/go-logr/logr"
@@ -61,6 +62,10 @@ const (
 	httpBasicAuthorizationMechanism    = "http_basic"
 
 	defaultSchemaRegistryPort = 8081
+
+	// metrics timeouts outside of this range are accepted with a warning
+	minRecommendedMetricsTimeout = 500 * time.Millisecond
+	maxRecommendedMetricsTimeout = 2 * time.Minute
 )
 
 // validHostnameSegment matches valid DNS name segments.
@@ -71,6 +76,10 @@ var validHostnameSegment = regexp.MustCompile(`^([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0
 // that has previously been decommissioned can cause issues.
 var AllowDownscalingInWebhook = false
 
+// AllowDownscalingAnnotation overrides AllowDownscalingInWebhook for a single
+// Cluster when set to "true" or "false".
+const AllowDownscalingAnnotation = "operator.redpanda.com/allow-downscaling"
+
 // DefaultLicenseSecretKey is the default key required in secret referenced by `SecretKeyRef`.
 var DefaultLicenseSecretKey = "license"
 
@@ -204,11 +213,14 @@ func (r *Cluster) ValidateCreate() (admission.Warnings, error) {
 
 	allErrs := r.validateCommon(log)
 
+	metricsTimeoutErrs, warnings := r.validateMetricsTimeout()
+	allErrs = append(allErrs, metricsTimeoutErrs...)
+
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(
+	return warnings, apierrors.NewInvalid(
 		r.GroupVersionKind().GroupKind(),
 		r.Name, allErrs)
 }
@@ -236,11 +248,14 @@ func (r *Cluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 
 	allErrs = append(allErrs, r.validateLicense(oldCluster)...)
 
+	metricsTimeoutErrs, warnings := r.validateMetricsTimeout()
+	allErrs = append(allErrs, metricsTimeoutErrs...)
+
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(
+	return warnings, apierrors.NewInvalid(
 		r.GroupVersionKind().GroupKind(),
 		r.Name, allErrs)
 }
@@ -286,15 +301,45 @@ func (r *Cluster) validateScaling() field.ErrorList {
 
 func (r *Cluster) validateDownscaling(old *Cluster) field.ErrorList {
 	var allErrs field.ErrorList
-	if !AllowDownscalingInWebhook && old.Spec.Replicas != nil && r.Spec.Replicas != nil && *r.Spec.Replicas < *old.Spec.Replicas {
+	allowDownscaling := AllowDownscalingInWebhook
+	if v, ok := r.Annotations[AllowDownscalingAnnotation]; ok {
+		allowed, err := strconv.ParseBool(v)
+		if err != nil {
+			return append(allErrs,
+				field.Invalid(field.NewPath("metadata").Child("annotations").Key(AllowDownscalingAnnotation),
+					v,
+					"must be either true or false"))
+		}
+		allowDownscaling = allowed
+	}
+	if !allowDownscaling && old.Spec.Replicas != nil && r.Spec.Replicas != nil && *r.Spec.Replicas < *old.Spec.Replicas {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec").Child("replicas"),
 				r.Spec.Replicas,
-				"downscaling is not enabled: unset --allow-downscaling=false in the controller parameters to enable it"))
+				fmt.Sprintf("downscaling is not enabled: unset --allow-downscaling=false in the controller parameters or set the %s annotation to true to enable it", AllowDownscalingAnnotation)))
 	}
 	return allErrs
 }
 
+// validateMetricsTimeout rejects non positive metrics timeouts and warns about
+// values that are unlikely to be intended.
+func (r *Cluster) validateMetricsTimeout() (field.ErrorList, admission.Warnings) {
+	if r.Spec.MetricsTimeout == nil {
+		return nil, nil
+	}
+	path := field.NewPath("spec").Child("metricsTimeout")
+	timeout := r.Spec.MetricsTimeout.Duration
+	switch {
+	case timeout <= 0:
+		return field.ErrorList{field.Invalid(path, timeout.String(), "must be positive")}, nil
+	case timeout < minRecommendedMetricsTimeout:
+		return nil, admission.Warnings{fmt.Sprintf("%s of %s is very short, metrics checks may time out on healthy brokers", path, timeout)}
+	case timeout > maxRecommendedMetricsTimeout:
+		return nil, admission.Warnings{fmt.Sprintf("%s of %s is very long, a stuck broker will block reconciliation for that long", path, timeout)}
+	}
+	return nil, nil
+}
+
 func (r *Cluster) validateAdminListeners() field.ErrorList {
 	var allErrs field.ErrorList
 	externalAdmin := r.AdminAPIExternal()
@@ -15,6 +15,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/stretchr/testify/assert"
@@ -311,6 +312,71 @@ func TestValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestAllowDownscalingAnnotation(t *testing.T) {
+	defer func(v bool) { v1alpha1.AllowDownscalingInWebhook = v }(v1alpha1.AllowDownscalingInWebhook)
+
+	tests := []struct {
+		name        string
+		global      bool
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{name: "global disabled", global: false, expectErr: true},
+		{name: "global enabled", global: true, expectErr: false},
+		{name: "annotation enables", global: false, annotations: map[string]string{v1alpha1.AllowDownscalingAnnotation: "true"}, expectErr: false},
+		{name: "annotation disables", global: true, annotations: map[string]string{v1alpha1.AllowDownscalingAnnotation: "false"}, expectErr: true},
+		{name: "invalid annotation", global: true, annotations: map[string]string{v1alpha1.AllowDownscalingAnnotation: "maybe"}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1alpha1.AllowDownscalingInWebhook = tt.global
+
+			oldCluster := validRedpandaCluster()
+			oldCluster.Spec.Replicas = ptr.To(int32(3))
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Spec.Replicas = ptr.To(int32(2))
+			newCluster.Annotations = tt.annotations
+
+			_, err := newCluster.ValidateUpdate(oldCluster)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMetricsTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      *metav1.Duration
+		expectErr    bool
+		expectWarned bool
+	}{
+		{name: "unset"},
+		{name: "regular", timeout: &metav1.Duration{Duration: 10 * time.Second}},
+		{name: "zero", timeout: &metav1.Duration{}, expectErr: true},
+		{name: "negative", timeout: &metav1.Duration{Duration: -time.Second}, expectErr: true},
+		{name: "very short", timeout: &metav1.Duration{Duration: 10 * time.Millisecond}, expectWarned: true},
+		{name: "very long", timeout: &metav1.Duration{Duration: time.Hour}, expectWarned: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := validRedpandaCluster()
+			cluster.Spec.MetricsTimeout = tt.timeout
+
+			warnings, err := cluster.ValidateCreate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectWarned, len(warnings) > 0)
+		})
+	}
+}
+
 func TestNilReplicasIsNotAllowed(t *testing.T) {
 	rpCluster := validRedpandaCluster()
 	_, err := rpCluster.ValidateCreate()
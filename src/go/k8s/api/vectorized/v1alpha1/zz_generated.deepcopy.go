@@ -261,6 +261,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MetricsTimeout != nil {
+		in, out := &in.MetricsTimeout, &out.MetricsTimeout
+		*out = new(apismetav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -893,6 +898,13 @@ func (in *NodesList) DeepCopyInto(out *NodesList) {
 		*out = new(SchemaRegistryStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalAdminByPod != nil {
+		in, out := &in.ExternalAdminByPod, &out.ExternalAdminByPod
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesList.
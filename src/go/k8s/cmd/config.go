@@ -0,0 +1,118 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	flag "github.com/spf13/pflag"
+)
+
+// configPath is the path of the metrics server endpoint serving the
+// effective configuration of the operator.
+const configPath = "/config"
+
+// redactedValue replaces the values of flags that may hold secrets.
+const redactedValue = "REDACTED"
+
+// secretValueFlags are the flags of NAME=VALUE entries whose values may hold
+// secrets, only the names are served.
+var secretValueFlags = map[string]bool{
+	"configurator-env": true,
+}
+
+// infoMetricFlags are the flags exposed as labels of the info metric, named
+// after the flag with dashes replaced by underscores. The operator mode is
+// already a label of every metric of the controller-runtime registry.
+var infoMetricFlags = []string{
+	"namespace",
+	"webhook-enabled",
+	"leader-elect",
+	"safe-mode",
+	"debug",
+	"allow-pvc-deletion",
+	"unsafe-decommission-failed-brokers",
+	"restrict-redpanda-version",
+	"restrict-redpanda-selector",
+	"additional-controllers",
+	"resync-period",
+	"reconcile-timeout",
+	"decommission-wait-interval",
+	"redpanda-max-concurrent-reconciles",
+	"topic-max-concurrent-reconciles",
+}
+
+// effectiveConfig returns the value of every flag of fs by name, including
+// flags left at their default. Values that may hold secrets are redacted.
+func effectiveConfig(fs *flag.FlagSet) map[string]string {
+	config := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		config[f.Name] = redactedFlagValue(f)
+	})
+	return config
+}
+
+// redactedFlagValue returns the value of f with the values of the entries of
+// secretValueFlags and the password of URLs redacted.
+func redactedFlagValue(f *flag.Flag) string {
+	if secretValueFlags[f.Name] {
+		sv, ok := f.Value.(flag.SliceValue)
+		if !ok {
+			return redactedValue
+		}
+		var entries []string
+		for _, entry := range sv.GetSlice() {
+			name, _, _ := strings.Cut(entry, "=")
+			entries = append(entries, name+"="+redactedValue)
+		}
+		return "[" + strings.Join(entries, ",") + "]"
+	}
+	value := f.Value.String()
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return value
+}
+
+// newInfoMetric returns the redpanda_operator_info gauge, always 1, with the
+// Go version and the infoMetricFlags of config as labels.
+func newInfoMetric(config map[string]string) prometheus.Gauge {
+	labels := prometheus.Labels{"go_version": runtime.Version()}
+	for _, name := range infoMetricFlags {
+		labels[strings.ReplaceAll(name, "-", "_")] = config[name]
+	}
+	info := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "redpanda_operator_info",
+		Help:        "Configuration of the running operator, see the " + configPath + " endpoint of the metrics server for all flags",
+		ConstLabels: labels,
+	})
+	info.Set(1)
+	return info
+}
+
+// configHandler serves config as a JSON object, as returned by
+// effectiveConfig.
+func configHandler(config map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(config)
+	})
+}
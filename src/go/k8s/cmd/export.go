@@ -0,0 +1,79 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	redpandacontrollers "github.com/redpanda-data/redpanda-operator/src/go/k8s/internal/controller/redpanda"
+)
+
+// exportCommand is the subcommand writing the management state of a Redpanda
+// as YAML, e.g. for disaster recovery backups.
+const exportCommand = "export"
+
+// runExport runs the export subcommand with the given arguments and returns
+// the exit code. The cluster is reached through the KUBECONFIG environment
+// variable, ~/.kube/config or the in-cluster configuration.
+func runExport(args []string) int {
+	var name, namespace, output string
+	fs := flag.NewFlagSet(exportCommand, flag.ContinueOnError)
+	fs.StringVar(&name, "name", "", "Set the name of the Redpanda to export")
+	fs.StringVar(&namespace, "namespace", "default", "Set the namespace of the Redpanda to export")
+	fs.StringVarP(&output, "output", "o", "", "Set the file the YAML is written to. If empty, it is written to stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s --name <redpanda> [--namespace <namespace>] [--output <file>]\n\n", os.Args[0], exportCommand)
+		fmt.Fprintln(os.Stderr, "Write the Redpanda, its HelmRepositories, its HelmRelease with the rendered values and the key chart resources as YAML that can be applied to recreate them.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if name == "" {
+		fmt.Fprintln(os.Stderr, "--name is required")
+		fs.Usage()
+		return 2
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not load kubeconfig: %s\n", err)
+		return 1
+	}
+	c, err := ctrlclient.New(restConfig, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not create client: %s\n", err)
+		return 1
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not create %s: %s\n", output, err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err = redpandacontrollers.ExportManagementState(context.Background(), c, scheme, types.NamespacedName{Namespace: namespace, Name: name}, w); err != nil {
+		fmt.Fprintf(os.Stderr, "could not export redpanda '%s/%s': %s\n", namespace, name, err)
+		return 1
+	}
+	return 0
+}
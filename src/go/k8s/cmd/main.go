@@ -10,14 +10,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	cmapiv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	helmControllerAPIv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
 	helmController "github.com/fluxcd/helm-controller/shim"
@@ -29,9 +32,11 @@ import (
 	sourceControllerAPIv1 "github.com/fluxcd/source-controller/api/v1"
 	sourceControllerAPIv1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
 	helmSourceController "github.com/fluxcd/source-controller/shim"
+	"github.com/prometheus/client_golang/prometheus"
 	flag "github.com/spf13/pflag"
 	"helm.sh/helm/v3/pkg/getter"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -40,18 +45,22 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterredpandacomv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/cluster.redpanda.com/v1alpha1"
 	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	redpandav1alpha2 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha2"
 	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
 	clusterredpandacomcontrollers "github.com/redpanda-data/redpanda-operator/src/go/k8s/internal/controller/cluster.redpanda.com"
 	redpandacontrollers "github.com/redpanda-data/redpanda-operator/src/go/k8s/internal/controller/redpanda"
 	adminutils "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/admin"
 	consolepkg "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/console"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/constlabels"
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/resources"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/throttle"
 	redpandawebhooks "github.com/redpanda-data/redpanda-operator/src/go/k8s/webhooks/redpanda"
 )
 
@@ -66,6 +75,12 @@ func (r RedpandaController) toString() string {
 const (
 	defaultConfiguratorContainerImage = "vectorized/configurator"
 
+	// defaultResyncPeriod bounds how long a missed event can go unnoticed.
+	// Every resync reconciles all watched resources, so shorter periods
+	// trade API server and Admin API load for faster recovery. It matches
+	// the controller-runtime default.
+	defaultResyncPeriod = 10 * time.Hour
+
 	AllControllers         = RedpandaController("all")
 	NodeController         = RedpandaController("nodeWatcher")
 	DecommissionController = RedpandaController("decommission")
@@ -109,6 +124,7 @@ func init() {
 	utilruntime.Must(cmapiv1.AddToScheme(scheme))
 	utilruntime.Must(helmControllerAPIv2beta1.AddToScheme(scheme))
 	utilruntime.Must(redpandav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(redpandav1alpha2.AddToScheme(scheme))
 	utilruntime.Must(sourceControllerAPIv1.AddToScheme(scheme))
 	utilruntime.Must(sourceControllerAPIv1beta2.AddToScheme(scheme))
 	utilruntime.Must(vectorizedv1alpha1.AddToScheme(scheme))
@@ -121,23 +137,62 @@ func init() {
 
 //nolint:funlen,gocyclo // length looks good
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == exportCommand {
+		os.Exit(runExport(os.Args[2:]))
+	}
+
 	var (
-		clusterDomain               string
-		metricsAddr                 string
-		probeAddr                   string
-		pprofAddr                   string
-		enableLeaderElection        bool
-		webhookEnabled              bool
-		configuratorBaseImage       string
-		configuratorTag             string
-		configuratorImagePullPolicy string
-		decommissionWaitInterval    time.Duration
-		metricsTimeout              time.Duration
-		restrictToRedpandaVersion   string
-		namespace                   string
-		eventsAddr                  string
-		additionalControllers       []string
-		operatorMode                bool
+		clusterDomain                       string
+		metricsAddr                         string
+		probeAddr                           string
+		pprofAddr                           string
+		enableLeaderElection                bool
+		webhookEnabled                      bool
+		configuratorBaseImage               string
+		configuratorTag                     string
+		configuratorImageDigest             string
+		valuesPreflight                     bool
+		supportedChartVersions              string
+		redpandaNamePattern                 string
+		helmRepositorySweepInterval         time.Duration
+		webhookWarmUpGrace                  time.Duration
+		licenseCheck                        bool
+		disableMigrationOnCompletion        bool
+		safeMode                            bool
+		actOnCordonedNodes                  bool
+		cordonedNodeGracePeriod             time.Duration
+		decommissionNodeSelector            string
+		adminAPIClientFactory               string
+		configuratorImagePullPolicy         string
+		configuratorEnv                     []string
+		configuratorRequests                map[string]string
+		configuratorLimits                  map[string]string
+		decommissionWaitInterval            time.Duration
+		decommissionMaxConcurrentReconciles int
+		redpandaMaxConcurrentReconciles     int
+		topicMaxConcurrentReconciles        int
+		decommissionMaxInFlight             int
+		metricsTimeout                      time.Duration
+		reconcileTimeout                    time.Duration
+		finalizerTimeout                    time.Duration
+		staleArtifactThreshold              time.Duration
+		lastReconcileTimeRefresh            time.Duration
+		maxReplicas                         int
+		resyncPeriod                        time.Duration
+		apiThrottleWindow                   time.Duration
+		apiThrottleThreshold                int
+		apiThrottleBackoff                  time.Duration
+		localChartsDir                      string
+		injectOperatorNamespace             bool
+		restrictToRedpandaVersion           string
+		restrictToRedpandaSelector          string
+		namespace                           string
+		eventsAddr                          string
+		structuredEvents                    bool
+		eventPolicies                       map[string]string
+		hookAllowedHosts                    []string
+		additionalControllers               []string
+		operatorMode                        bool
 
 		// allowPVCDeletion controls the PVC deletion feature in the Cluster custom resource.
 		// PVCs will be deleted when its Pod has been deleted and the Node that Pod is assigned to
@@ -149,6 +204,9 @@ func main() {
 	)
 
 	flag.StringVar(&eventsAddr, "events-addr", "", "The address of the events receiver.")
+	flag.BoolVar(&structuredEvents, "structured-events", false, "Also post Redpanda events as structured JSON to the events receiver set by --events-addr")
+	flag.StringToStringVar(&eventPolicies, "event-policy", nil, "Set how Redpanda events are emitted by reason, e.g. ArtifactFailed=Drop,info=Drop,ReplicaMismatch=Normal. A policy is Normal, Warning or Drop. A policy for a severity, info or error, applies to the events of that severity without a policy for their reason. By default error events are Warning and all others Normal")
+	flag.StringSliceVar(&hookAllowedHosts, "hook-allowed-hosts", nil, "Set the hosts, optionally with a port, the lifecycle hooks set by the hook-<phase> annotations of Redpanda resources may call, e.g. hooks.example.svc,10.0.0.1:8080. Hooks to other hosts fail. If empty, no hook is called")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&pprofAddr, "pprof-bind-address", ":8082", "The address the metric endpoint binds to.")
@@ -157,15 +215,47 @@ func main() {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&webhookEnabled, "webhook-enabled", false, "Enable webhook Manager")
+	flag.DurationVar(&webhookWarmUpGrace, "webhook-warmup-grace", 0, "Set the time after startup during which the Redpanda validating webhooks admit the requests they would reject or fail to process, logging them instead, so that writes are not blocked while the operator starts. This fails open: invalid Redpandas may be admitted during that time. If set to 0, requests are always validated")
 	flag.StringVar(&configuratorBaseImage, "configurator-base-image", defaultConfiguratorContainerImage, "Set the configurator base image")
 	flag.StringVar(&configuratorTag, "configurator-tag", "latest", "Set the configurator tag")
+	flag.StringVar(&configuratorImageDigest, "configurator-image-digest", "", "Pin the configurator image by digest, e.g. sha256:<hex>, instead of --configurator-tag. Use the digest of the image index to pin multi-arch images")
 	flag.StringVar(&configuratorImagePullPolicy, "configurator-image-pull-policy", "Always", "Set the configurator image pull policy")
-	flag.DurationVar(&decommissionWaitInterval, "decommission-wait-interval", 8*time.Second, "Set the time to wait for a node decommission to happen in the cluster")
+	flag.StringArrayVar(&configuratorEnv, "configurator-env", nil, "Set an extra NAME=VALUE environment variable on the configurator container, can be repeated")
+	flag.StringToStringVar(&configuratorRequests, "configurator-resources-requests", nil, "Set the configurator container resource requests, e.g. cpu=100m,memory=64Mi. If unset, the Redpanda container resources are used")
+	flag.StringToStringVar(&configuratorLimits, "configurator-resources-limits", nil, "Set the configurator container resource limits, e.g. cpu=100m,memory=64Mi. If unset, the Redpanda container resources are used")
+	flag.DurationVar(&decommissionWaitInterval, "decommission-wait-interval", 8*time.Second, "Set the time to wait between checks of the decommission status of a node in the cluster")
+	flag.IntVar(&decommissionMaxConcurrentReconciles, "decommission-max-concurrent-reconciles", 1, "Set the maximum number of StatefulSets the decommission controller reconciles in parallel")
+	flag.IntVar(&redpandaMaxConcurrentReconciles, "redpanda-max-concurrent-reconciles", 1, "Set the maximum number of Redpanda resources reconciled in parallel. The Redpanda and Topic controllers have separate workers, so that many Topic reconciles do not delay the reconciliation of clusters. The default of 1 reconciles one cluster at a time")
+	flag.IntVar(&topicMaxConcurrentReconciles, "topic-max-concurrent-reconciles", 1, "Set the maximum number of Topic resources reconciled in parallel, independently of --redpanda-max-concurrent-reconciles. Raise it when many Topics are managed, each Topic reconcile waits on the Kafka API of its cluster")
+	flag.IntVar(&decommissionMaxInFlight, "decommission-max-in-flight", 1, "Set the maximum number of decommissions actively processed at the same time across all clusters. If set to 0, no cap is applied")
 	flag.DurationVar(&metricsTimeout, "metrics-timeout", 8*time.Second, "Set the timeout for a checking metrics Admin API endpoint. If set to 0, then the 2 seconds default will be used")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 0, "Set the maximum duration of a single Redpanda reconcile. If set to 0, no deadline is applied")
+	flag.DurationVar(&finalizerTimeout, "finalizer-timeout", 0, "Set the time the deletion of a Redpanda may take before the operator force-removes its finalizer, orphaning the resources that are left, e.g. a HelmRelease stuck terminating. The cluster.redpanda.com/finalizer-timeout annotation overrides it per Redpanda. If set to 0, the finalizer is never force-removed")
+	flag.IntVar(&maxReplicas, "max-replicas", 0, "Set the largest broker count a Redpanda may request. Larger values are rejected with the ReplicaLimitExceeded condition and not applied, guarding against accidental scale-ups. The cluster.redpanda.com/max-replicas annotation overrides it per Redpanda. If set to 0, no limit is applied")
+	flag.BoolVar(&valuesPreflight, "values-preflight", false, "Render the chart with the values of a Redpanda before updating its HelmRelease and report failures with the ValuesInvalid condition. Rendering is costly and starts once the HelmRelease fetched its chart")
+	flag.StringVar(&supportedChartVersions, "supported-chart-versions", redpandacontrollers.DefaultSupportedChartVersions, "Set the semver range of chart versions Redpanda resources may use. Other versions are rejected unless the Redpanda has the cluster.redpanda.com/allow-unsupported-chart-version annotation set to \"true\". If empty, any version is accepted")
+	flag.StringVar(&redpandaNamePattern, "redpanda-name-pattern", "", "Set a regular expression the name and clusterSpec.fullNameOverride of Redpanda resources must match, e.g. ^team-[a-z]+-[a-z0-9-]{1,20}$. Enforced by a validating webhook, requires --webhook-enabled. If empty, any name is accepted")
+	flag.DurationVar(&staleArtifactThreshold, "stale-artifact-threshold", 0, "Set the age of the HelmRepository artifact past which the StaleArtifact condition is set on the Redpanda resources using it, e.g. because the source controller stopped refreshing the repository index. It should exceed the interval of the repositories, as an unchanged index keeps its artifact. If set to 0, the age is not checked")
+	flag.DurationVar(&lastReconcileTimeRefresh, "last-reconcile-time-refresh", 5*time.Minute, "Set the age after which the lastReconcileTime of a Redpanda is written although nothing else in its status changed. Reconciles that change nothing do not write the status before that, lowering the load on the API server")
+	flag.DurationVar(&helmRepositorySweepInterval, "helm-repository-sweep-interval", 10*time.Minute, "Set the interval at which HelmRepositories left behind by deleted Redpanda resources are removed. If set to 0, no sweep is run")
+	flag.BoolVar(&licenseCheck, "license-check", false, "Report the license loaded in each Redpanda cluster in its status and set the LicenseInvalid and LicenseExpiringSoon conditions. Requires connectivity to the Admin API of the brokers")
+	flag.BoolVar(&disableMigrationOnCompletion, "disable-migration-on-completion", false, "Set spec.migration.enabled to false on Redpanda resources once their migration completed. The completion is recorded in status.migration either way, after which the migration is only run again for the steps of the cluster.redpanda.com/migration-rerun annotation")
+	flag.BoolVar(&safeMode, "safe-mode", false, "Turn destructive actions, deleting HelmReleases, PVCs of decommissioned brokers and of deleted Nodes, HelmRepositories of deleted Redpandas and resources replaced by a migration, into dry runs that are only logged and reported with events. An action is performed when the Redpanda, or the StatefulSet for PVCs of decommissioned brokers, the PVC for PVCs of deleted Nodes and the HelmRepository for HelmRepositories, has the cluster.redpanda.com/allow-destructive-actions annotation set to \"true\"")
+	flag.BoolVar(&actOnCordonedNodes, "act-on-cordoned-nodes", false, "Let the decommission and node PVC controllers act on brokers of cordoned Nodes. By default decommissions are paused while brokers run on cordoned Nodes and the deletion of the PVCs of Nodes deleted while cordoned waits for --cordoned-node-grace-period, assuming the Nodes are under maintenance")
+	flag.DurationVar(&cordonedNodeGracePeriod, "cordoned-node-grace-period", time.Hour, "Set the time the PVCs of a Node deleted while cordoned are kept for, unless --act-on-cordoned-nodes is set. They are kept for good if the Node registers again within it, otherwise the Node is assumed to be removed and its PVCs are deleted")
+	flag.StringVar(&decommissionNodeSelector, "decommission-node-selector", "", "Set a label selector, e.g. pool=redpanda,zone!=zone-c, restricting the decommission controller to StatefulSets whose brokers all run on matching Nodes, or whose pod template selects matching Nodes while no broker is scheduled. Lets several operators split the decommissions of a cluster by node pool. If empty, every StatefulSet is in scope")
+	flag.StringVar(&adminAPIClientFactory, "admin-api-client-factory", adminutils.InternalAdminAPIClientFactory, "Set how the Cluster and Console controllers reach the Admin API of the brokers: internal, through the headless Service, or external, through the addresses of the external Admin API listener reported in the Cluster status")
+	flag.DurationVar(&resyncPeriod, "resync-period", defaultResyncPeriod, "Set the period after which every watched resource is reconciled again, even without changes. Lower values recover faster from missed events at the cost of more reconciles and API server load. 0 uses the controller-runtime default")
+	flag.DurationVar(&apiThrottleWindow, "api-throttle-window", time.Minute, "Set the period over which requests rejected by the API server with 429 Too Many Requests are counted")
+	flag.IntVar(&apiThrottleThreshold, "api-throttle-threshold", 10, "Set the number of throttled requests within --api-throttle-window after which the Redpanda and Cluster reconciles are postponed by --api-throttle-backoff, so that the operator does not add to the load of the API server. If set to 0, reconciles are never postponed and throttled requests are only counted")
+	flag.DurationVar(&apiThrottleBackoff, "api-throttle-backoff", 30*time.Second, "Set the delay reconciles are postponed by while the API server throttles the requests of the operator")
+	flag.StringVar(&localChartsDir, "local-charts-dir", "", "Set the directory chartRef.localChartPath of Redpanda resources is relative to, e.g. a volume with pre-staged chart archives for offline environments. If empty, local charts are rejected")
+	flag.BoolVar(&injectOperatorNamespace, "inject-operator-namespace", false, "Pass the namespace the operator runs in to the chart of every Redpanda as the operator.namespace value, so that chart templates can refer to resources the operator provisions there. It is read from the POD_NAMESPACE environment variable or the service account of the operator, falling back to --namespace. Enabling it changes the values of, and thereby upgrades, every release")
 	flag.BoolVar(&vectorizedv1alpha1.AllowDownscalingInWebhook, "allow-downscaling", true, "Allow to reduce the number of replicas in existing clusters")
 	flag.BoolVar(&allowPVCDeletion, "allow-pvc-deletion", false, "Allow the operator to delete PVCs for Pods assigned to failed or missing Nodes (alpha feature)")
 	flag.BoolVar(&vectorizedv1alpha1.AllowConsoleAnyNamespace, "allow-console-any-ns", false, "Allow to create Console in any namespace. Allowing this copies Redpanda SchemaRegistry TLS Secret to namespace (alpha feature)")
-	flag.StringVar(&restrictToRedpandaVersion, "restrict-redpanda-version", "", "Restrict management of clusters to those with this version")
+	flag.StringVar(&restrictToRedpandaVersion, "restrict-redpanda-version", "", "Restrict management of clusters to those with this version, or within this semver range, e.g. \">=23.2.0 <24.1.0\"")
+	flag.StringVar(&restrictToRedpandaSelector, "restrict-redpanda-selector", "", "Restrict management of clusters to those with labels matching this selector, e.g. upgrade-wave in (1,2),!legacy. Combined with --restrict-redpanda-version, lets several operators split clusters of mixed versions during an upgrade. If empty, every cluster is managed")
 	flag.StringVar(&vectorizedv1alpha1.SuperUsersPrefix, "superusers-prefix", "", "Prefix to add in username of superusers managed by operator. This will only affect new clusters, enabling this will not add prefix to existing clusters (alpha feature)")
 	flag.BoolVar(&debug, "debug", false, "Set to enable debugging")
 	flag.StringVar(&namespace, "namespace", "", "If namespace is set to not empty value, it changes scope of Redpanda operator to work in single namespace")
@@ -182,17 +272,40 @@ func main() {
 
 	ctrl.SetLogger(logger.NewLogger(logOptions))
 
+	// the flags as parsed, served by the metrics server
+	effective := effectiveConfig(flag.CommandLine)
+
+	decommissionSelector, err := labels.Parse(decommissionNodeSelector)
+	if err != nil {
+		setupLog.Error(err, "Invalid --decommission-node-selector")
+		os.Exit(1)
+	}
+	restrictSelector, err := labels.Parse(restrictToRedpandaSelector)
+	if err != nil {
+		setupLog.Error(err, "Invalid --restrict-redpanda-selector")
+		os.Exit(1)
+	}
+	var restrictVersion *semver.Constraints
+	if restrictToRedpandaVersion != "" {
+		if restrictVersion, err = semver.NewConstraint(restrictToRedpandaVersion); err != nil {
+			setupLog.Error(err, "Invalid --restrict-redpanda-version")
+			os.Exit(1)
+		}
+	}
+
+	// debugMux serves pprof and, in v2 mode, the Redpanda reconcile state
+	// when debugging is enabled.
+	debugMux := http.NewServeMux()
 	if debug {
 		go func() {
-			pprofMux := http.NewServeMux()
-			pprofMux.HandleFunc("/debug/pprof/", pprof.Index)
-			pprofMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-			pprofMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-			pprofMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-			pprofMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+			debugMux.HandleFunc("/debug/pprof/", pprof.Index)
+			debugMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+			debugMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+			debugMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+			debugMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 			pprofServer := &http.Server{
 				Addr:              pprofAddr,
-				Handler:           pprofMux,
+				Handler:           debugMux,
 				ReadHeaderTimeout: 3 * time.Second,
 			}
 			log.Fatal(pprofServer.ListenAndServe())
@@ -204,17 +317,25 @@ func main() {
 
 	mgrOptions := ctrl.Options{
 		Scheme:                  scheme,
-		Metrics:                 metricsserver.Options{BindAddress: metricsAddr},
+		Metrics:                 metricsserver.Options{BindAddress: metricsAddr, ExtraHandlers: map[string]http.Handler{configPath: configHandler(effective)}},
 		HealthProbeBindAddress:  probeAddr,
 		LeaderElection:          enableLeaderElection,
 		LeaderElectionID:        "aa9fc693.vectorized.io",
 		LeaderElectionNamespace: namespace,
 	}
+	if resyncPeriod > 0 {
+		mgrOptions.Cache.SyncPeriod = &resyncPeriod
+	}
 	if namespace != "" {
 		mgrOptions.Cache.DefaultNamespaces = map[string]cache.Config{namespace: {}}
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
+	// throttled requests are counted for every client built by the manager
+	apiThrottle := throttle.NewMonitor(apiThrottleWindow, apiThrottleThreshold, apiThrottleBackoff)
+	restConfig := ctrl.GetConfigOrDie()
+	apiThrottle.WrapConfig(restConfig)
+
+	mgr, err := ctrl.NewManager(restConfig, mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager")
 		// nolint:gocritic // this exits without closing the context. That's ok.
@@ -224,8 +345,34 @@ func main() {
 	configurator := resources.ConfiguratorSettings{
 		ConfiguratorBaseImage: configuratorBaseImage,
 		ConfiguratorTag:       configuratorTag,
+		ConfiguratorDigest:    configuratorImageDigest,
 		ImagePullPolicy:       corev1.PullPolicy(configuratorImagePullPolicy),
 	}
+	if configuratorImageDigest != "" {
+		if flag.CommandLine.Changed("configurator-tag") {
+			setupLog.Error(errors.New("--configurator-tag and --configurator-image-digest are mutually exclusive"), "Invalid configurator image")
+			os.Exit(1)
+		}
+		if err = resources.ValidateImageDigest(configuratorImageDigest); err != nil {
+			setupLog.Error(err, "Invalid --configurator-image-digest")
+			os.Exit(1)
+		}
+	}
+	if configurator.Env, err = resources.ParseConfiguratorEnv(configuratorEnv); err != nil {
+		setupLog.Error(err, "Invalid --configurator-env")
+		os.Exit(1)
+	}
+	if len(configuratorRequests) > 0 || len(configuratorLimits) > 0 {
+		configurator.Resources = &corev1.ResourceRequirements{}
+		if configurator.Resources.Requests, err = resources.ParseResourceList(configuratorRequests); err != nil {
+			setupLog.Error(err, "Invalid --configurator-resources-requests")
+			os.Exit(1)
+		}
+		if configurator.Resources.Limits, err = resources.ParseResourceList(configuratorLimits); err != nil {
+			setupLog.Error(err, "Invalid --configurator-resources-limits")
+			os.Exit(1)
+		}
+	}
 
 	// init running state values if we are not in operator mode
 	operatorRunningState := ClusterControllerMode
@@ -241,31 +388,48 @@ func main() {
 		}
 	}
 
+	// dashboards compare the reconciles of the modes in mixed deployments
+	ctrlmetrics.Registry = constlabels.Wrap(ctrlmetrics.Registry, prometheus.Labels{"operator_mode": string(operatorRunningState)})
+	ctrlmetrics.Registry.MustRegister(newInfoMetric(effective))
+
+	// storageBasePath holds the chart artifacts of this instance in v2 mode
+	var storageBasePath string
+
+	adminAPIFactory, err := adminutils.AdminAPIClientFactoryByName(adminAPIClientFactory)
+	if err != nil {
+		setupLog.Error(err, "Invalid --admin-api-client-factory")
+		os.Exit(1)
+	}
+
 	// Now we start different processes depending on state
 	switch operatorRunningState {
 	case OperatorV1Mode:
 		ctrl.Log.Info("running in v1", "mode", OperatorV1Mode)
 
 		if err = (&redpandacontrollers.ClusterReconciler{
-			Client:                    mgr.GetClient(),
-			Log:                       ctrl.Log.WithName("controllers").WithName("redpanda").WithName("Cluster"),
-			Scheme:                    mgr.GetScheme(),
-			AdminAPIClientFactory:     adminutils.NewInternalAdminAPI,
-			DecommissionWaitInterval:  decommissionWaitInterval,
-			MetricsTimeout:            metricsTimeout,
-			RestrictToRedpandaVersion: restrictToRedpandaVersion,
-			GhostDecommissioning:      ghostbuster,
+			Client:                     mgr.GetClient(),
+			Log:                        ctrl.Log.WithName("controllers").WithName("redpanda").WithName("Cluster"),
+			Scheme:                     mgr.GetScheme(),
+			AdminAPIClientFactory:      adminAPIFactory,
+			DecommissionWaitInterval:   decommissionWaitInterval,
+			MetricsTimeout:             metricsTimeout,
+			RestrictToRedpandaVersion:  restrictVersion,
+			RestrictToRedpandaSelector: restrictSelector,
+			GhostDecommissioning:       ghostbuster,
+			EventRecorder:              mgr.GetEventRecorderFor("Cluster"),
+			APIThrottle:                apiThrottle,
 		}).WithClusterDomain(clusterDomain).WithConfiguratorSettings(configurator).WithAllowPVCDeletion(allowPVCDeletion).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "Cluster")
 			os.Exit(1)
 		}
 
 		if err = (&redpandacontrollers.ClusterConfigurationDriftReconciler{
-			Client:                    mgr.GetClient(),
-			Log:                       ctrl.Log.WithName("controllers").WithName("redpanda").WithName("ClusterConfigurationDrift"),
-			Scheme:                    mgr.GetScheme(),
-			AdminAPIClientFactory:     adminutils.NewInternalAdminAPI,
-			RestrictToRedpandaVersion: restrictToRedpandaVersion,
+			Client:                     mgr.GetClient(),
+			Log:                        ctrl.Log.WithName("controllers").WithName("redpanda").WithName("ClusterConfigurationDrift"),
+			Scheme:                     mgr.GetScheme(),
+			AdminAPIClientFactory:      adminAPIFactory,
+			RestrictToRedpandaVersion:  restrictVersion,
+			RestrictToRedpandaSelector: restrictSelector,
 		}).WithClusterDomain(clusterDomain).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "Unable to create controller", "controller", "ClusterConfigurationDrift")
 			os.Exit(1)
@@ -281,7 +445,7 @@ func main() {
 			Client:                  mgr.GetClient(),
 			Scheme:                  mgr.GetScheme(),
 			Log:                     ctrl.Log.WithName("controllers").WithName("redpanda").WithName("Console"),
-			AdminAPIClientFactory:   adminutils.NewInternalAdminAPI,
+			AdminAPIClientFactory:   adminAPIFactory,
 			Store:                   consolepkg.NewStore(mgr.GetClient(), mgr.GetScheme()),
 			EventRecorder:           mgr.GetEventRecorderFor("Console"),
 			KafkaAdminClientFactory: consolepkg.NewKafkaAdmin,
@@ -315,7 +479,8 @@ func main() {
 		ctrl.Log.Info("running in v2", "mode", OperatorV2Mode, "namespace", namespace)
 		storageAddr := ":9090"
 		storageAdvAddr = redpandacontrollers.DetermineAdvStorageAddr(storageAddr, setupLog)
-		storage := redpandacontrollers.MustInitStorage("/tmp", storageAdvAddr, 60*time.Second, 2, setupLog)
+		storageBasePath = redpandacontrollers.StorageBasePath(os.TempDir(), namespace, redpandacontrollers.StorageInstanceName())
+		storage := redpandacontrollers.MustInitStorage(storageBasePath, storageAdvAddr, 60*time.Second, 2, setupLog)
 
 		metricsH := helper.NewMetrics(mgr, metrics.MustMakeRecorder())
 
@@ -403,15 +568,116 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = (&redpandacontrollers.RedpandaReconciler{
-			Client:          mgr.GetClient(),
-			Scheme:          mgr.GetScheme(),
-			EventRecorder:   redpandaEventRecorder,
-			RequeueHelmDeps: 10 * time.Second,
-		}).SetupWithManager(mgr); err != nil {
+		redpandaReconciler := &redpandacontrollers.RedpandaReconciler{
+			Client:                   mgr.GetClient(),
+			Scheme:                   mgr.GetScheme(),
+			EventRecorder:            redpandaEventRecorder,
+			RequeueHelmDeps:          10 * time.Second,
+			ReconcileTimeout:         reconcileTimeout,
+			FinalizerTimeout:         finalizerTimeout,
+			StaleArtifactThreshold:   staleArtifactThreshold,
+			LastReconcileTimeRefresh: lastReconcileTimeRefresh,
+			MaxReplicas:              int32(maxReplicas),
+			MaxConcurrentReconciles:  redpandaMaxConcurrentReconciles,
+			// must match the HelmRelease controller NoCrossNamespaceRef
+			NoCrossNamespaceRef: true,
+			ValuesPreflight:     valuesPreflight,
+			LicenseCheck:        licenseCheck,
+			SafeMode:            safeMode,
+			APIThrottle:         apiThrottle,
+			LocalChartsDir:      localChartsDir,
+			StoragePath:         storageBasePath,
+			StorageAdvAddr:      storageAdvAddr,
+
+			DisableMigrationOnCompletion: disableMigrationOnCompletion,
+		}
+		// only set when given, the chart already defaults to cluster.local and
+		// adding it to the values would upgrade every release
+		if flag.CommandLine.Changed("cluster-domain") {
+			redpandaReconciler.ClusterDomain = clusterDomain
+		}
+		if injectOperatorNamespace {
+			if redpandaReconciler.OperatorNamespace, err = redpandacontrollers.DetermineOperatorNamespace(namespace); err != nil {
+				setupLog.Error(err, "Unable to determine the operator namespace for --inject-operator-namespace")
+				os.Exit(1)
+			}
+		}
+		if supportedChartVersions != "" {
+			if redpandaReconciler.SupportedChartVersions, err = semver.NewConstraint(supportedChartVersions); err != nil {
+				setupLog.Error(err, "Invalid --supported-chart-versions")
+				os.Exit(1)
+			}
+		}
+		if structuredEvents && eventsAddr != "" {
+			redpandaReconciler.StructuredEventsAddr = eventsAddr
+		}
+		redpandaReconciler.HookAllowedHosts = hookAllowedHosts
+		if redpandaReconciler.EventPolicies, err = redpandacontrollers.ParseEventPolicies(eventPolicies); err != nil {
+			setupLog.Error(err, "Invalid --event-policy")
+			os.Exit(1)
+		}
+		if err = redpandaReconciler.SetupWithManager(ctx, mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "Redpanda")
 			os.Exit(1)
 		}
+		if debug {
+			debugMux.Handle("/debug/redpanda/state", redpandaReconciler.ReconcileStateHandler())
+		}
+
+		if helmRepositorySweepInterval > 0 {
+			if err = mgr.Add(&redpandacontrollers.HelmRepositorySweeper{
+				Client:    mgr.GetClient(),
+				Interval:  helmRepositorySweepInterval,
+				Namespace: namespace,
+				SafeMode:  safeMode,
+			}); err != nil {
+				setupLog.Error(err, "unable to add HelmRepository sweeper")
+				os.Exit(1)
+			}
+		}
+
+		if webhookEnabled {
+			setupLog.Info("Setup Redpanda conversion webhook")
+			if err = (&redpandav1alpha1.Redpanda{}).SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "Unable to create webhook", "webhook", "Redpanda")
+				os.Exit(1)
+			}
+			warmUp := func(handler admission.Handler) admission.Handler {
+				if webhookWarmUpGrace <= 0 {
+					return handler
+				}
+				h := &redpandawebhooks.WarmUpHandler{
+					Handler: handler,
+					Grace:   webhookWarmUpGrace,
+					Log:     ctrl.Log.WithName("webhooks").WithName("Redpanda"),
+				}
+				// the grace period starts with the webhook server
+				if err := mgr.Add(h); err != nil {
+					setupLog.Error(err, "unable to add webhook warm-up")
+					os.Exit(1)
+				}
+				return h
+			}
+			mgr.GetWebhookServer().Register("/validate-cluster-redpanda-com-v1alpha1-redpanda", &webhook.Admission{
+				Handler: warmUp(&redpandawebhooks.RedpandaDeletionValidator{
+					Client:  mgr.GetClient(),
+					Decoder: admission.NewDecoder(scheme),
+				}),
+			})
+			var namePattern *regexp.Regexp
+			if redpandaNamePattern != "" {
+				if namePattern, err = regexp.Compile(redpandaNamePattern); err != nil {
+					setupLog.Error(err, "invalid --redpanda-name-pattern")
+					os.Exit(1)
+				}
+			}
+			mgr.GetWebhookServer().Register("/validate-cluster-redpanda-com-v1alpha1-redpanda-naming", &webhook.Admission{
+				Handler: warmUp(&redpandawebhooks.RedpandaNamingValidator{
+					Pattern: namePattern,
+					Decoder: admission.NewDecoder(scheme),
+				}),
+			})
+		}
 
 		var topicEventRecorder *events.Recorder
 		if topicEventRecorder, err = events.NewRecorder(mgr, ctrl.Log, eventsAddr, "TopicReconciler"); err != nil {
@@ -420,9 +686,11 @@ func main() {
 		}
 
 		if err = (&clusterredpandacomcontrollers.TopicReconciler{
-			Client:        mgr.GetClient(),
-			Scheme:        mgr.GetScheme(),
-			EventRecorder: topicEventRecorder,
+			Client:                  mgr.GetClient(),
+			Scheme:                  mgr.GetScheme(),
+			EventRecorder:           topicEventRecorder,
+			Namespace:               namespace,
+			MaxConcurrentReconciles: topicMaxConcurrentReconciles,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "Topic")
 			os.Exit(1)
@@ -430,8 +698,12 @@ func main() {
 
 		if runThisController(NodeController, additionalControllers) {
 			if err = (&redpandacontrollers.RedpandaNodePVCReconciler{
-				Client:       mgr.GetClient(),
-				OperatorMode: operatorMode,
+				Client:                  mgr.GetClient(),
+				OperatorMode:            operatorMode,
+				ActOnCordonedNodes:      actOnCordonedNodes,
+				CordonedNodeGracePeriod: cordonedNodeGracePeriod,
+				SafeMode:                safeMode,
+				EventRecorder:           mgr.GetEventRecorderFor("RedpandaNodePVCReconciler"),
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "RedpandaNodePVCReconciler")
 				os.Exit(1)
@@ -440,8 +712,15 @@ func main() {
 
 		if runThisController(DecommissionController, additionalControllers) {
 			if err = (&redpandacontrollers.DecommissionReconciler{
-				Client:       mgr.GetClient(),
-				OperatorMode: operatorMode,
+				Client:                   mgr.GetClient(),
+				OperatorMode:             operatorMode,
+				DecommissionWaitInterval: decommissionWaitInterval,
+				MaxConcurrentReconciles:  decommissionMaxConcurrentReconciles,
+				MaxInFlightDecommissions: decommissionMaxInFlight,
+				SafeMode:                 safeMode,
+				ActOnCordonedNodes:       actOnCordonedNodes,
+				NodeSelector:             decommissionSelector,
+				EventRecorder:            mgr.GetEventRecorderFor("DecommissionReconciler"),
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "DecommissionReconciler")
 				os.Exit(1)
@@ -456,8 +735,12 @@ func main() {
 		ctrl.Log.Info("running as a namespace controller", "mode", NamespaceControllerMode, "namespace", namespace)
 		if runThisController(NodeController, additionalControllers) {
 			if err = (&redpandacontrollers.RedpandaNodePVCReconciler{
-				Client:       mgr.GetClient(),
-				OperatorMode: operatorMode,
+				Client:                  mgr.GetClient(),
+				OperatorMode:            operatorMode,
+				ActOnCordonedNodes:      actOnCordonedNodes,
+				CordonedNodeGracePeriod: cordonedNodeGracePeriod,
+				SafeMode:                safeMode,
+				EventRecorder:           mgr.GetEventRecorderFor("RedpandaNodePVCReconciler"),
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "RedpandaNodePVCReconciler")
 				os.Exit(1)
@@ -466,8 +749,15 @@ func main() {
 
 		if runThisController(DecommissionController, additionalControllers) {
 			if err = (&redpandacontrollers.DecommissionReconciler{
-				Client:       mgr.GetClient(),
-				OperatorMode: operatorMode,
+				Client:                   mgr.GetClient(),
+				OperatorMode:             operatorMode,
+				DecommissionWaitInterval: decommissionWaitInterval,
+				MaxConcurrentReconciles:  decommissionMaxConcurrentReconciles,
+				MaxInFlightDecommissions: decommissionMaxInFlight,
+				SafeMode:                 safeMode,
+				ActOnCordonedNodes:       actOnCordonedNodes,
+				NodeSelector:             decommissionSelector,
+				EventRecorder:            mgr.GetEventRecorderFor("DecommissionReconciler"),
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "DecommissionReconciler")
 				os.Exit(1)
@@ -504,7 +794,11 @@ func main() {
 	}
 	setupLog.Info("Starting manager")
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if storageBasePath != "" {
+		redpandacontrollers.RemoveStorage(storageBasePath, setupLog)
+	}
+	if err != nil {
 		setupLog.Error(err, "Problem running manager")
 		os.Exit(1)
 	}
@@ -45,6 +45,13 @@ Create chart name and version as used by the chart label.
 {{- printf .Values.webhookSecretName }}
 {{- end }}
 
+{{/*
+failurePolicy of all the webhooks
+*/}}
+{{- define "redpanda-operator.webhook-failure-policy" -}}
+{{- .Values.webhook.failurePolicy | default "Fail" }}
+{{- end }}
+
 {{/*
 Common labels
 */}}
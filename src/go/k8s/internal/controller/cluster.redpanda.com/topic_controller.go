@@ -17,22 +17,26 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/fluxcd/pkg/apis/meta"
 	"github.com/go-logr/logr"
 	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kuberecorder "k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	v2 "sigs.k8s.io/controller-runtime/pkg/webhook/conversion/testdata/api/v2"
 
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/cluster.redpanda.com/v1alpha1"
+	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
 )
 
 const (
@@ -62,17 +66,27 @@ type TopicReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	kuberecorder.EventRecorder
+
+	// Namespace restricts the lookup of Redpanda resources backing a Topic
+	// when the operator is namespace scoped. Empty means all namespaces.
+	Namespace string
+	// MaxConcurrentReconciles is the maximum number of Topics reconciled in
+	// parallel, independently of the Redpanda controller. Zero uses the
+	// controller-runtime default of 1.
+	MaxConcurrentReconciles int
 }
 
 //+kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=topics,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=topics/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=topics/finalizers,verbs=update
+//+kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=redpandas,verbs=get;list;watch
 
 // For cluster scoped operator
 
 //+kubebuilder:rbac:groups=cluster.redpanda.com,resources=topics,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=cluster.redpanda.com,resources=topics/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cluster.redpanda.com,resources=topics/finalizers,verbs=update
+//+kubebuilder:rbac:groups=cluster.redpanda.com,resources=redpandas,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -135,6 +149,7 @@ func (r *TopicReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 func (r *TopicReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Topic{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
@@ -152,6 +167,19 @@ func (r *TopicReconciler) reconcile(ctx context.Context, topic *v1alpha1.Topic,
 		l.V(TraceLevel).Info("bump observed generation", "observed generation", topic.Generation)
 	}
 
+	if topic.ObjectMeta.DeletionTimestamp.IsZero() {
+		msg, err := r.clusterNotReadyMessage(ctx, topic)
+		if err != nil {
+			return v1alpha1.TopicFailed(topic), ctrl.Result{}, err
+		}
+		if msg != "" {
+			l.V(DebugLevel).Info(msg)
+			// requeue through the rate limiter to back off while the cluster starts
+			return v1alpha1.TopicWaitingForCluster(topic, msg), ctrl.Result{Requeue: true}, nil
+		}
+		topic = v1alpha1.TopicClusterReady(topic)
+	}
+
 	kafkaClient, err := r.createKafkaClient(ctx, topic, l)
 	if err != nil {
 		return v1alpha1.TopicFailed(topic), ctrl.Result{}, err
@@ -227,6 +255,43 @@ func (r *TopicReconciler) reconcile(ctx context.Context, topic *v1alpha1.Topic,
 	return r.successfulTopicReconciliation(topic), ctrl.Result{RequeueAfter: interval.Duration}, nil
 }
 
+// clusterNotReadyMessage returns a message naming the Redpanda the Topic
+// brokers point at when that Redpanda is not ready yet, or an empty string.
+// Topics pointing at clusters not managed by a Redpanda resource are never
+// considered waiting.
+func (r *TopicReconciler) clusterNotReadyMessage(ctx context.Context, topic *v1alpha1.Topic) (string, error) {
+	if topic.Spec.KafkaAPISpec == nil {
+		return "", nil
+	}
+
+	var opts []client.ListOption
+	if r.Namespace != "" {
+		opts = append(opts, client.InNamespace(r.Namespace))
+	}
+
+	var rps redpandav1alpha1.RedpandaList
+	if err := r.Client.List(ctx, &rps, opts...); err != nil {
+		if runtime.IsNotRegisteredError(err) || apimeta.IsNoMatchError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("listing redpandas: %w", err)
+	}
+
+	for i := range rps.Items {
+		rp := &rps.Items[i]
+		for _, broker := range topic.Spec.KafkaAPISpec.Brokers {
+			if !rp.IsBrokerAddress(broker, topic.Namespace) {
+				continue
+			}
+			if apimeta.IsStatusConditionTrue(rp.Status.Conditions, meta.ReadyCondition) {
+				return "", nil
+			}
+			return fmt.Sprintf("waiting for redpanda %s/%s to become ready", rp.Namespace, rp.Name), nil
+		}
+	}
+	return "", nil
+}
+
 func (r *TopicReconciler) successfulTopicReconciliation(topic *v1alpha1.Topic) *v1alpha1.Topic {
 	if r.EventRecorder != nil {
 		r.EventRecorder.AnnotatedEventf(topic,
@@ -2,10 +2,12 @@ package clusterredpandacom_test
 
 import (
 	"context"
+	"net"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/fluxcd/pkg/apis/meta"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
@@ -14,6 +16,7 @@ import (
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -22,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/cluster.redpanda.com/v1alpha1"
+	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
 	clusterredpandacom "github.com/redpanda-data/redpanda-operator/src/go/k8s/internal/controller/cluster.redpanda.com"
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/internal/testutils"
 )
@@ -37,6 +41,8 @@ func TestReconcile(t *testing.T) { // nolint:funlen // These tests have clear su
 
 	err = v1alpha1.AddToScheme(scheme.Scheme)
 	require.NoError(t, err)
+	err = redpandav1alpha1.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
 
 	c, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	require.NoError(t, err)
@@ -886,4 +892,154 @@ func TestReconcile(t *testing.T) { // nolint:funlen // These tests have clear su
 		assert.False(t, result.Requeue)
 		assert.Equal(t, time.Duration(0), result.RequeueAfter)
 	})
+	t.Run("redpanda_not_ready", func(t *testing.T) {
+		topicName := "not-ready-redpanda-topic"
+
+		rp := redpandav1alpha1.Redpanda{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "not-ready-cluster",
+				Namespace: testNamespace,
+			},
+		}
+		require.NoError(t, c.Create(ctx, &rp))
+		t.Cleanup(func() {
+			_ = c.Delete(context.Background(), &rp)
+		})
+
+		testTopic := v1alpha1.Topic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      topicName,
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.TopicSpec{
+				KafkaAPISpec: &v1alpha1.KafkaAPISpec{
+					Brokers: []string{"not-ready-cluster.default.svc.cluster.local.:9093"},
+				},
+			},
+		}
+		require.NoError(t, c.Create(ctx, &testTopic))
+
+		req := ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      topicName,
+				Namespace: testNamespace,
+			},
+		}
+		result, err := tr.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.True(t, result.Requeue)
+
+		require.NoError(t, c.Get(ctx, req.NamespacedName, &testTopic))
+		cond := apimeta.FindStatusCondition(testTopic.Status.Conditions, v1alpha1.WaitingForClusterCondition)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, v1alpha1.WaitingForClusterReason, cond.Reason)
+		assert.Contains(t, cond.Message, "default/not-ready-cluster")
+	})
+	t.Run("redpanda_ready", func(t *testing.T) {
+		topicName := "ready-redpanda-topic"
+
+		// the Redpanda is named after the seed broker host, so the Topic
+		// brokers resolve to it while still reaching the test container
+		host, _, err := net.SplitHostPort(seedBroker)
+		require.NoError(t, err)
+
+		rp := redpandav1alpha1.Redpanda{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ready-cluster",
+				Namespace: testNamespace,
+			},
+			Spec: redpandav1alpha1.RedpandaSpec{
+				ClusterSpec: &redpandav1alpha1.RedpandaClusterSpec{
+					FullNameOverride: host,
+				},
+			},
+		}
+		require.NoError(t, c.Create(ctx, &rp))
+		t.Cleanup(func() {
+			_ = c.Delete(context.Background(), &rp)
+		})
+
+		testTopic := v1alpha1.Topic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      topicName,
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.TopicSpec{
+				KafkaAPISpec: &v1alpha1.KafkaAPISpec{
+					Brokers: []string{seedBroker},
+				},
+				SynchronizationInterval: &metav1.Duration{Duration: time.Second * 5},
+			},
+		}
+		require.NoError(t, c.Create(ctx, &testTopic))
+
+		req := ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      topicName,
+				Namespace: testNamespace,
+			},
+		}
+		result, err := tr.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.True(t, result.Requeue)
+
+		apimeta.SetStatusCondition(&rp.Status.Conditions, metav1.Condition{
+			Type:   meta.ReadyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "RedpandaClusterDeployed",
+		})
+		require.NoError(t, c.Status().Update(ctx, &rp))
+
+		result, err = tr.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.False(t, result.Requeue)
+		assert.Equal(t, time.Second*5, result.RequeueAfter)
+
+		require.NoError(t, c.Get(ctx, req.NamespacedName, &testTopic))
+		assert.Nil(t, apimeta.FindStatusCondition(testTopic.Status.Conditions, v1alpha1.WaitingForClusterCondition))
+		assert.True(t, apimeta.IsStatusConditionTrue(testTopic.Status.Conditions, v1alpha1.ReadyCondition))
+	})
+	t.Run("unmanaged_broker_address", func(t *testing.T) {
+		topicName := "unmanaged-broker-topic"
+
+		rp := redpandav1alpha1.Redpanda{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "unrelated-cluster",
+				Namespace: testNamespace,
+			},
+		}
+		require.NoError(t, c.Create(ctx, &rp))
+		t.Cleanup(func() {
+			_ = c.Delete(context.Background(), &rp)
+		})
+
+		testTopic := v1alpha1.Topic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      topicName,
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.TopicSpec{
+				KafkaAPISpec: &v1alpha1.KafkaAPISpec{
+					Brokers: []string{seedBroker},
+				},
+				SynchronizationInterval: &metav1.Duration{Duration: time.Second * 5},
+			},
+		}
+		require.NoError(t, c.Create(ctx, &testTopic))
+
+		req := ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      topicName,
+				Namespace: testNamespace,
+			},
+		}
+		result, err := tr.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.False(t, result.Requeue)
+		assert.Equal(t, time.Second*5, result.RequeueAfter)
+
+		require.NoError(t, c.Get(ctx, req.NamespacedName, &testTopic))
+		assert.Nil(t, apimeta.FindStatusCondition(testTopic.Status.Conditions, v1alpha1.WaitingForClusterCondition))
+	})
 }
@@ -19,14 +19,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/fluxcd/pkg/runtime/logger"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -45,6 +48,7 @@ import (
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/networking"
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/resources"
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/resources/featuregates"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/throttle"
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/utils"
 )
 
@@ -65,16 +69,21 @@ var (
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
 	client.Client
-	Log                       logr.Logger
-	configuratorSettings      resources.ConfiguratorSettings
-	clusterDomain             string
-	Scheme                    *runtime.Scheme
-	AdminAPIClientFactory     adminutils.AdminAPIClientFactory
-	DecommissionWaitInterval  time.Duration
-	MetricsTimeout            time.Duration
-	RestrictToRedpandaVersion string
-	allowPVCDeletion          bool
-	GhostDecommissioning      bool
+	Log                        logr.Logger
+	configuratorSettings       resources.ConfiguratorSettings
+	clusterDomain              string
+	Scheme                     *runtime.Scheme
+	AdminAPIClientFactory      adminutils.AdminAPIClientFactory
+	DecommissionWaitInterval   time.Duration
+	MetricsTimeout             time.Duration
+	RestrictToRedpandaVersion  *semver.Constraints
+	RestrictToRedpandaSelector k8slabels.Selector
+	allowPVCDeletion           bool
+	GhostDecommissioning       bool
+	EventRecorder              record.EventRecorder
+	// APIThrottle postpones reconciles while the API server throttles the
+	// requests of the operator. Nil never postpones.
+	APIThrottle *throttle.Monitor
 }
 
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
@@ -113,6 +122,11 @@ func (r *ClusterReconciler) Reconcile(
 	defer done()
 	log := ctrl.LoggerFrom(ctx).WithName("ClusterReconciler.Reconcile")
 
+	if backoff := r.APIThrottle.Backoff(); backoff > 0 {
+		log.Info(fmt.Sprintf("API server is throttling requests, postponing reconcile by %s", backoff))
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
 	log.Info("Starting reconcile loop")
 	defer log.Info("Finished reconcile loop")
 
@@ -131,7 +145,7 @@ func (r *ClusterReconciler) Reconcile(
 		return ctrl.Result{}, fmt.Errorf("unable to retrieve Cluster resource: %w", err)
 	}
 
-	isManaged := isRedpandaClusterManaged(log, &vectorizedCluster) && isRedpandaClusterVersionManaged(log, &vectorizedCluster, r.RestrictToRedpandaVersion)
+	isManaged := isRedpandaClusterManaged(log, &vectorizedCluster) && isRedpandaClusterVersionManaged(log, &vectorizedCluster, r.RestrictToRedpandaVersion, r.RestrictToRedpandaSelector)
 
 	// if the cluster is being deleted, or is no longer managed by the controller,
 	// delete the finalizers from the Cluster and its Pods
@@ -205,6 +219,8 @@ func (r *ClusterReconciler) Reconcile(
 		return result, errs
 	}
 
+	r.checkSuperUsersPrefix(ctx, log, &vectorizedCluster, ar.getProxySuperuser(), ar.getSchemaRegistrySuperUser())
+
 	adminAPI, err := r.AdminAPIClientFactory(ctx, r.Client, &vectorizedCluster, ar.getHeadlessServiceFQDN(), pki.AdminAPIConfigProvider())
 	if err != nil && !errors.Is(err, &adminutils.NoInternalAdminAPI{}) {
 		return ctrl.Result{}, fmt.Errorf("creating admin api client: %w", err)
@@ -683,6 +699,7 @@ func statusShouldBeUpdated(
 		(!reflect.DeepEqual(nodeList.Internal, status.Nodes.Internal) ||
 			!reflect.DeepEqual(nodeList.External, status.Nodes.External) ||
 			!reflect.DeepEqual(nodeList.ExternalAdmin, status.Nodes.ExternalAdmin) ||
+			!reflect.DeepEqual(nodeList.ExternalAdminByPod, status.Nodes.ExternalAdminByPod) ||
 			!reflect.DeepEqual(nodeList.ExternalPandaproxy, status.Nodes.ExternalPandaproxy) ||
 			!reflect.DeepEqual(nodeList.SchemaRegistry, status.Nodes.SchemaRegistry) ||
 			!reflect.DeepEqual(nodeList.ExternalBootstrap, status.Nodes.ExternalBootstrap)) ||
@@ -797,6 +814,9 @@ func (r *ClusterReconciler) createExternalNodesList(
 			ExternalNodeIPs: make([]string, 0, len(pods)),
 		},
 	}
+	if externalAdminListener != nil {
+		result.ExternalAdminByPod = make(map[string]string, len(pods))
+	}
 
 	for i := range pods {
 		pod := pods[i]
@@ -830,12 +850,14 @@ func (r *ClusterReconciler) createExternalNodesList(
 				return nil, err
 			}
 			result.ExternalAdmin = append(result.ExternalAdmin, address)
+			result.ExternalAdminByPod[pod.Name] = address
 		} else if externalAdminListener != nil {
-			result.ExternalAdmin = append(result.ExternalAdmin,
-				fmt.Sprintf("%s:%d",
-					getExternalIP(&node),
-					getNodePort(&nodePortSvc, resources.AdminPortExternalName),
-				))
+			address := fmt.Sprintf("%s:%d",
+				getExternalIP(&node),
+				getNodePort(&nodePortSvc, resources.AdminPortExternalName),
+			)
+			result.ExternalAdmin = append(result.ExternalAdmin, address)
+			result.ExternalAdminByPod[pod.Name] = address
 		}
 
 		if externalProxyListener != nil && len(externalProxyListener.External.Subdomain) > 0 {
@@ -1199,15 +1221,36 @@ func isRedpandaClusterManaged(
 	return true
 }
 
+// isRedpandaClusterVersionManaged reports whether the cluster is within the
+// management restriction of the operator: its labels must match
+// restrictToRedpandaSelector, when set, and its version must satisfy
+// restrictToRedpandaVersion, either an exact version or a semver range such
+// as ">=23.2.0 <24.1.0", when set. This lets several operators split the
+// clusters of mixed versions during an upgrade.
 func isRedpandaClusterVersionManaged(
 	l logr.Logger,
 	redpandaCluster *vectorizedv1alpha1.Cluster,
-	restrictToRedpandaVersion string,
+	restrictToRedpandaVersion *semver.Constraints,
+	restrictToRedpandaSelector k8slabels.Selector,
 ) bool {
-	log := l.WithName("isRedpandaClusterVersionManaged").WithValues("restrictToRedpandaVersion", restrictToRedpandaVersion, "cluster spec.version", redpandaCluster.Status.Version)
-	if restrictToRedpandaVersion != "" && restrictToRedpandaVersion != redpandaCluster.Spec.Version {
-		log.Info("not managed due to version management restriction")
+	log := l.WithName("isRedpandaClusterVersionManaged").WithValues("cluster spec.version", redpandaCluster.Status.Version)
+	if restrictToRedpandaSelector != nil && !restrictToRedpandaSelector.Matches(k8slabels.Set(redpandaCluster.Labels)) {
+		log.Info("not managed due to label selector management restriction", "restrictToRedpandaSelector", restrictToRedpandaSelector.String())
+		return false
+	}
+	if restrictToRedpandaVersion != nil && !redpandaVersionMatches(restrictToRedpandaVersion, redpandaCluster.Spec.Version) {
+		log.Info("not managed due to version management restriction", "restrictToRedpandaVersion", restrictToRedpandaVersion.String())
 		return false
 	}
 	return true
 }
+
+// redpandaVersionMatches reports whether version satisfies the restriction.
+// Versions that are not semver, e.g. "dev", never match.
+func redpandaVersionMatches(restriction *semver.Constraints, version string) bool {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	return restriction.Check(v)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
@@ -374,10 +375,19 @@ func (a *attachedResources) statefulSet() error {
 		a.reconciler.AdminAPIClientFactory,
 		a.reconciler.DecommissionWaitInterval,
 		a.log,
-		a.reconciler.MetricsTimeout)
+		a.getMetricsTimeout())
 	return nil
 }
 
+// getMetricsTimeout returns the cluster metrics timeout override, falling
+// back to the operator wide setting.
+func (a *attachedResources) getMetricsTimeout() time.Duration {
+	if a.cluster.Spec.MetricsTimeout != nil {
+		return a.cluster.Spec.MetricsTimeout.Duration
+	}
+	return a.reconciler.MetricsTimeout
+}
+
 func (a *attachedResources) getStatefulSet() (*resources.StatefulSetResource, error) {
 	if err := a.statefulSet(); err != nil {
 		return nil, err
@@ -14,10 +14,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/fluxcd/pkg/runtime/logger"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -40,12 +42,13 @@ const (
 // ClusterConfigurationDriftReconciler detects drifts in the cluster configuration and triggers a reconciliation.
 type ClusterConfigurationDriftReconciler struct {
 	client.Client
-	Log                       logr.Logger
-	clusterDomain             string
-	Scheme                    *runtime.Scheme
-	DriftCheckPeriod          *time.Duration
-	AdminAPIClientFactory     adminutils.AdminAPIClientFactory
-	RestrictToRedpandaVersion string
+	Log                        logr.Logger
+	clusterDomain              string
+	Scheme                     *runtime.Scheme
+	DriftCheckPeriod           *time.Duration
+	AdminAPIClientFactory      adminutils.AdminAPIClientFactory
+	RestrictToRedpandaVersion  *semver.Constraints
+	RestrictToRedpandaSelector labels.Selector
 }
 
 // Reconcile detects drift in configuration for clusters and schedules a patch.
@@ -81,7 +84,7 @@ func (r *ClusterConfigurationDriftReconciler) Reconcile(
 	if !isRedpandaClusterManaged(log, &redpandaCluster) {
 		return ctrl.Result{RequeueAfter: r.getDriftCheckPeriod()}, nil
 	}
-	if !isRedpandaClusterVersionManaged(log, &redpandaCluster, r.RestrictToRedpandaVersion) {
+	if !isRedpandaClusterVersionManaged(log, &redpandaCluster, r.RestrictToRedpandaVersion, r.RestrictToRedpandaSelector) {
 		return ctrl.Result{}, nil
 	}
 
@@ -0,0 +1,78 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/resources"
+)
+
+const (
+	// SuperUsersPrefixMismatchReason is the event reason used when the configured
+	// --superusers-prefix does not match the username of an existing superuser.
+	SuperUsersPrefixMismatchReason = "SuperUsersPrefixMismatch"
+
+	// SuperUsersPrefixWarnedAnnotation is set on a superuser Secret once the
+	// mismatch with the expected username, its value, has been reported.
+	SuperUsersPrefixWarnedAnnotation = "operator.redpanda.com/superusers-prefix-warned"
+)
+
+// checkSuperUsersPrefix warns when the username stored in an existing
+// superuser Secret does not match the one derived from the current
+// --superusers-prefix flag. Existing users are never renamed, so the prefix
+// only applies to clusters created after it was set. Each mismatch is
+// reported once, tracked by SuperUsersPrefixWarnedAnnotation on the Secret.
+func (r *ClusterReconciler) checkSuperUsersPrefix(
+	ctx context.Context,
+	log logr.Logger,
+	cluster *vectorizedv1alpha1.Cluster,
+	superUsers ...*resources.SuperUsersResource,
+) {
+	for _, su := range superUsers {
+		if su == nil {
+			continue
+		}
+		secret, err := su.AppliedSecret(ctx)
+		if err != nil {
+			log.Error(err, "checking superuser prefix")
+			continue
+		}
+		if secret == nil {
+			continue
+		}
+		applied := string(secret.Data[corev1.BasicAuthUsernameKey])
+		if applied == "" || applied == su.GetUsername() || secret.Annotations[SuperUsersPrefixWarnedAnnotation] == su.GetUsername() {
+			continue
+		}
+
+		msg := fmt.Sprintf("superuser Secret %s holds username %q but --superusers-prefix=%q expects %q; existing users are not renamed, the prefix only applies to new clusters",
+			su.Key(), applied, vectorizedv1alpha1.SuperUsersPrefix, su.GetUsername())
+		log.Info(msg)
+		if r.EventRecorder != nil {
+			r.EventRecorder.Event(cluster, corev1.EventTypeWarning, SuperUsersPrefixMismatchReason, msg)
+		}
+
+		patch := client.MergeFrom(secret.DeepCopy())
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[SuperUsersPrefixWarnedAnnotation] = su.GetUsername()
+		if err := r.Patch(ctx, secret, patch); err != nil {
+			log.Error(err, "marking superuser prefix mismatch as reported", "secret", su.Key())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/resources"
+)
+
+func TestCheckSuperUsersPrefix(t *testing.T) {
+	prefix := vectorizedv1alpha1.SuperUsersPrefix
+	vectorizedv1alpha1.SuperUsersPrefix = "operator-"
+	t.Cleanup(func() { vectorizedv1alpha1.SuperUsersPrefix = prefix })
+
+	tests := []struct {
+		name         string
+		username     string
+		expectEvents int
+	}{
+		{name: "mismatch", username: resources.ScramPandaproxyUsername, expectEvents: 1},
+		{name: "match", username: "operator-" + resources.ScramPandaproxyUsername},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, vectorizedv1alpha1.AddToScheme(scheme))
+
+			cluster := &vectorizedv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+			su := resources.NewSuperUsers(c, cluster, scheme, resources.ScramPandaproxyUsername, resources.PandaProxySuffix, logr.Discard())
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: su.Key().Name, Namespace: su.Key().Namespace},
+				Data:       map[string][]byte{corev1.BasicAuthUsernameKey: []byte(tt.username)},
+			}
+			require.NoError(t, c.Create(context.Background(), secret))
+
+			recorder := record.NewFakeRecorder(10)
+			r := &ClusterReconciler{Client: c, EventRecorder: recorder}
+
+			// reconciling repeatedly reports a mismatch only once
+			for i := 0; i < 3; i++ {
+				r.checkSuperUsersPrefix(context.Background(), logr.Discard(), cluster, su, nil)
+			}
+			assert.Len(t, recorder.Events, tt.expectEvents)
+			if tt.expectEvents > 0 {
+				assert.Contains(t, <-recorder.Events, SuperUsersPrefixMismatchReason)
+			}
+
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(secret), secret))
+			if tt.expectEvents > 0 {
+				assert.Equal(t, su.GetUsername(), secret.Annotations[SuperUsersPrefixWarnedAnnotation])
+			} else {
+				assert.NotContains(t, secret.Annotations, SuperUsersPrefixWarnedAnnotation)
+			}
+		})
+	}
+}
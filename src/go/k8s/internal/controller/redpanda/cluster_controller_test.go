@@ -0,0 +1,60 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+func TestIsRedpandaClusterVersionManaged(t *testing.T) {
+	tests := []struct {
+		name        string
+		restriction string
+		selector    string
+		version     string
+		labels      map[string]string
+		managed     bool
+	}{
+		{name: "no restriction", version: "v23.2.1", managed: true},
+		{name: "exact version", restriction: "v23.2.1", version: "v23.2.1", managed: true},
+		{name: "other version", restriction: "v23.2.1", version: "v23.2.2"},
+		{name: "non semver version", restriction: ">=23.2.0", version: "dev"},
+		{name: "within range", restriction: ">=23.2.0 <24.1.0", version: "v23.3.5", managed: true},
+		{name: "outside range", restriction: ">=23.2.0 <24.1.0", version: "v24.1.0"},
+		{name: "matching selector", selector: "upgrade-wave in (1,2),!legacy", version: "v23.2.1", labels: map[string]string{"upgrade-wave": "1"}, managed: true},
+		{name: "excluded by selector", selector: "upgrade-wave in (1,2),!legacy", version: "v23.2.1", labels: map[string]string{"upgrade-wave": "1", "legacy": "true"}},
+		{name: "selector and range", restriction: "^23.2", selector: "upgrade-wave=2", version: "v24.1.0", labels: map[string]string{"upgrade-wave": "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := k8slabels.Parse(tt.selector)
+			require.NoError(t, err)
+			var restriction *semver.Constraints
+			if tt.restriction != "" {
+				restriction, err = semver.NewConstraint(tt.restriction)
+				require.NoError(t, err)
+			}
+			cluster := &vectorizedv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default", Labels: tt.labels},
+				Spec:       vectorizedv1alpha1.ClusterSpec{Version: tt.version},
+			}
+			assert.Equal(t, tt.managed, isRedpandaClusterVersionManaged(logr.Discard(), cluster, restriction, selector))
+		})
+	}
+}
@@ -47,11 +47,24 @@ var (
 			Help: "Number of Redpanda clusters having configuration problems",
 		}, []string{"reason"},
 	)
+	decommissionThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redpanda_decommission_throttled_total",
+			Help: "Number of times a decommission was postponed because the in-flight decommission cap was reached",
+		}, []string{"statefulset"},
+	)
+	redpandaTimeToReady = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "redpanda_time_to_ready_seconds",
+			Help:    "Duration from the creation of a Redpanda to the first time it became ready",
+			Buckets: prometheus.ExponentialBuckets(30, 2, 10),
+		},
+	)
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(redpandaClusters, desireRedpandaNodes, actualRedpandaNodes, misconfiguredClusters)
+	metrics.Registry.MustRegister(redpandaClusters, desireRedpandaNodes, actualRedpandaNodes, misconfiguredClusters, decommissionThrottled, redpandaTimeToReady)
 }
 
 // ClusterMetricController provides metrics for nodes and cluster
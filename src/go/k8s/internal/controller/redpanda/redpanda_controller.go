@@ -17,8 +17,10 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
 	"github.com/fluxcd/pkg/apis/meta"
 	"github.com/fluxcd/pkg/runtime/logger"
@@ -29,20 +31,28 @@ import (
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	kuberecorder "k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	v2 "sigs.k8s.io/controller-runtime/pkg/webhook/conversion/testdata/api/v2"
 
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
 	vectorzied_v1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+	adminutils "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/admin"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/throttle"
 )
 
 const (
@@ -53,6 +63,221 @@ const (
 	resourceTypeHelmRelease    = "HelmRelease"
 
 	managedPath = "/managed"
+
+	// PendingApprovalCondition is set when a chart version change waits for
+	// the approve-upgrade annotation.
+	PendingApprovalCondition = "PendingApproval"
+	// approveUpgradePath is the annotation path holding the approved chart version.
+	approveUpgradePath = "/approve-upgrade"
+	// approvalRequired is the ChartRef.Approval value that gates chart version changes.
+	approvalRequired = "required"
+
+	// DowngradeBlockedCondition is set when the desired chart version is lower
+	// than the deployed one and the downgrade has not been allowed.
+	DowngradeBlockedCondition = "DowngradeBlocked"
+	// allowDowngradePath is the annotation path that, when set to "true",
+	// allows the chart version to be lowered.
+	allowDowngradePath = "/allow-downgrade"
+
+	// OwnershipLostCondition is set when the managed HelmRelease no longer
+	// references its Redpanda as owner and re-asserting it is disabled.
+	OwnershipLostCondition = "OwnershipLost"
+	// reassertOwnershipPath is the annotation path that, when set to "false",
+	// stops the operator from restoring the HelmRelease owner reference.
+	reassertOwnershipPath = "/reassert-ownership"
+
+	// TeardownCondition reflects the progress of a teardown requested with
+	// the teardown annotation.
+	TeardownCondition = "Teardown"
+	// teardownPath is the annotation path that, when set to "true", removes
+	// the HelmRelease while keeping the Redpanda resource.
+	teardownPath = "/teardown"
+
+	// resumePath is the annotation path that, when set to "true", resumes a
+	// HelmRelease created suspended because of ChartRef.SuspendOnCreate.
+	resumePath = "/resume"
+
+	// WaitingForSecretCondition is set while Secrets the HelmRelease depends
+	// on do not exist yet.
+	WaitingForSecretCondition = "WaitingForSecret"
+	// WaitingForCertificateCondition is set while cert-manager Certificates
+	// issuing Secrets the HelmRelease depends on are not ready.
+	WaitingForCertificateCondition = "WaitingForCertificate"
+
+	// ReleaseNameCollisionCondition is set when another Redpanda of the
+	// namespace deploys the same Helm release or chart resources of the same
+	// name, the HelmRelease is then not written.
+	ReleaseNameCollisionCondition = "ReleaseNameCollision"
+
+	// MigrationConflictCondition is set when migration is enabled on a
+	// Redpanda whose HelmRelease already exists.
+	MigrationConflictCondition = "MigrationConflict"
+
+	// DualOwnershipCondition is set while the v1 Cluster a Redpanda migrates
+	// from is still reconciled by the Cluster controller. Migration and the
+	// HelmRelease wait for it, so that both controllers do not write the same
+	// resources.
+	DualOwnershipCondition = "DualOwnership"
+
+	// materialChangesOnlyPath is the annotation path that, when set to "true",
+	// limits HelmRelease updates to changes of the values SHA, chart version
+	// or chart source.
+	materialChangesOnlyPath = "/material-changes-only"
+	// valuesSHAPath is the HelmRelease annotation path holding the SHA of the
+	// values it was rendered with.
+	valuesSHAPath = "/values-sha"
+	// valuesFromSHAPath is the HelmRelease annotation path holding the SHA of
+	// the data referenced in valuesFrom.
+	valuesFromSHAPath = "/values-from-sha"
+
+	// suppressEventsPath is the annotation path holding a comma separated list
+	// of reason=duration pairs, e.g. "ArtifactFailed=10m". Events with a listed
+	// reason are not emitted until the duration has passed since the creation
+	// of the Redpanda. Conditions are not affected.
+	suppressEventsPath = "/suppress-events"
+
+	// ValuesInvalidCondition is set when the values pre-flight fails to
+	// render the chart with the values of the Redpanda.
+	ValuesInvalidCondition = "ValuesInvalid"
+
+	// UnsupportedChartVersionCondition is set when the chart version is
+	// outside of the supported range.
+	UnsupportedChartVersionCondition = "UnsupportedChartVersion"
+	// allowUnsupportedChartPath is the annotation path that, when set to
+	// "true", accepts chart versions outside of the supported range.
+	allowUnsupportedChartPath = "/allow-unsupported-chart-version"
+
+	// ReplicaMismatchCondition is set when the ready brokers of the
+	// StatefulSet disagree with the requested replicas for longer than the
+	// grace period.
+	ReplicaMismatchCondition = "ReplicaMismatch"
+
+	// defaultReplicaMismatchGracePeriod is the time a replica mismatch is
+	// tolerated before it is reported, covering regular rolling restarts and
+	// scale operations.
+	defaultReplicaMismatchGracePeriod = 10 * time.Minute
+
+	// DependencyVersionSkewCondition is set when a deployed subchart
+	// version violates its constraint in ChartRef.DependencyVersions.
+	DependencyVersionSkewCondition = "DependencyVersionSkew"
+
+	// ReplicaLimitExceededCondition is set when the requested broker count
+	// exceeds the maximum replicas, the HelmRelease is then not updated.
+	ReplicaLimitExceededCondition = "ReplicaLimitExceeded"
+	// maxReplicasPath is the annotation path holding the maximum broker
+	// count of a Redpanda, overriding MaxReplicas.
+	maxReplicasPath = "/max-replicas"
+
+	// ReplicasExternallyManagedCondition is set while the broker count is
+	// managed outside of the operator, see replicasExternallyManagedPath.
+	ReplicasExternallyManagedCondition = "ReplicasExternallyManaged"
+	// replicasExternallyManagedPath is the annotation path that, when set to
+	// "true", leaves the replicas of the StatefulSet to an external source,
+	// e.g. an autoscaler, instead of statefulset.replicas of the Redpanda.
+	replicasExternallyManagedPath = "/replicas-externally-managed"
+
+	// VolumeExpansionCondition reports the resize of the broker PVCs to the
+	// requested storage size, it is removed once all of them are resized.
+	VolumeExpansionCondition = "VolumeExpansion"
+	// expandVolumesPath is the annotation path that, when set to "true", lets
+	// the operator resize the broker PVCs when the requested storage size
+	// grows.
+	expandVolumesPath = "/expand-volumes"
+
+	// DeletionBlockedCondition is set when the HelmRelease is still
+	// terminating after the deletion blocked timeout, listing the resources
+	// of the release that remain.
+	DeletionBlockedCondition = "DeletionBlocked"
+
+	// defaultDeletionBlockedTimeout is the time a HelmRelease may take to be
+	// deleted before DeletionBlocked is reported.
+	defaultDeletionBlockedTimeout = 5 * time.Minute
+
+	// ForcedFinalizationCondition is set when the finalizer of a Redpanda is
+	// removed after the finalizer timeout, leaving resources behind.
+	ForcedFinalizationCondition = "ForcedFinalization"
+	// finalizerTimeoutPath is the annotation path holding the finalizer
+	// timeout of a Redpanda, e.g. "30m", overriding FinalizerTimeout.
+	finalizerTimeoutPath = "/finalizer-timeout"
+
+	// LicenseInvalidCondition is set when no license is loaded in the cluster
+	// or the loaded license has expired.
+	LicenseInvalidCondition = "LicenseInvalid"
+	// LicenseExpiringSoonCondition is set when the loaded license expires
+	// within the license expiry warning period.
+	LicenseExpiringSoonCondition = "LicenseExpiringSoon"
+
+	// defaultLicenseExpiryWarning is the time before the license expiration
+	// LicenseExpiringSoon is reported.
+	defaultLicenseExpiryWarning = 30 * 24 * time.Hour
+
+	// minRequeueInterval is the shortest ChartRef.RequeueInterval accepted.
+	minRequeueInterval = 5 * time.Second
+
+	// ReleaseUninstallingCondition is set while the HelmRelease is being
+	// deleted and its release uninstalled. The HelmRelease is not updated
+	// until it is gone and created again.
+	ReleaseUninstallingCondition = "ReleaseUninstalling"
+	// RecreateBlockedCondition is set while safe mode blocks the recreation
+	// of the HelmRelease requested by the recreate-helmrelease annotation.
+	RecreateBlockedCondition = "RecreateBlocked"
+	// recreateHelmReleasePath is the annotation path holding a token, the
+	// HelmRelease is deleted and created again once per distinct token.
+	recreateHelmReleasePath = "/recreate-helmrelease"
+
+	// allowDestructiveActionsPath is the annotation path that, when set to
+	// "true", lets an operator running in safe mode perform destructive
+	// actions on behalf of the annotated object.
+	allowDestructiveActionsPath = "/allow-destructive-actions"
+
+	// maxReleaseNameLength is the longest Helm release name, see
+	// https://github.com/helm/helm/blob/v3.14.0/pkg/chartutil/validate_name.go
+	maxReleaseNameLength = 53
+
+	// HelmRepositoryNotReadyCondition is set while the HelmRepository the
+	// HelmRelease fetches the chart from is not ready, its transition time
+	// starts the ChartRef.FailoverAfter period.
+	HelmRepositoryNotReadyCondition = "HelmRepositoryNotReady"
+
+	// StaleArtifactCondition is set while the artifact of the HelmRepository
+	// the chart is fetched from is older than StaleArtifactThreshold.
+	StaleArtifactCondition = "StaleArtifact"
+
+	// defaultFailoverAfter is the default ChartRef.FailoverAfter.
+	defaultFailoverAfter = 5 * time.Minute
+
+	// migrationRerunPath is the annotation path holding a comma separated
+	// list of migration steps, e.g. "statefulset,pdb", to run again even
+	// though their resources are already adopted.
+	migrationRerunPath = "/migration-rerun"
+
+	// ExternalReadyCondition reports whether the external Service of the
+	// chart has ready endpoints, see ChartRef.WaitForExternalEndpoints.
+	ExternalReadyCondition = "ExternalReady"
+
+	// hookPathPrefix is the prefix of the annotation paths holding the URL
+	// of a lifecycle hook, followed by the phase, e.g. "/hook-pre-upgrade".
+	hookPathPrefix = "/hook-"
+	// hookFailurePolicyPath is the annotation path that, when set to
+	// "fail-closed", blocks the transition of a failing hook. Failing hooks
+	// are only reported by default.
+	hookFailurePolicyPath = "/hook-failure-policy"
+	// hookPreDeleteCalledPath is the annotation path set on a HelmRelease
+	// once the pre-delete hook was called for it, so that it is called once
+	// while the resources ahead of the HelmRelease are deleted.
+	hookPreDeleteCalledPath = "/hook-pre-delete-called"
+
+	// ChartUnverifiedCondition is set while ChartRef.Verify is invalid or
+	// the source controller failed to verify the signature of the chart.
+	ChartUnverifiedCondition = "ChartUnverified"
+
+	// statusPatchTimeout bounds the status patch issued after a reconcile,
+	// which runs detached from the reconcile context so that timeouts are
+	// still recorded.
+	statusPatchTimeout = 10 * time.Second
+	// defaultLastReconcileTimeRefresh is the age after which LastReconcileTime
+	// is written even when nothing else in the status changed.
+	defaultLastReconcileTimeRefresh = 5 * time.Minute
 )
 
 // RedpandaReconciler reconciles a Redpanda object
@@ -62,6 +287,93 @@ type RedpandaReconciler struct {
 	kuberecorder.EventRecorder
 
 	RequeueHelmDeps time.Duration
+	// MaxConcurrentReconciles is the maximum number of Redpandas reconciled
+	// in parallel, independently of the other controllers. Zero uses the
+	// controller-runtime default of 1.
+	MaxConcurrentReconciles int
+	// ReconcileTimeout bounds a single call to Reconcile. Zero disables the
+	// deadline.
+	ReconcileTimeout time.Duration
+	// StructuredEventsAddr, when set, receives every event as a JSON
+	// StructuredEvent in addition to the Kubernetes event.
+	StructuredEventsAddr string
+	// HookAllowedHosts are the hosts, optionally with a port, the lifecycle
+	// hooks of the Redpandas may be posted to. Empty rejects every hook.
+	HookAllowedHosts []string
+	// EventPolicies maps event reasons, or the info and error severities, to
+	// EventPolicyNormal, EventPolicyWarning or EventPolicyDrop, overriding
+	// the event type derived from the severity. Nil keeps the defaults.
+	EventPolicies map[string]string
+	// NoCrossNamespaceRef rejects ChartRef.DependsOn entries pointing at
+	// another namespace, matching the HelmRelease controller setting.
+	NoCrossNamespaceRef bool
+	// ReplicaMismatchGracePeriod is the time the ready brokers may disagree
+	// with the requested replicas before ReplicaMismatch is reported. Zero
+	// uses a default of 10 minutes.
+	ReplicaMismatchGracePeriod time.Duration
+	// MaxReplicas is the largest broker count a Redpanda may request, larger
+	// values are rejected with the ReplicaLimitExceeded condition. Zero
+	// disables the limit, the max-replicas annotation overrides it.
+	MaxReplicas int32
+	// ValuesPreflight renders the chart with the values before the
+	// HelmRelease is updated, reporting failures with the ValuesInvalid
+	// condition instead of a failing HelmRelease.
+	ValuesPreflight bool
+	// SupportedChartVersions is the range of chart versions the Redpanda
+	// resources may use. Nil accepts any version.
+	SupportedChartVersions *semver.Constraints
+	// DeletionBlockedTimeout is the time a HelmRelease may be terminating
+	// before DeletionBlocked is reported. Zero uses a default of 5 minutes.
+	DeletionBlockedTimeout time.Duration
+	// FinalizerTimeout is the time the deletion of a Redpanda may take before
+	// its finalizer is force-removed, orphaning what is left. Zero disables
+	// it, the finalizer-timeout annotation overrides it.
+	FinalizerTimeout time.Duration
+	// StaleArtifactThreshold is the age of the HelmRepository artifact past
+	// which StaleArtifact is reported. Zero disables the check.
+	StaleArtifactThreshold time.Duration
+	// LastReconcileTimeRefresh is the age after which LastReconcileTime is
+	// patched although nothing else in the status changed, bounding the
+	// status writes of no-op reconciles. Zero uses a default of 5 minutes.
+	LastReconcileTimeRefresh time.Duration
+	// DisableMigrationOnCompletion turns Spec.Migration.Enabled off once the
+	// migration of a Redpanda completed.
+	DisableMigrationOnCompletion bool
+	// LicenseCheck reports the license loaded in each cluster in the
+	// Redpanda status. It requires connectivity to the Admin API.
+	LicenseCheck bool
+	// LicenseExpiryWarning is the time before the license expiration
+	// LicenseExpiringSoon is reported. Zero uses a default of 30 days.
+	LicenseExpiryWarning time.Duration
+	// SafeMode turns the deletion of HelmReleases and of the StatefulSet and
+	// Console Deployment replaced by a migration into a dry run, unless the
+	// Redpanda has the allow-destructive-actions annotation.
+	SafeMode bool
+	// APIThrottle postpones reconciles while the API server throttles the
+	// requests of the operator. Nil never postpones.
+	APIThrottle *throttle.Monitor
+	// ClusterDomain is the Kubernetes cluster domain passed to the chart as
+	// the clusterDomain value, unless the Redpanda sets it. Empty uses the
+	// chart default.
+	ClusterDomain string
+	// OperatorNamespace is the namespace the operator runs in, passed to the
+	// chart as the operator.namespace value so that templates can refer to
+	// resources the operator provisions there. Empty omits the value.
+	OperatorNamespace string
+	// LocalChartsDir is the directory ChartRef.LocalChartPath is relative
+	// to. Empty disables local charts.
+	LocalChartsDir string
+	// StoragePath and StorageAdvAddr are the directory and the advertised
+	// address of the artifact storage local charts are served from.
+	StoragePath    string
+	StorageAdvAddr string
+
+	// adminAPI builds the Admin API client of the given Redpanda, it
+	// defaults to redpandaAdminAPI.
+	adminAPI func(ctx context.Context, rp *v1alpha1.Redpanda) (adminutils.AdminAPIClient, error)
+
+	states           reconcileStates
+	structuredEvents structuredEventQueue
 }
 
 // flux resources main resources
@@ -89,15 +401,19 @@ type RedpandaReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,namespace=default,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,namespace=default,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,namespace=default,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace=default,resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,namespace=default,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace=default,resources=endpoints,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,namespace=default,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=apps,namespace=default,resources=statefulsets,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,namespace=default,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,namespace=default,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,namespace=default,resources=deployments,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=cert-manager.io,namespace=default,resources=certificates,verbs=get;create;update;patch;delete
-// +kubebuilder:rbac:groups=cert-manager.io,namespace=default,resources=issuers,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,namespace=default,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,namespace=default,resources=issuers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=default,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="monitoring.coreos.com",namespace=default,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,namespace=default,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,namespace=default,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 
 // for the migration purposes to disable reconciliation of cluster and console custom resources
 // +kubebuilder:rbac:groups=redpanda.vectorized.io,resources=clusters,verbs=get;list;watch;update;patch
@@ -108,35 +424,112 @@ type RedpandaReconciler struct {
 // +kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=redpandas/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=redpandas/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,namespace=default,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,namespace=default,resources=persistentvolumeclaims,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+
+// redpandaChangedPredicate ignores Redpanda updates that only touch the
+// status. Reconciles that change the status patch it, which would otherwise
+// trigger the next reconcile right away. Spec and annotation changes,
+// HelmRelease changes and periodic resyncs still reconcile.
+var redpandaChangedPredicate = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *RedpandaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *RedpandaReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Redpanda{}, valuesOverlaysIndex, valuesOverlayNames); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Redpanda{}, valuesFromIndex, valuesFromNames); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1alpha1.Redpanda{}, consoleTLSSecretsIndex, consoleTLSSecretKeys); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.Redpanda{}).
+		For(&v1alpha1.Redpanda{}, builder.WithPredicates(redpandaChangedPredicate)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Owns(&helmv2beta1.HelmRelease{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Watches(
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.redpandasForValuesOverlay(valuesOverlayKindConfigMap)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.redpandasForValuesOverlay(valuesOverlayKindSecret)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.redpandasForValuesFrom(valuesOverlayKindConfigMap)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.redpandasForValuesFrom(valuesOverlayKindSecret)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.redpandasForConsoleTLSSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&v1alpha1.Redpanda{},
+			handler.EnqueueRequestsFromMapFunc(r.redpandasForReleaseNameCollision),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Complete(r)
 }
 
 func (r *RedpandaReconciler) Reconcile(c context.Context, req ctrl.Request) (ctrl.Result, error) {
-	ctx, done := context.WithCancel(c)
+	var ctx context.Context
+	var done context.CancelFunc
+	if r.ReconcileTimeout > 0 {
+		ctx, done = context.WithTimeout(c, r.ReconcileTimeout)
+	} else {
+		ctx, done = context.WithCancel(c)
+	}
 	defer done()
 
 	start := time.Now()
 	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.Reconcile")
 
+	if backoff := r.APIThrottle.Backoff(); backoff > 0 {
+		log.Info(fmt.Sprintf("API server is throttling requests, postponing reconcile by %s", backoff))
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
 	log.Info("Starting reconcile loop")
 
 	rp := &v1alpha1.Redpanda{}
 	if err := r.Client.Get(ctx, req.NamespacedName, rp); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.states.delete(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Dependent resources vanish while the namespace is terminating, do not
+	// try to reconcile or delete them
+	terminating, err := isNamespaceTerminating(ctx, r.Client, rp.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if terminating {
+		r.recordReconcileMode(req.NamespacedName, reconcileModeNamespaceTerminating, start)
+		return r.reconcileTerminatingNamespace(ctx, rp)
+	}
+
 	// Examine if the object is under deletion
 	if !rp.ObjectMeta.DeletionTimestamp.IsZero() {
+		r.recordReconcileMode(req.NamespacedName, reconcileModeDeleting, start)
 		return r.reconcileDelete(ctx, rp)
 	}
 
 	if !isRedpandaManaged(ctx, rp) {
+		r.recordReconcileMode(req.NamespacedName, reconcileModeUnmanaged, start)
 		if controllerutil.ContainsFinalizer(rp, FinalizerKey) {
 			// if no longer managed by us, attempt to remove the finalizer
 			controllerutil.RemoveFinalizer(rp, FinalizerKey)
@@ -158,20 +551,55 @@ func (r *RedpandaReconciler) Reconcile(c context.Context, req ctrl.Request) (ctr
 		}
 	}
 
-	if rp.Spec.Migration != nil && rp.Spec.Migration.Enabled {
-		if err := r.tryMigration(ctx, log, rp); err != nil {
-			log.Error(err, "migration")
-		}
+	if isTeardownRequested(rp) {
+		r.recordReconcileMode(req.NamespacedName, reconcileModeTeardown, start)
+		return r.reconcileTeardown(ctx, rp)
+	}
+	apimeta.RemoveStatusCondition(rp.GetConditions(), TeardownCondition)
+
+	if rp.Spec.Migration != nil && rp.Spec.Migration.Enabled && !migrationCompleted(rp) {
+		r.reconcileMigration(ctx, log, rp)
+	} else {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), MigrationConflictCondition)
+		apimeta.RemoveStatusCondition(rp.GetConditions(), DualOwnershipCondition)
+	}
+
+	var result ctrl.Result
+	if cond := apimeta.FindStatusCondition(rp.Status.Conditions, DualOwnershipCondition); cond != nil && cond.Status == metav1.ConditionTrue {
+		// keep off the resources of the Cluster until its controller is done
+		rp = v1alpha1.RedpandaNotReady(rp, v1alpha1.DualOwnershipReason, cond.Message)
+		result = ctrl.Result{RequeueAfter: r.requeueInterval(rp)}
+	} else {
+		rp, result, err = r.reconcile(ctx, rp)
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		msg := fmt.Sprintf("reconcile timed out after %s", r.ReconcileTimeout.String())
+		rp = v1alpha1.RedpandaNotReady(rp, v1alpha1.ReconcileTimeoutReason, msg)
+		r.reasonEvent(rp, v1alpha1.ReconcileTimeoutReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		err = errors.Join(errors.New(msg), err)
 	}
 
-	rp, result, err := r.reconcile(ctx, rp)
+	rp.Status.Summary = statusSummary(rp)
+	if err == nil {
+		rp.Status.LastReconcileTime = ptr.To(metav1.Now())
+		rp.Status.LastReconcileError = ""
+	} else {
+		rp.Status.LastReconcileError = err.Error()
+	}
 
-	// Update status after reconciliation.
-	if updateStatusErr := r.patchRedpandaStatus(ctx, rp); updateStatusErr != nil {
+	// Update status after reconciliation. The reconcile context may already be
+	// done at this point, so patch with a short context that is not canceled
+	// alongside it.
+	statusCtx, statusDone := context.WithTimeout(context.WithoutCancel(c), statusPatchTimeout)
+	defer statusDone()
+	if updateStatusErr := r.patchRedpandaStatus(statusCtx, rp); updateStatusErr != nil {
 		log.Error(updateStatusErr, "unable to update status after reconciliation")
 		return ctrl.Result{Requeue: true}, updateStatusErr
 	}
 
+	r.recordReconcileState(rp, start, result, err)
+
 	// Log reconciliation duration
 	durationMsg := fmt.Sprintf("reconciliation finished in %s", time.Since(start).String())
 	if result.RequeueAfter > 0 {
@@ -182,54 +610,135 @@ func (r *RedpandaReconciler) Reconcile(c context.Context, req ctrl.Request) (ctr
 	return result, err
 }
 
+// checkMigrationConflict reports whether the HelmRelease of the given
+// Redpanda already existed when migration was enabled. In that case the chart
+// owns the resources that migration would relabel, so a Warning event is
+// emitted and the MigrationConflict condition is set instead. A HelmRelease
+// owned by the Redpanda was created by this operator as part of the
+// migration and is not a conflict. Once reported, the conflict sticks until
+// the HelmRelease is gone or migration is disabled, as the ownership of a
+// foreign HelmRelease is re-asserted later in the reconcile. Migration
+// references pointing at resources of the Redpanda itself are reported the
+// same way, until they are fixed.
+func (r *RedpandaReconciler) checkMigrationConflict(ctx context.Context, rp *v1alpha1.Redpanda) (bool, error) {
+	msg, err := r.migrationSelfReference(ctx, rp)
+	if err != nil {
+		return false, err
+	}
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition)
+	if msg != "" {
+		if cond == nil || cond.Message != msg {
+			r.event(rp, v1alpha1.MigrationSelfReferenceReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		}
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               MigrationConflictCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.MigrationSelfReferenceReason,
+			Message:            msg,
+		})
+		return true, nil
+	}
+	if cond != nil && cond.Reason == v1alpha1.MigrationSelfReferenceReason {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), MigrationConflictCondition)
+	}
+
+	hr := &helmv2beta1.HelmRelease{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetHelmReleaseName()}, hr)
+	if apierrors.IsNotFound(err) {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), MigrationConflictCondition)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get HelmRelease '%s/%s': %w", rp.Namespace, rp.GetHelmReleaseName(), err)
+	}
+
+	if apimeta.IsStatusConditionTrue(rp.Status.Conditions, MigrationConflictCondition) {
+		return true, nil
+	}
+
+	for _, ref := range hr.OwnerReferences {
+		if ref.UID == rp.UID {
+			return false, nil
+		}
+	}
+
+	msg = fmt.Sprintf("HelmRelease '%s/%s' already exists, skipping migration; disable spec.migration as the cluster is already migrated", hr.Namespace, hr.Name)
+	r.event(rp, v1alpha1.HelmReleaseExistsReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               MigrationConflictCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.HelmReleaseExistsReason,
+		Message:            msg,
+	})
+	return true, nil
+}
+
+// observeGeneration records the generation of the Redpanda and resets its
+// conditions to progressing. The MigrationConflict condition is kept: the
+// ownership of the conflicting HelmRelease is re-asserted in the meantime,
+// so the conflict could not be detected again.
+func observeGeneration(rp *v1alpha1.Redpanda) *v1alpha1.Redpanda {
+	conflict := apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition).DeepCopy()
+	rp.Status.ObservedGeneration = rp.Generation
+	rp = v1alpha1.RedpandaProgressing(rp)
+	if conflict != nil {
+		apimeta.SetStatusCondition(rp.GetConditions(), *conflict)
+	}
+	return rp
+}
+
+// tryMigration adopts the resources of the v1 Cluster and Console into the
+// release of the given Redpanda. Steps blocked by safe mode are reported with
+// errDestructiveActionBlocked, the migration is only complete once every
+// step ran.
 func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger, rp *v1alpha1.Redpanda) error {
 	log = log.WithName("tryMigration")
 	var errorResult error
+	rerun := r.pendingMigrationRerun(rp)
+	defer func() {
+		// a blocked step is still pending, rerun it once allowed
+		if !errors.Is(errorResult, errDestructiveActionBlocked) {
+			r.recordMigrationRerun(rp, rerun)
+		}
+	}()
 
 	var cluster vectorzied_v1alpha1.Cluster
-	namespace := rp.Spec.Migration.ClusterRef.Namespace
-	if namespace == "" {
-		namespace = rp.Namespace
-	}
-	name := rp.Spec.Migration.ClusterRef.Name
-	if name == "" {
-		name = rp.Name
-	}
-	err := r.Get(ctx, types.NamespacedName{
-		Namespace: namespace,
-		Name:      name,
-	}, &cluster)
+	key := migrationRefKey(rp, rp.Spec.Migration.ClusterRef)
+	err := r.Get(ctx, key, &cluster)
 	if err != nil {
-		errorResult = errors.Join(fmt.Errorf("get cluster reference (%s/%s): %w", namespace, name, err), errorResult)
-	} else if isRedpandaClusterManaged(log, &cluster) {
-		annotatedCluster := cluster.DeepCopy()
-		disableRedpandaReconciliation(annotatedCluster)
+		if apierrors.IsNotFound(err) {
+			apimeta.RemoveStatusCondition(rp.GetConditions(), DualOwnershipCondition)
+		}
+		errorResult = errors.Join(fmt.Errorf("get cluster reference (%s): %w", key, err), errorResult)
+	} else {
+		if isRedpandaClusterManaged(log, &cluster) {
+			annotatedCluster := cluster.DeepCopy()
+			disableRedpandaReconciliation(annotatedCluster)
 
-		err = r.Update(ctx, annotatedCluster)
-		if err != nil {
-			errorResult = errors.Join(fmt.Errorf("disabling Cluster reconciliation (%s): %w", annotatedCluster.Name, err), errorResult)
+			err = r.Update(ctx, annotatedCluster)
+			if err != nil {
+				errorResult = errors.Join(fmt.Errorf("disabling Cluster reconciliation (%s): %w", annotatedCluster.Name, err), errorResult)
+			} else {
+				cluster = *annotatedCluster
+			}
+
+			msg := "update Cluster custom resource"
+			log.V(logger.DebugLevel).Info(msg, "cluster-name", annotatedCluster.Name, "annotations", annotatedCluster.Annotations, "finalizers", annotatedCluster.Finalizers)
+			r.EventRecorder.AnnotatedEventf(annotatedCluster, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 		}
 
-		msg := "update Cluster custom resource"
-		log.V(logger.DebugLevel).Info(msg, "cluster-name", annotatedCluster.Name, "annotations", annotatedCluster.Annotations, "finalizers", annotatedCluster.Finalizers)
-		r.EventRecorder.AnnotatedEventf(annotatedCluster, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		if r.checkDualOwnership(log, rp, &cluster) {
+			return errors.Join(fmt.Errorf("cluster (%s/%s) is still reconciled, skipping migration of its resources", cluster.Namespace, cluster.Name), errorResult)
+		}
 	}
 
 	var console vectorzied_v1alpha1.Console
-	namespace = rp.Spec.Migration.ConsoleRef.Namespace
-	if namespace == "" {
-		namespace = rp.Namespace
-	}
-	name = rp.Spec.Migration.ConsoleRef.Name
-	if name == "" {
-		name = rp.Name
-	}
-	err = r.Get(ctx, types.NamespacedName{
-		Namespace: namespace,
-		Name:      name,
-	}, &console)
+	key = migrationRefKey(rp, rp.Spec.Migration.ConsoleRef)
+	err = r.Get(ctx, key, &console)
 	if err != nil {
-		errorResult = errors.Join(fmt.Errorf("get cluster reference (%s/%s): %w", namespace, name, err), errorResult)
+		errorResult = errors.Join(fmt.Errorf("get cluster reference (%s): %w", key, err), errorResult)
 	} else if isConsoleManaged(log, &console) ||
 		controllerutil.ContainsFinalizer(&console, consolepkg.ConsoleSAFinalizer) ||
 		controllerutil.ContainsFinalizer(&console, consolepkg.ConsoleACLFinalizer) {
@@ -246,27 +755,35 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 		msg := "update Console custom resource"
 		log.V(logger.DebugLevel).Info(msg, "console-name", annotatedConsole.Name, "annotations", annotatedConsole.Annotations, "finalizers", annotatedConsole.Finalizers)
-		r.EventRecorder.AnnotatedEventf(annotatedConsole, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		r.EventRecorder.AnnotatedEventf(annotatedConsole, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
 	var pl v1.PodList
 	err = r.List(ctx, &pl, []client.ListOption{
 		client.InNamespace(rp.Namespace),
-		client.MatchingLabels(map[string]string{"app.kubernetes.io/instance": rp.Name, "app.kubernetes.io/name": "redpanda"}),
+		client.MatchingLabels(map[string]string{"app.kubernetes.io/instance": rp.GetReleaseName()}),
 	}...)
 	if err != nil {
 		errorResult = errors.Join(fmt.Errorf("listing pods: %w", err), errorResult)
 	}
 
+	chartName := redpandaChartName(rp)
+	componentLabel := redpandaComponentLabel(rp)
 	for i := range pl.Items {
-		if l, exist := pl.Items[i].Labels["app.kubernetes.io/component"]; exist && l == "redpanda-statefulset" && !controllerutil.ContainsFinalizer(&pl.Items[i], FinalizerKey) {
+		// Pods created by the Cluster controller are always named "redpanda",
+		// while the chart may use a nameOverride.
+		if l := pl.Items[i].Labels["app.kubernetes.io/name"]; l != defaultRedpandaChartName && l != chartName {
+			continue
+		}
+		if !rerun[migrationStepPods] && pl.Items[i].Labels["app.kubernetes.io/name"] == chartName && pl.Items[i].Labels["app.kubernetes.io/component"] == componentLabel && !controllerutil.ContainsFinalizer(&pl.Items[i], FinalizerKey) {
 			continue
 		}
 		newPod := pl.Items[i].DeepCopy()
 		if newPod.Labels == nil {
 			newPod.Labels = make(map[string]string)
 		}
-		newPod.Labels["app.kubernetes.io/component"] = "redpanda-statefulset"
+		newPod.Labels["app.kubernetes.io/name"] = chartName
+		newPod.Labels["app.kubernetes.io/component"] = componentLabel
 
 		controllerutil.RemoveFinalizer(newPod, FinalizerKey)
 
@@ -277,10 +794,10 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 		msg := "update Redpanda Pod"
 		log.V(logger.DebugLevel).Info(msg, "pod-name", newPod.Name, "labels", newPod.Labels)
-		r.EventRecorder.AnnotatedEventf(newPod, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		r.EventRecorder.AnnotatedEventf(newPod, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
-	resourcesName := rp.Name
+	resourcesName := rp.GetReleaseName()
 	if rp.Spec.ClusterSpec.FullNameOverride != "" {
 		resourcesName = rp.Spec.ClusterSpec.FullNameOverride
 	}
@@ -292,16 +809,16 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 	}, &svc)
 	if err != nil {
 		errorResult = errors.Join(fmt.Errorf("get internal service (%s): %w", resourcesName, err), errorResult)
-	} else if !hasLabelsAndAnnotations(&svc, rp) || !maps.Equal(svc.Spec.Selector, map[string]string{
-		"app.kubernetes.io/instance": rp.Name,
-		"app.kubernetes.io/name":     "redpanda",
+	} else if rerun[migrationStepService] || !hasLabelsAndAnnotations(&svc, rp) || !maps.Equal(svc.Spec.Selector, map[string]string{
+		"app.kubernetes.io/instance": rp.GetReleaseName(),
+		"app.kubernetes.io/name":     chartName,
 	}) {
 		internalService := svc.DeepCopy()
 		setHelmLabelsAndAnnotations(internalService, rp)
 
 		internalService.Spec.Selector = make(map[string]string)
-		internalService.Spec.Selector["app.kubernetes.io/instance"] = rp.Name
-		internalService.Spec.Selector["app.kubernetes.io/name"] = "redpanda"
+		internalService.Spec.Selector["app.kubernetes.io/instance"] = rp.GetReleaseName()
+		internalService.Spec.Selector["app.kubernetes.io/name"] = chartName
 
 		err = r.Update(ctx, internalService)
 		if err != nil {
@@ -310,7 +827,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 		msg := "update internal Service"
 		log.V(logger.DebugLevel).Info(msg, "service-name", internalService.Name, "labels", internalService.Labels, "annotations", internalService.Annotations, "selector", internalService.Spec.Selector)
-		r.EventRecorder.AnnotatedEventf(internalService, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		r.EventRecorder.AnnotatedEventf(internalService, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
 	externalSVCName := fmt.Sprintf("%s-external", resourcesName)
@@ -320,7 +837,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 	}, &svc)
 	if err != nil {
 		errorResult = errors.Join(fmt.Errorf("get external service (%s): %w", externalSVCName, err), errorResult)
-	} else if !hasLabelsAndAnnotations(&svc, rp) {
+	} else if rerun[migrationStepExternalService] || !hasLabelsAndAnnotations(&svc, rp) {
 		externalService := svc.DeepCopy()
 		setHelmLabelsAndAnnotations(externalService, rp)
 
@@ -331,7 +848,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 		msg := "update external Service"
 		log.V(logger.DebugLevel).Info(msg, "service-account-name", externalService.Name, "labels", externalService.Labels, "annotations", externalService.Annotations)
-		r.EventRecorder.AnnotatedEventf(externalService, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		r.EventRecorder.AnnotatedEventf(externalService, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
 	var sa v1.ServiceAccount
@@ -341,7 +858,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 	}, &sa)
 	if err != nil {
 		errorResult = errors.Join(fmt.Errorf("get service account (%s): %w", resourcesName, err), errorResult)
-	} else if !hasLabelsAndAnnotations(&sa, rp) {
+	} else if rerun[migrationStepServiceAccount] || !hasLabelsAndAnnotations(&sa, rp) {
 		annotatedSA := sa.DeepCopy()
 		setHelmLabelsAndAnnotations(annotatedSA, rp)
 
@@ -352,7 +869,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 		msg := "update ServiceAccount"
 		log.V(logger.DebugLevel).Info(msg, "service-account-name", annotatedSA.Name, "labels", annotatedSA.Labels, "annotations", annotatedSA.Annotations)
-		r.EventRecorder.AnnotatedEventf(annotatedSA, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		r.EventRecorder.AnnotatedEventf(annotatedSA, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
 	var pdb policyv1.PodDisruptionBudget
@@ -362,7 +879,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 	}, &pdb)
 	if err != nil {
 		errorResult = errors.Join(fmt.Errorf("get pod disruption budget (%s): %w", resourcesName, err), errorResult)
-	} else if !hasLabelsAndAnnotations(&pdb, rp) {
+	} else if rerun[migrationStepPDB] || !hasLabelsAndAnnotations(&pdb, rp) {
 		annotatedPDB := pdb.DeepCopy()
 		setHelmLabelsAndAnnotations(annotatedPDB, rp)
 
@@ -373,7 +890,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 		msg := "update PodDistributionBudget"
 		log.V(logger.DebugLevel).Info(msg, "pod-distribution-budget-name", annotatedPDB.Name, "labels", annotatedPDB.Labels, "annotations", annotatedPDB.Annotations)
-		r.EventRecorder.AnnotatedEventf(annotatedPDB, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		r.EventRecorder.AnnotatedEventf(annotatedPDB, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
 	var sts appsv1.StatefulSet
@@ -383,23 +900,53 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 	}, &sts)
 	if err != nil {
 		errorResult = errors.Join(fmt.Errorf("get statefulset (%s): %w", resourcesName, err), errorResult)
-	} else if !hasLabelsAndAnnotations(&sts, rp) {
-		orphan := metav1.DeletePropagationOrphan
-		err = r.Delete(ctx, &sts, &client.DeleteOptions{
-			PropagationPolicy: &orphan,
-		})
+	} else if rerun[migrationStepStatefulSet] || !hasLabelsAndAnnotations(&sts, rp) {
+		action := fmt.Sprintf("delete StatefulSet %s with orphan propagation mode", sts.Name)
+		if destructiveActionAllowed(log, r.SafeMode, rp, action) {
+			orphan := metav1.DeletePropagationOrphan
+			err = r.Delete(ctx, &sts, &client.DeleteOptions{
+				PropagationPolicy: &orphan,
+			})
+			if err != nil {
+				errorResult = errors.Join(fmt.Errorf("deleting statefulset (%s): %w", sts.Name, err), errorResult)
+			}
+
+			msg := "delete StatefulSet with orphant propagation mode"
+			log.V(logger.DebugLevel).Info(msg, "stateful-set-name", sts.Name)
+			r.EventRecorder.AnnotatedEventf(&sts, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceDeletedReason, msg)
+		} else {
+			r.event(rp, v1alpha1.DestructiveActionBlockedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, safeModeMessage(action))
+			errorResult = errors.Join(fmt.Errorf("%s: %w", action, errDestructiveActionBlocked), errorResult)
+		}
+	}
+
+	// the PodMonitor CRD is optional, without it there is nothing to adopt
+	podMonitor := newPodMonitor()
+	err = r.Get(ctx, types.NamespacedName{
+		Namespace: rp.Namespace,
+		Name:      resourcesName,
+	}, podMonitor)
+	if err != nil {
+		if !isAbsent(err) {
+			errorResult = errors.Join(fmt.Errorf("get pod monitor (%s): %w", resourcesName, err), errorResult)
+		}
+	} else if rerun[migrationStepPodMonitor] || !hasLabelsAndAnnotations(podMonitor, rp) {
+		annotatedPodMonitor := podMonitor.DeepCopy()
+		setHelmLabelsAndAnnotations(annotatedPodMonitor, rp)
+
+		err = r.Update(ctx, annotatedPodMonitor)
 		if err != nil {
-			errorResult = errors.Join(fmt.Errorf("deleting statefulset (%s): %w", sts.Name, err), errorResult)
+			errorResult = errors.Join(fmt.Errorf("updating pod monitor (%s): %w", annotatedPodMonitor.GetName(), err), errorResult)
 		}
 
-		msg := "delete StatefulSet with orphant propagation mode"
-		log.V(logger.DebugLevel).Info(msg, "stateful-set-name", sts.Name)
-		r.EventRecorder.AnnotatedEventf(&sts, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+		msg := "update PodMonitor"
+		log.V(logger.DebugLevel).Info(msg, "pod-monitor-name", annotatedPodMonitor.GetName(), "labels", annotatedPodMonitor.GetLabels(), "annotations", annotatedPodMonitor.GetAnnotations())
+		r.EventRecorder.AnnotatedEventf(annotatedPodMonitor, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 	}
 
 	if ptr.Deref(rp.Spec.ClusterSpec.Console.Enabled, true) {
 		log.V(logger.DebugLevel).Info("migrate console")
-		consoleResourcesName := rp.Name
+		consoleResourcesName := rp.GetReleaseName()
 		if overwriteSAName := ptr.Deref(rp.Spec.ClusterSpec.Console.FullNameOverride, ""); overwriteSAName != "" {
 			consoleResourcesName = overwriteSAName
 		}
@@ -409,7 +956,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 		}, &sa)
 		if err != nil {
 			errorResult = errors.Join(fmt.Errorf("get console service account (%s): %w", consoleResourcesName, err), errorResult)
-		} else if !hasLabelsAndAnnotations(&sa, rp) {
+		} else if rerun[migrationStepConsoleServiceAccount] || !hasLabelsAndAnnotations(&sa, rp) {
 			annotatedConsoleSA := sa.DeepCopy()
 			setHelmLabelsAndAnnotations(annotatedConsoleSA, rp)
 
@@ -420,7 +967,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 			msg := "update console ServiceAccount"
 			log.V(logger.DebugLevel).Info(msg, "service-account-name", annotatedConsoleSA.Name, "labels", annotatedConsoleSA.Labels, "annotations", annotatedConsoleSA.Annotations)
-			r.EventRecorder.AnnotatedEventf(annotatedConsoleSA, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+			r.EventRecorder.AnnotatedEventf(annotatedConsoleSA, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 		}
 
 		err = r.Get(ctx, types.NamespacedName{
@@ -429,16 +976,16 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 		}, &svc)
 		if err != nil {
 			errorResult = errors.Join(fmt.Errorf("get console service (%s): %w", consoleResourcesName, err), errorResult)
-		} else if !hasLabelsAndAnnotations(&svc, rp) || !maps.Equal(svc.Spec.Selector, map[string]string{
-			"app.kubernetes.io/instance": rp.Name,
-			"app.kubernetes.io/name":     "console",
+		} else if rerun[migrationStepConsoleService] || !hasLabelsAndAnnotations(&svc, rp) || !maps.Equal(svc.Spec.Selector, map[string]string{
+			"app.kubernetes.io/instance": rp.GetReleaseName(),
+			"app.kubernetes.io/name":     consoleChartName(rp),
 		}) {
 			annotatedConsoleSVC := svc.DeepCopy()
 			setHelmLabelsAndAnnotations(annotatedConsoleSVC, rp)
 
 			annotatedConsoleSVC.Spec.Selector = make(map[string]string)
-			annotatedConsoleSVC.Spec.Selector["app.kubernetes.io/instance"] = rp.Name
-			annotatedConsoleSVC.Spec.Selector["app.kubernetes.io/name"] = "console"
+			annotatedConsoleSVC.Spec.Selector["app.kubernetes.io/instance"] = rp.GetReleaseName()
+			annotatedConsoleSVC.Spec.Selector["app.kubernetes.io/name"] = consoleChartName(rp)
 
 			err = r.Update(ctx, annotatedConsoleSVC)
 			if err != nil {
@@ -447,7 +994,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 			msg := "update console Service"
 			log.V(logger.DebugLevel).Info(msg, "service-name", annotatedConsoleSVC.Name, "labels", annotatedConsoleSVC.Labels, "annotations", annotatedConsoleSVC.Annotations, "selector", annotatedConsoleSVC.Spec.Selector)
-			r.EventRecorder.AnnotatedEventf(annotatedConsoleSVC, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+			r.EventRecorder.AnnotatedEventf(annotatedConsoleSVC, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 		}
 
 		var deploy appsv1.Deployment
@@ -457,15 +1004,24 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 		}, &deploy)
 		if err != nil {
 			errorResult = errors.Join(fmt.Errorf("get console deployment (%s): %w", consoleResourcesName, err), errorResult)
-		} else if !hasLabelsAndAnnotations(&sts, rp) {
-			err = r.Delete(ctx, &deploy)
-			if err != nil {
-				errorResult = errors.Join(fmt.Errorf("deleting console deployment (%s): %w", deploy.Name, err), errorResult)
+		} else if rerun[migrationStepConsoleDeployment] || !hasLabelsAndAnnotations(&deploy, rp) {
+			action := fmt.Sprintf("delete console Deployment %s", deploy.Name)
+			if err = r.recordMigratedConsoleResources(ctx, rp); err != nil {
+				// the resources of Console would be lost with the Deployment
+				errorResult = errors.Join(err, errorResult)
+			} else if destructiveActionAllowed(log, r.SafeMode, rp, action) {
+				err = r.Delete(ctx, &deploy)
+				if err != nil {
+					errorResult = errors.Join(fmt.Errorf("deleting console deployment (%s): %w", deploy.Name, err), errorResult)
+				}
+
+				msg := "delete console Deployment"
+				log.V(logger.DebugLevel).Info(msg, "deployment-name", deploy.Name)
+				r.EventRecorder.AnnotatedEventf(&deploy, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceDeletedReason, msg)
+			} else {
+				r.event(rp, v1alpha1.DestructiveActionBlockedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, safeModeMessage(action))
+				errorResult = errors.Join(fmt.Errorf("%s: %w", action, errDestructiveActionBlocked), errorResult)
 			}
-
-			msg := "delete console Deployment"
-			log.V(logger.DebugLevel).Info(msg, "deployment-name", deploy.Name)
-			r.EventRecorder.AnnotatedEventf(&deploy, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
 		}
 
 		var ing networkingv1.Ingress
@@ -475,7 +1031,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 		}, &ing)
 		if err != nil {
 			errorResult = errors.Join(fmt.Errorf("get console ingress (%s): %w", consoleResourcesName, err), errorResult)
-		} else if !hasLabelsAndAnnotations(&ing, rp) {
+		} else if rerun[migrationStepConsoleIngress] || !hasLabelsAndAnnotations(&ing, rp) {
 			annotatedIngress := ing.DeepCopy()
 			setHelmLabelsAndAnnotations(annotatedIngress, rp)
 
@@ -486,7 +1042,7 @@ func (r *RedpandaReconciler) tryMigration(ctx context.Context, log logr.Logger,
 
 			msg := "update console Ingress"
 			log.V(logger.DebugLevel).Info(msg, "ingress-name", annotatedIngress.Name, "labels", annotatedIngress.Labels, "annotations", annotatedIngress.Annotations)
-			r.EventRecorder.AnnotatedEventf(annotatedIngress, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.EventSeverityInfo, msg)
+			r.EventRecorder.AnnotatedEventf(annotatedIngress, map[string]string{v2.GroupVersion.Group + "/revision": rp.Status.LastAttemptedRevision}, "Normal", v1alpha1.MigrationResourceAdoptedReason, msg)
 		}
 	}
 	return errorResult
@@ -505,7 +1061,7 @@ func hasLabelsAndAnnotations(object client.Object, rp *v1alpha1.Redpanda) bool {
 	for k, v := range object.GetAnnotations() {
 		switch k {
 		case "meta.helm.sh/release-name":
-			releaseName = v == rp.Name
+			releaseName = v == rp.GetReleaseName()
 		case "meta.helm.sh/release-namespace":
 			releaseNamespace = v == rp.Namespace
 		}
@@ -522,7 +1078,7 @@ func setHelmLabelsAndAnnotations(object client.Object, rp *v1alpha1.Redpanda) {
 	object.SetLabels(labels)
 
 	annotations := make(map[string]string)
-	annotations["meta.helm.sh/release-name"] = rp.Name
+	annotations["meta.helm.sh/release-name"] = rp.GetReleaseName()
 	annotations["meta.helm.sh/release-namespace"] = rp.Namespace
 	object.SetAnnotations(annotations)
 }
@@ -533,14 +1089,37 @@ func (r *RedpandaReconciler) reconcile(ctx context.Context, rp *v1alpha1.Redpand
 
 	// Observe HelmRelease generation.
 	if rp.Status.ObservedGeneration != rp.Generation {
-		rp.Status.ObservedGeneration = rp.Generation
-		rp = v1alpha1.RedpandaProgressing(rp)
+		rp = observeGeneration(rp)
 		if updateStatusErr := r.patchRedpandaStatus(ctx, rp); updateStatusErr != nil {
 			log.Error(updateStatusErr, "unable to update status after generation update")
 			return rp, ctrl.Result{Requeue: true}, updateStatusErr
 		}
 	}
 
+	if ok, err := r.checkReleaseNameCollision(ctx, rp); err != nil || !ok {
+		return rp, ctrl.Result{}, err
+	}
+	if !r.checkChartVersion(rp) {
+		return rp, ctrl.Result{}, nil
+	}
+	r.reconcileReplicasExternallyManaged(rp)
+	if !r.checkReplicaLimit(rp) {
+		return rp, ctrl.Result{}, nil
+	}
+	if ok, err := r.reconcileVolumeExpansion(ctx, rp); err != nil || !ok {
+		return rp, ctrl.Result{}, err
+	}
+
+	if err := validateRequeueInterval(rp); err != nil {
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.InvalidRequeueIntervalReason, fmt.Sprintf("invalid requeueInterval: %s", err)), ctrl.Result{}, nil
+	}
+	requeueHelmDeps := r.requeueInterval(rp)
+	rp.Status.RequeueInterval = &metav1.Duration{Duration: requeueHelmDeps}
+
+	if err := r.reconcileCertManager(ctx, rp); err != nil {
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.CertificateFailedReason, err.Error()), ctrl.Result{}, err
+	}
+
 	// Check if HelmRepository exists or create it
 	rp, repo, err := r.reconcileHelmRepository(ctx, rp)
 	if err != nil {
@@ -557,7 +1136,61 @@ func (r *RedpandaReconciler) reconcile(ctx context.Context, rp *v1alpha1.Redpand
 	isResourceReady := r.checkIfResourceIsReady(log, msgNotReady, msgReady, resourceTypeHelmRepository, isGenerationCurrent, isStatusConditionReady, isStatusReadyNILorTRUE, isStatusReadyNILorFALSE, rp)
 	if !isResourceReady {
 		// need to requeue in this case
-		return v1alpha1.RedpandaNotReady(rp, "ArtifactFailed", msgNotReady), ctrl.Result{RequeueAfter: r.RequeueHelmDeps}, nil
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.ArtifactFailedReason, msgNotReady), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
+	}
+	r.reconcileStaleArtifact(rp, repo, time.Now())
+
+	missing, err := r.missingSecrets(ctx, rp)
+	if err != nil {
+		return rp, ctrl.Result{}, err
+	}
+	if len(missing) > 0 {
+		msg := fmt.Sprintf("waiting for secrets: %s", strings.Join(missing, ", "))
+		log.Info(msg)
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               WaitingForSecretCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.SecretNotFoundReason,
+			Message:            msg,
+		})
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.WaitingForSecretReason, msg), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
+	}
+	apimeta.RemoveStatusCondition(rp.GetConditions(), WaitingForSecretCondition)
+
+	notReadyCerts, err := r.notReadyCertificates(ctx, rp)
+	if err != nil {
+		return rp, ctrl.Result{}, err
+	}
+	if len(notReadyCerts) > 0 {
+		msg := fmt.Sprintf("waiting for certificates to be ready: %s", strings.Join(notReadyCerts, ", "))
+		if cond := apimeta.FindStatusCondition(rp.Status.Conditions, WaitingForCertificateCondition); cond == nil || cond.Message != msg {
+			r.event(rp, v1alpha1.CertificateNotReadyReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msg)
+		}
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               WaitingForCertificateCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.CertificateNotReadyReason,
+			Message:            msg,
+		})
+		// requeue with the backoff of the rate limiter until the Certificates are issued
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.WaitingForCertificateReason, msg), ctrl.Result{Requeue: true}, nil
+	}
+	apimeta.RemoveStatusCondition(rp.GetConditions(), WaitingForCertificateCondition)
+
+	// Console mounts the copies, they have to exist before it is deployed
+	if err = r.reconcileConsoleTLSSecrets(ctx, rp); err != nil {
+		msg := fmt.Sprintf("could not copy console TLS secrets: %s", err)
+		r.event(rp, v1alpha1.ConsoleTLSSecretsFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.ConsoleTLSSecretsFailedReason, msg), ctrl.Result{}, err
+	}
+
+	// an invalid verify must not install an unverified chart
+	if err = r.validateChartVerification(ctx, rp); err != nil {
+		msg := fmt.Sprintf("invalid verify: %s", err)
+		r.setChartUnverified(rp, msg)
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.ChartUnverifiedReason, msg), ctrl.Result{}, err
 	}
 
 	// Check if HelmRelease exists or create it also
@@ -569,6 +1202,38 @@ func (r *RedpandaReconciler) reconcile(ctx context.Context, rp *v1alpha1.Redpand
 		log.Info(fmt.Sprintf("Created HelmRelease for '%s/%s', will requeue", rp.Namespace, rp.Name))
 		return rp, ctrl.Result{}, err
 	}
+	// the location the release is deployed to, as Helm resolves it
+	rp.Status.TargetNamespace = hr.GetReleaseNamespace()
+	rp.Status.ReleaseName = hr.GetReleaseName()
+	if cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReleaseUninstallingCondition); cond != nil {
+		// requeue with the backoff of the rate limiter until the HelmRelease is gone
+		return v1alpha1.RedpandaNotReady(rp, cond.Reason, cond.Message), ctrl.Result{Requeue: true}, nil
+	}
+
+	if err = r.repairInternalServiceSelector(ctx, rp); err != nil {
+		log.Error(err, "checking internal service selector")
+	}
+	if err = r.repairHelmMetadata(ctx, rp); err != nil {
+		log.Error(err, "checking helm metadata of chart resources")
+	}
+
+	if err = r.reconcileNetworkPolicy(ctx, rp, hr); err != nil {
+		return rp, ctrl.Result{}, err
+	}
+
+	verified, err := r.reconcileChartVerification(ctx, rp, hr)
+	if err != nil {
+		return rp, ctrl.Result{}, err
+	}
+	if !verified {
+		cond := apimeta.FindStatusCondition(rp.Status.Conditions, ChartUnverifiedCondition)
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.ChartUnverifiedReason, cond.Message), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
+	}
+
+	if hr.Spec.Suspend && rp.Spec.ChartRef.SuspendOnCreate {
+		msg := fmt.Sprintf("HelmRelease '%s/%s' is suspended, set the %s annotation to \"true\" to deploy it", hr.Namespace, hr.Name, v1alpha1.GroupVersion.Group+resumePath)
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.SuspendedOnCreateReason, msg), ctrl.Result{}, nil
+	}
 
 	isGenerationCurrent = hr.Generation != hr.Status.ObservedGeneration
 	isStatusConditionReady = apimeta.IsStatusConditionTrue(hr.Status.Conditions, meta.ReadyCondition)
@@ -580,17 +1245,59 @@ func (r *RedpandaReconciler) reconcile(ctx context.Context, rp *v1alpha1.Redpand
 	isResourceReady = r.checkIfResourceIsReady(log, msgNotReady, msgReady, resourceTypeHelmRelease, isGenerationCurrent, isStatusConditionReady, isStatusReadyNILorTRUE, isStatusReadyNILorFALSE, rp)
 	if !isResourceReady {
 		// need to requeue in this case
-		return v1alpha1.RedpandaNotReady(rp, "ArtifactFailed", msgNotReady), ctrl.Result{RequeueAfter: r.RequeueHelmDeps}, nil
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.ArtifactFailedReason, msgNotReady), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
 	}
 
-	return v1alpha1.RedpandaReady(rp), ctrl.Result{}, nil
+	externalReady, err := r.reconcileExternalEndpoints(ctx, rp)
+	if err != nil {
+		return rp, ctrl.Result{}, err
+	}
+	if !externalReady {
+		cond := apimeta.FindStatusCondition(rp.Status.Conditions, ExternalReadyCondition)
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.ExternalNotReadyReason, cond.Message), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
+	}
+
+	requeueAfter, err := r.reconcileReplicaDrift(ctx, rp)
+	if err != nil {
+		log.Error(err, "checking replica drift")
+	}
+	if apimeta.IsStatusConditionTrue(rp.Status.Conditions, VolumeExpansionCondition) && (requeueAfter == 0 || requeueAfter > requeueHelmDeps) {
+		// follow the resize of the broker PVCs
+		requeueAfter = requeueHelmDeps
+	}
+
+	if err = r.reconcileLicense(ctx, rp); err != nil {
+		log.Error(err, "checking license")
+	}
+
+	if err = r.reconcileDependencyVersions(ctx, rp); err != nil {
+		log.Error(err, "checking dependency versions")
+	}
+
+	// Ready summarizes the readiness of all sub-resources
+	reason, msg, err := r.checkSubResourcesReady(ctx, rp)
+	if err != nil {
+		return rp, ctrl.Result{}, err
+	}
+	if reason != "" {
+		return v1alpha1.RedpandaNotReady(rp, reason, msg), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
+	}
+
+	if !apimeta.IsStatusConditionTrue(rp.Status.Conditions, meta.ReadyCondition) {
+		if err = r.runHook(ctx, rp, hookPhasePostReady, hr.Spec.Chart.Spec.Version); err != nil {
+			return v1alpha1.RedpandaNotReady(rp, v1alpha1.HookFailedReason, err.Error()), ctrl.Result{RequeueAfter: requeueHelmDeps}, nil
+		}
+	}
+
+	recordTimeToReady(rp, time.Now())
+	return v1alpha1.RedpandaReady(rp), ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *RedpandaReconciler) checkIfResourceIsReady(log logr.Logger, msgNotReady, msgReady, kind string, isGenerationCurrent, isStatusConditionReady, isStatusReadyNILorTRUE, isStatusReadyNILorFALSE bool, rp *v1alpha1.Redpanda) bool {
 	if isGenerationCurrent || !isStatusConditionReady {
 		// capture event only
 		if isStatusReadyNILorTRUE {
-			r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msgNotReady)
+			r.reasonEvent(rp, v1alpha1.ArtifactFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msgNotReady)
 		}
 
 		switch kind {
@@ -605,14 +1312,16 @@ func (r *RedpandaReconciler) checkIfResourceIsReady(log logr.Logger, msgNotReady
 	} else if isStatusConditionReady && isStatusReadyNILorFALSE {
 		// here since the condition should be true, we update the value to
 		// be true, and send an event
+		readyReason := v1alpha1.HelmReleaseReadyReason
 		switch kind {
 		case resourceTypeHelmRepository:
 			rp.Status.HelmRepositoryReady = ptr.To(true)
+			readyReason = v1alpha1.HelmRepositoryReadyReason
 		case resourceTypeHelmRelease:
 			rp.Status.HelmReleaseReady = ptr.To(true)
 		}
 
-		r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msgReady)
+		r.event(rp, readyReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msgReady)
 	}
 
 	return true
@@ -624,40 +1333,108 @@ func (r *RedpandaReconciler) reconcileHelmRelease(ctx context.Context, rp *v1alp
 	// Check if HelmRelease exists or create it
 	hr := &helmv2beta1.HelmRelease{}
 
-	// have we recorded a helmRelease, if not assume we have not created it
-	if rp.Status.HelmRelease == "" {
-		// did not find helmRelease, then create it
-		hr, err = r.createHelmRelease(ctx, rp)
+	// if we recorded a helmRelease, we assume at some point this existed, let's check
+	if rp.Status.HelmRelease != "" {
+		key := types.NamespacedName{Namespace: rp.Namespace, Name: rp.Status.GetHelmRelease()}
+		if err = r.Client.Get(ctx, key, hr); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return rp, hr, fmt.Errorf("failed to get HelmRelease '%s/%s': %w", rp.Namespace, rp.Status.HelmRelease, err)
+			}
+			rp.Status.HelmRelease = ""
+			apimeta.RemoveStatusCondition(rp.GetConditions(), ReleaseUninstallingCondition)
+		}
+	}
+
+	if deleted, err := r.recreateHelmRelease(ctx, rp, hr); err != nil || deleted {
 		return rp, hr, err
 	}
 
-	// if we are not empty, then we assume at some point this existed, let's check
-	key := types.NamespacedName{Namespace: rp.Namespace, Name: rp.Status.GetHelmRelease()}
-	err = r.Client.Get(ctx, key, hr)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			rp.Status.HelmRelease = ""
-			hr, err = r.createHelmRelease(ctx, rp)
+	// have we recorded a helmRelease, if not assume we have not created it
+	if rp.Status.HelmRelease == "" {
+		var created bool
+		hr, created, err = r.createHelmRelease(ctx, rp)
+		if err != nil || created {
 			return rp, hr, err
 		}
-		// if this is a not found error
-		return rp, hr, fmt.Errorf("failed to get HelmRelease '%s/%s': %w", rp.Namespace, rp.Status.HelmRelease, err)
+		// the HelmRelease was created concurrently, evaluate its drift below
+		// rather than waiting for the next reconcile
+	}
+
+	if !hr.DeletionTimestamp.IsZero() {
+		// updating a HelmRelease being uninstalled races with its deletion,
+		// wait for it to be gone and create it again
+		msg := fmt.Sprintf("HelmRelease '%s/%s' is being uninstalled, waiting for its deletion", hr.Namespace, hr.Name)
+		if !apimeta.IsStatusConditionTrue(rp.Status.Conditions, ReleaseUninstallingCondition) {
+			r.event(rp, v1alpha1.HelmReleaseDeletingReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msg)
+		}
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               ReleaseUninstallingCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.HelmReleaseDeletingReason,
+			Message:            msg,
+		})
+		return rp, hr, nil
+	}
+	apimeta.RemoveStatusCondition(rp.GetConditions(), ReleaseUninstallingCondition)
+
+	if err = r.reconcileHelmReleaseOwnership(ctx, rp, hr); err != nil {
+		return rp, hr, err
 	}
 
 	// Check if we need to update here
 	hrTemplate, errTemplated := r.createHelmReleaseFromTemplate(ctx, rp)
 	if errTemplated != nil {
-		r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, errTemplated.Error())
+		r.event(rp, v1alpha1.HelmReleaseFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, errTemplated.Error())
 		return rp, hr, errTemplated
 	}
 
-	if r.helmReleaseRequiresUpdate(ctx, hr, hrTemplate) {
+	r.blockChartDowngrade(rp, hr, hrTemplate)
+	r.gateChartUpgrade(rp, hr, hrTemplate)
+
+	resume := r.applySuspendOnCreate(rp, hr, hrTemplate)
+
+	if isMaterialChangesOnly(rp) && !resume && !helmReleaseMateriallyChanged(hr, hrTemplate) {
+		Debugf(ctrl.LoggerFrom(ctx), "values SHA and chart version of HelmRelease '%s/%s' unchanged, skipping update", hr.Namespace, hr.Name)
+		return rp, hr, nil
+	}
+
+	changes := helmReleaseChanges(hr, hrTemplate)
+	if resume || len(changes) > 0 {
+		if r.ValuesPreflight {
+			if err = r.preflightValues(ctx, rp, hr, hrTemplate); err != nil {
+				return rp, hr, err
+			}
+		}
+
+		if err = r.runHook(ctx, rp, hookPhasePreUpgrade, hrTemplate.Spec.Chart.Spec.Version); err != nil {
+			return rp, hr, err
+		}
+
 		hr.Spec = hrTemplate.Spec
+		if hr.Annotations == nil {
+			hr.Annotations = map[string]string{}
+		}
+		hr.Annotations[v1alpha1.GroupVersion.Group+valuesSHAPath] = hrTemplate.Annotations[v1alpha1.GroupVersion.Group+valuesSHAPath]
+		if valuesFromDataChanged(hr, hrTemplate) {
+			// the spec may be unchanged, request a reconcile so that the
+			// helm controller picks up the new valuesFrom data
+			hr.Annotations[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+		}
+		if sha, ok := hrTemplate.Annotations[v1alpha1.GroupVersion.Group+valuesFromSHAPath]; ok {
+			hr.Annotations[v1alpha1.GroupVersion.Group+valuesFromSHAPath] = sha
+		} else {
+			delete(hr.Annotations, v1alpha1.GroupVersion.Group+valuesFromSHAPath)
+		}
 		if err = r.Client.Update(ctx, hr); err != nil {
-			r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, err.Error())
+			r.event(rp, v1alpha1.HelmReleaseFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, err.Error())
 			return rp, hr, err
 		}
-		r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRelease '%s/%s' updated", rp.Namespace, rp.GetHelmReleaseName()))
+		msg := fmt.Sprintf("HelmRelease '%s/%s' updated", rp.Namespace, rp.GetHelmReleaseName())
+		if len(changes) > 0 {
+			msg = fmt.Sprintf("%s because %s changed", msg, strings.Join(changes, ", "))
+		}
+		r.event(rp, v1alpha1.HelmReleaseUpdatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msg)
 		rp.Status.HelmRelease = rp.GetHelmReleaseName()
 	}
 
@@ -665,29 +1442,150 @@ func (r *RedpandaReconciler) reconcileHelmRelease(ctx context.Context, rp *v1alp
 }
 
 func (r *RedpandaReconciler) reconcileHelmRepository(ctx context.Context, rp *v1alpha1.Redpanda) (*v1alpha1.Redpanda, *sourcev1.HelmRepository, error) {
+	if rp.Spec.ChartRef.LocalChartPath != "" {
+		return r.reconcileLocalChartRepository(ctx, rp)
+	}
+	if rp.Spec.ChartRef.ExistingRepositoryName != "" {
+		return r.reconcileExistingHelmRepository(ctx, rp)
+	}
+
+	if err := r.validateRegistrySecret(ctx, rp); err != nil {
+		r.event(rp, v1alpha1.RegistrySecretNotFoundReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("invalid registrySecretRef: %s", err))
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.RegistrySecretNotFoundReason, err.Error()), &sourcev1.HelmRepository{}, err
+	}
+
+	if err := r.validateCABundleSecret(ctx, rp); err != nil {
+		r.event(rp, v1alpha1.CABundleInvalidReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("invalid caBundleSecretRef: %s", err))
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.CABundleInvalidReason, err.Error()), &sourcev1.HelmRepository{}, err
+	}
+
+	repo, err := r.applyHelmRepository(ctx, rp, r.createHelmRepositoryFromTemplate(rp))
+	if err != nil {
+		return rp, repo, err
+	}
+	rp.Status.HelmRepository = rp.GetHelmRepositoryName()
+
+	active, err := r.reconcileChartSourceFailover(ctx, rp, repo)
+	if err != nil {
+		return rp, repo, err
+	}
+	return rp, active, nil
+}
+
+// applyHelmRepository creates the given HelmRepository or updates the
+// repository settings of the existing one.
+func (r *RedpandaReconciler) applyHelmRepository(ctx context.Context, rp *v1alpha1.Redpanda, repoTemplate *sourcev1.HelmRepository) (*sourcev1.HelmRepository, error) {
 	// Check if HelmRepository exists or create it
 	repo := &sourcev1.HelmRepository{}
-	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetHelmRepositoryName()}, repo); err != nil {
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(repoTemplate), repo); err != nil {
 		if apierrors.IsNotFound(err) {
-			repo = r.createHelmRepositoryFromTemplate(rp)
+			repo = repoTemplate
 			if errCreate := r.Client.Create(ctx, repo); errCreate != nil {
-				r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("error creating HelmRepository: %s", errCreate))
-				return rp, repo, fmt.Errorf("error creating HelmRepository: %w", errCreate)
+				r.event(rp, v1alpha1.HelmRepositoryFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("error creating HelmRepository: %s", errCreate))
+				return repo, fmt.Errorf("error creating HelmRepository: %w", errCreate)
 			}
-			r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRepository '%s/%s' created ", rp.Namespace, rp.GetHelmRepositoryName()))
+			r.event(rp, v1alpha1.HelmRepositoryCreatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRepository '%s/%s' created ", repo.Namespace, repo.Name))
 		} else {
-			r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("error getting HelmRepository: %s", err))
-			return rp, repo, fmt.Errorf("error getting HelmRepository: %w", err)
+			r.event(rp, v1alpha1.HelmRepositoryFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("error getting HelmRepository: %s", err))
+			return repo, fmt.Errorf("error getting HelmRepository: %w", err)
+		}
+	} else if helmRepositoryRequiresUpdate(repo, repoTemplate) {
+		repo.Spec.URL = repoTemplate.Spec.URL
+		repo.Spec.Type = repoTemplate.Spec.Type
+		repo.Spec.SecretRef = repoTemplate.Spec.SecretRef
+		if errUpdate := r.Client.Update(ctx, repo); errUpdate != nil {
+			r.event(rp, v1alpha1.HelmRepositoryFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("error updating HelmRepository: %s", errUpdate))
+			return repo, fmt.Errorf("error updating HelmRepository: %w", errUpdate)
 		}
+		r.event(rp, v1alpha1.HelmRepositoryUpdatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRepository '%s/%s' updated", repo.Namespace, repo.Name))
+	}
+
+	if msg := helmRepositoryAuthFailureMessage(rp, repo); msg != "" {
+		r.event(rp, v1alpha1.HelmRepositoryAuthFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+
+	return repo, nil
+}
+
+// reconcileExistingHelmRepository verifies that the externally managed
+// HelmRepository referenced by ChartRef.ExistingRepositoryName exists. It is
+// never created, updated or owned by the operator, its readiness is checked
+// by the caller.
+func (r *RedpandaReconciler) reconcileExistingHelmRepository(ctx context.Context, rp *v1alpha1.Redpanda) (*v1alpha1.Redpanda, *sourcev1.HelmRepository, error) {
+	repo := &sourcev1.HelmRepository{}
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: rp.Spec.ChartRef.ExistingRepositoryName}
+	if err := r.Client.Get(ctx, key, repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			msg := fmt.Sprintf("existing HelmRepository '%s' referenced by existingRepositoryName not found", key)
+			r.event(rp, v1alpha1.HelmRepositoryNotFoundReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+			return v1alpha1.RedpandaNotReady(rp, v1alpha1.HelmRepositoryNotFoundReason, msg), repo, errors.New(msg)
+		}
+		r.event(rp, v1alpha1.HelmRepositoryFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("error getting HelmRepository: %s", err))
+		return rp, repo, fmt.Errorf("error getting HelmRepository: %w", err)
+	}
+	rp.Status.HelmRepository = repo.Name
+	rp.Status.ActiveHelmRepository = repo.Name
+	apimeta.RemoveStatusCondition(rp.GetConditions(), HelmRepositoryNotReadyCondition)
+
+	// fallback URLs are ignored for an existing HelmRepository
+	if err := r.deleteStaleFallbackHelmRepositories(ctx, rp, 0); err != nil {
+		return rp, repo, err
 	}
-	rp.Status.HelmRepository = rp.GetHelmRepositoryName()
 
 	return rp, repo, nil
 }
 
+// isTeardownRequested reports whether the Redpanda carries the teardown annotation.
+func isTeardownRequested(rp *v1alpha1.Redpanda) bool {
+	return rp.Annotations[v1alpha1.GroupVersion.Group+teardownPath] == "true"
+}
+
+// reconcileTeardown runs the delete path, removing the HelmRelease, without
+// deleting the Redpanda itself. Removing the annotation before the teardown
+// completes makes the next reconcile recreate the HelmRelease.
+func (r *RedpandaReconciler) reconcileTeardown(ctx context.Context, rp *v1alpha1.Redpanda) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.reconcileTeardown")
+
+	result := ctrl.Result{}
+	cond := metav1.Condition{
+		Type:               TeardownCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.TeardownCompletedReason,
+		Message:            "HelmRelease removed, remove the teardown annotation to deploy the cluster again",
+	}
+
+	if err := r.deleteHelmRelease(ctx, rp); err != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = v1alpha1.TeardownInProgressReason
+		cond.Message = fmt.Sprintf("removing HelmRelease: %s", err)
+		result = ctrl.Result{RequeueAfter: r.requeueInterval(rp)}
+	}
+
+	if !apimeta.IsStatusConditionPresentAndEqual(rp.Status.Conditions, TeardownCondition, cond.Status) {
+		r.event(rp, cond.Reason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, cond.Message)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), cond)
+	rp = v1alpha1.RedpandaNotReady(rp, cond.Reason, cond.Message)
+
+	if err := r.patchRedpandaStatus(ctx, rp); err != nil {
+		log.Error(err, "unable to update status after teardown")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	return result, nil
+}
+
 func (r *RedpandaReconciler) reconcileDelete(ctx context.Context, rp *v1alpha1.Redpanda) (ctrl.Result, error) {
 	if err := r.deleteHelmRelease(ctx, rp); err != nil {
-		return ctrl.Result{}, err
+		if errors.Is(err, errDestructiveActionBlocked) {
+			// keep the finalizer, adding the annotation triggers a reconcile
+			return ctrl.Result{}, nil
+		}
+		return r.forceFinalizeAfterTimeout(ctx, rp, err)
+	}
+	if err := r.deleteCertManager(ctx, rp); err != nil {
+		return r.forceFinalizeAfterTimeout(ctx, rp, err)
 	}
 	if controllerutil.ContainsFinalizer(rp, FinalizerKey) {
 		controllerutil.RemoveFinalizer(rp, FinalizerKey)
@@ -698,29 +1596,60 @@ func (r *RedpandaReconciler) reconcileDelete(ctx context.Context, rp *v1alpha1.R
 	return ctrl.Result{}, nil
 }
 
-func (r *RedpandaReconciler) createHelmRelease(ctx context.Context, rp *v1alpha1.Redpanda) (*helmv2beta1.HelmRelease, error) {
+// createHelmRelease creates the HelmRelease of the given Redpanda and reports
+// whether it did. If the HelmRelease already exists, e.g. because a previous
+// reconcile created it but failed to record it in the status, it is fetched
+// and returned instead, unless another Redpanda owns it.
+func (r *RedpandaReconciler) createHelmRelease(ctx context.Context, rp *v1alpha1.Redpanda) (*helmv2beta1.HelmRelease, bool, error) {
 	// create helmRelease resource from template
 	hRelease, err := r.createHelmReleaseFromTemplate(ctx, rp)
 	if err != nil {
-		r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("could not create helm release template: %s", err))
-		return hRelease, fmt.Errorf("could not create HelmRelease template: %w", err)
+		r.event(rp, v1alpha1.HelmReleaseFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("could not create helm release template: %s", err))
+		return hRelease, false, fmt.Errorf("could not create HelmRelease template: %w", err)
+	}
+
+	if rp.Spec.ChartRef.SuspendOnCreate && !isResumeRequested(rp) {
+		hRelease.Spec.Suspend = true
 	}
 
 	// create helmRelease object here
 	if err := r.Client.Create(ctx, hRelease); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
-			r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, err.Error())
-			return hRelease, fmt.Errorf("failed to create HelmRelease '%s/%s': %w", rp.Namespace, rp.Status.HelmRelease, err)
+			r.event(rp, v1alpha1.HelmReleaseFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, err.Error())
+			return hRelease, false, fmt.Errorf("failed to create HelmRelease '%s/%s': %w", rp.Namespace, rp.GetHelmReleaseName(), err)
 		}
-		// we already exist, then update the status to rp
-		rp.Status.HelmRelease = rp.GetHelmReleaseName()
+		return r.adoptExistingHelmRelease(ctx, rp)
 	}
 
 	// we have created the resource, so we are ok to update events, and update the helmRelease name on the status object
-	r.event(rp, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRelease '%s/%s' created ", rp.Namespace, rp.GetHelmReleaseName()))
+	r.event(rp, v1alpha1.HelmReleaseCreatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRelease '%s/%s' created ", rp.Namespace, rp.GetHelmReleaseName()))
 	rp.Status.HelmRelease = rp.GetHelmReleaseName()
 
-	return hRelease, nil
+	return hRelease, true, nil
+}
+
+// adoptExistingHelmRelease fetches the HelmRelease of the given Redpanda that
+// already exists and records it in the status. A HelmRelease owned by
+// another Redpanda is not adopted. Missing or stale owner references are
+// handled by reconcileHelmReleaseOwnership.
+func (r *RedpandaReconciler) adoptExistingHelmRelease(ctx context.Context, rp *v1alpha1.Redpanda) (*helmv2beta1.HelmRelease, bool, error) {
+	hr := &helmv2beta1.HelmRelease{}
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetHelmReleaseName()}
+	if err := r.Client.Get(ctx, key, hr); err != nil {
+		return hr, false, fmt.Errorf("failed to get existing HelmRelease '%s': %w", key, err)
+	}
+
+	for _, ref := range hr.OwnerReferences {
+		if ref.Kind == "Redpanda" && ref.UID != rp.UID && ref.Name != rp.Name {
+			err := fmt.Errorf("HelmRelease '%s' already exists and is owned by Redpanda '%s/%s'", key, rp.Namespace, ref.Name)
+			r.event(rp, v1alpha1.ReleaseNameCollisionReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, err.Error())
+			return hr, false, err
+		}
+	}
+
+	Debugf(ctrl.LoggerFrom(ctx), "HelmRelease '%s' already exists, adopting it", key)
+	rp.Status.HelmRelease = rp.GetHelmReleaseName()
+	return hr, false, nil
 }
 
 func (r *RedpandaReconciler) deleteHelmRelease(ctx context.Context, rp *v1alpha1.Redpanda) error {
@@ -735,11 +1664,43 @@ func (r *RedpandaReconciler) deleteHelmRelease(ctx context.Context, rp *v1alpha1
 		if apierrors.IsNotFound(err) {
 			rp.Status.HelmRelease = ""
 			rp.Status.HelmRepository = ""
+			rp.Status.TargetNamespace = ""
+			rp.Status.ReleaseName = ""
 			return nil
 		}
 		return fmt.Errorf("failed to get HelmRelease '%s': %w", rp.Status.HelmRelease, err)
 	}
 
+	action := fmt.Sprintf("delete HelmRelease '%s/%s'", hr.Namespace, hr.Name)
+	if !destructiveActionAllowed(ctrl.LoggerFrom(ctx), r.SafeMode, rp, action) {
+		r.event(rp, v1alpha1.DestructiveActionBlockedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, safeModeMessage(action))
+		return errDestructiveActionBlocked
+	}
+
+	// deleteHelmRelease is called again while resources ahead of the
+	// HelmRelease are deleted, the hook is only called the first time
+	if _, called := hr.Annotations[v1alpha1.GroupVersion.Group+hookPreDeleteCalledPath]; !called && hr.DeletionTimestamp.IsZero() {
+		if err = r.runHook(ctx, rp, hookPhasePreDelete, hr.Spec.Chart.Spec.Version); err != nil {
+			return err
+		}
+		patch := client.MergeFrom(hr.DeepCopy())
+		if hr.Annotations == nil {
+			hr.Annotations = map[string]string{}
+		}
+		hr.Annotations[v1alpha1.GroupVersion.Group+hookPreDeleteCalledPath] = "true"
+		if err = r.Client.Patch(ctx, &hr, patch); err != nil {
+			return fmt.Errorf("recording pre-delete hook on HelmRelease '%s/%s': %w", hr.Namespace, hr.Name, err)
+		}
+	}
+
+	done, err := r.preDeleteResources(ctx, rp, &hr)
+	if err != nil {
+		return fmt.Errorf("deleting resources ahead of helm release (%s): %w", rp.Name, err)
+	}
+	if !done {
+		return errors.New("wait for the deletion of resources ahead of helm release")
+	}
+
 	foregroundDeletePropagation := metav1.DeletePropagationForeground
 
 	if err = r.Client.Delete(ctx, &hr, &client.DeleteOptions{
@@ -748,22 +1709,60 @@ func (r *RedpandaReconciler) deleteHelmRelease(ctx context.Context, rp *v1alpha1
 		return fmt.Errorf("deleting helm release connected with Redpanda (%s): %w", rp.Name, err)
 	}
 
+	if err = r.reportDeletionBlocked(ctx, rp, &hr); err != nil {
+		return fmt.Errorf("reporting blocked helm release deletion (%s): %w", rp.Name, err)
+	}
+
 	return errors.New("wait for helm release deletion")
 }
 
 func (r *RedpandaReconciler) createHelmReleaseFromTemplate(ctx context.Context, rp *v1alpha1.Redpanda) (*helmv2beta1.HelmRelease, error) {
 	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.createHelmReleaseFromTemplate")
 
-	values, err := rp.ValuesJSON()
+	values, err := r.buildValues(ctx, rp)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse clusterSpec to json: %w", err)
+		return nil, err
+	}
+	if err = r.reportUnreconciledFields(rp, values); err != nil {
+		return nil, err
 	}
 
 	hasher := sha256.New()
 	hasher.Write(values.Raw)
 	sha := base64.URLEncoding.EncodeToString(hasher.Sum(nil))
-	// TODO possibly add the SHA to the status
 	log.Info(fmt.Sprintf("SHA of values file to use: %s", sha))
+	r.states.update(client.ObjectKeyFromObject(rp), func(s *ReconcileState) {
+		s.ValuesSHA = sha
+	})
+
+	if err = validatePostRenderers(rp.Spec.ChartRef.PostRenderers); err != nil {
+		return nil, fmt.Errorf("invalid postRenderers: %w", err)
+	}
+
+	if err = r.validateDependsOn(rp); err != nil {
+		return nil, fmt.Errorf("invalid dependsOn: %w", err)
+	}
+
+	if err = r.validateServiceAccount(ctx, rp); err != nil {
+		return nil, fmt.Errorf("invalid serviceAccountName: %w", err)
+	}
+
+	if err = validateReleaseName(rp.Spec.ChartRef.ReleaseName); err != nil {
+		return nil, fmt.Errorf("invalid releaseName: %w", err)
+	}
+
+	if err = validateValuesFrom(rp.Spec.ChartRef.ValuesFrom); err != nil {
+		return nil, fmt.Errorf("invalid valuesFrom: %w", err)
+	}
+
+	valuesFromSHA, err := r.valuesFromSHA(ctx, rp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid valuesFrom: %w", err)
+	}
+
+	if err = validateConsoleResources(rp); err != nil {
+		return nil, fmt.Errorf("invalid console resources: %w", err)
+	}
 
 	timeout := rp.Spec.ChartRef.Timeout
 	if timeout == nil {
@@ -795,11 +1794,19 @@ func (r *RedpandaReconciler) createHelmReleaseFromTemplate(ctx context.Context,
 		}
 	}
 
+	annotations := map[string]string{
+		v1alpha1.GroupVersion.Group + valuesSHAPath: sha,
+	}
+	if valuesFromSHA != "" {
+		annotations[v1alpha1.GroupVersion.Group+valuesFromSHAPath] = valuesFromSHA
+	}
+
 	return &helmv2beta1.HelmRelease{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            rp.GetHelmReleaseName(),
 			Namespace:       rp.Namespace,
 			OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
+			Annotations:     annotations,
 		},
 		Spec: helmv2beta1.HelmReleaseSpec{
 			Chart: helmv2beta1.HelmChartTemplate{
@@ -809,87 +1816,252 @@ func (r *RedpandaReconciler) createHelmReleaseFromTemplate(ctx context.Context,
 					Interval: &metav1.Duration{Duration: 1 * time.Minute},
 					SourceRef: helmv2beta1.CrossNamespaceObjectReference{
 						Kind:      "HelmRepository",
-						Name:      rp.GetHelmRepositoryName(),
+						Name:      rp.GetActiveHelmRepositoryName(),
 						Namespace: rp.Namespace,
 					},
+					Verify: rp.Spec.ChartRef.Verify.DeepCopy(),
 				},
 			},
-			Values:   values,
-			Interval: metav1.Duration{Duration: 30 * time.Second},
-			Timeout:  timeout,
-			Upgrade:  upgrade,
+			Values:             values,
+			Interval:           metav1.Duration{Duration: 30 * time.Second},
+			Timeout:            timeout,
+			Upgrade:            upgrade,
+			PostRenderers:      rp.Spec.ChartRef.PostRenderers,
+			DependsOn:          rp.Spec.ChartRef.DependsOn,
+			ServiceAccountName: rp.Spec.ChartRef.ServiceAccountName,
+			ValuesFrom:         rp.Spec.ChartRef.ValuesFrom,
+			ReleaseName:        rp.Spec.ChartRef.ReleaseName,
 		},
 	}, nil
 }
 
 func (r *RedpandaReconciler) createHelmRepositoryFromTemplate(rp *v1alpha1.Redpanda) *sourcev1.HelmRepository {
+	return helmRepositoryFromTemplate(rp, rp.GetHelmRepositoryName(), rp.Spec.ChartRef.GetRepositoryURL())
+}
+
+// helmRepositoryFromTemplate returns the HelmRepository of the given Redpanda
+// fetching the chart from the given URL.
+func helmRepositoryFromTemplate(rp *v1alpha1.Redpanda, name, url string) *sourcev1.HelmRepository {
 	return &sourcev1.HelmRepository{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rp.GetHelmRepositoryName(),
+			Name:            name,
 			Namespace:       rp.Namespace,
 			OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
 		},
 		Spec: sourcev1.HelmRepositorySpec{
-			Interval: metav1.Duration{Duration: 30 * time.Second},
-			URL:      v1alpha1.RedpandaChartRepository,
+			Interval:      metav1.Duration{Duration: 30 * time.Second},
+			URL:           url,
+			Type:          helmRepositoryType(url),
+			SecretRef:     rp.Spec.ChartRef.RegistrySecretRef.DeepCopy(),
+			CertSecretRef: rp.Spec.ChartRef.CABundleSecretRef.DeepCopy(),
 		},
 	}
 }
 
+// patchRedpandaStatus patches the status of the Redpanda. The patch is
+// skipped when the status does not differ from the stored one apart from a
+// LastReconcileTime younger than the LastReconcileTime refresh, so that
+// no-op reconciles do not write to the API server on every run.
 func (r *RedpandaReconciler) patchRedpandaStatus(ctx context.Context, rp *v1alpha1.Redpanda) error {
 	key := client.ObjectKeyFromObject(rp)
 	latest := &v1alpha1.Redpanda{}
 	if err := r.Client.Get(ctx, key, latest); err != nil {
 		return err
 	}
+	if isRedpandaStatusUnchanged(&latest.Status, &rp.Status, r.lastReconcileTimeRefresh()) {
+		return nil
+	}
 	return r.Client.Status().Patch(ctx, rp, client.MergeFrom(latest))
 }
 
-// event emits a Kubernetes event and forwards the event to notification controller if configured.
-func (r *RedpandaReconciler) event(rp *v1alpha1.Redpanda, revision, severity, msg string) {
+func (r *RedpandaReconciler) lastReconcileTimeRefresh() time.Duration {
+	if r.LastReconcileTimeRefresh > 0 {
+		return r.LastReconcileTimeRefresh
+	}
+	return defaultLastReconcileTimeRefresh
+}
+
+// isRedpandaStatusUnchanged reports whether the computed status equals the
+// stored one. LastReconcileTime is ignored until the stored one is older than
+// refresh.
+func isRedpandaStatusUnchanged(stored, computed *v1alpha1.RedpandaStatus, refresh time.Duration) bool {
+	a, b := stored.DeepCopy(), computed.DeepCopy()
+	if a.LastReconcileTime != nil && b.LastReconcileTime != nil && b.LastReconcileTime.Sub(a.LastReconcileTime.Time) < refresh {
+		a.LastReconcileTime, b.LastReconcileTime = nil, nil
+	}
+	return equality.Semantic.DeepEqual(a, b)
+}
+
+// validateServiceAccount checks that the ServiceAccount the HelmRelease is
+// reconciled with exists in the namespace of the Redpanda.
+func (r *RedpandaReconciler) validateServiceAccount(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	name := rp.Spec.ChartRef.ServiceAccountName
+	if name == "" {
+		return nil
+	}
+
+	var sa v1.ServiceAccount
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: name}, &sa); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("ServiceAccount '%s/%s' not found", rp.Namespace, name)
+		}
+		return fmt.Errorf("get ServiceAccount '%s/%s': %w", rp.Namespace, name, err)
+	}
+	return nil
+}
+
+// validateReleaseName checks the Helm release name constraints: a DNS
+// subdomain of at most 53 characters, leaving room for the suffixes of the
+// resources of the chart.
+func validateReleaseName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if len(name) > maxReleaseNameLength {
+		return fmt.Errorf("%q is longer than %d characters", name, maxReleaseNameLength)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("%q: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateDependsOn rejects DependsOn references without a name, and those
+// pointing outside of the Redpanda namespace when cross namespace references
+// are disabled.
+func (r *RedpandaReconciler) validateDependsOn(rp *v1alpha1.Redpanda) error {
+	for i, dep := range rp.Spec.ChartRef.DependsOn {
+		if dep.Name == "" {
+			return fmt.Errorf("dependsOn[%d]: name is required", i)
+		}
+		if r.NoCrossNamespaceRef && dep.Namespace != "" && dep.Namespace != rp.Namespace {
+			return fmt.Errorf("dependsOn[%d]: cross namespace reference to %s/%s is not allowed", i, dep.Namespace, dep.Name)
+		}
+	}
+	return nil
+}
+
+// statusSummary returns a concise description of the Redpanda state, e.g.
+// "HelmRelease Ready, chart 5.7.1, 3 brokers".
+func statusSummary(rp *v1alpha1.Redpanda) string {
+	release := "HelmRelease Not Ready"
+	if ptr.Deref(rp.Status.HelmReleaseReady, false) {
+		release = "HelmRelease Ready"
+	}
+
+	chartVersion := rp.Spec.ChartRef.ChartVersion
+	if chartVersion == "" {
+		chartVersion = "latest"
+	}
+
+	summary := fmt.Sprintf("%s, chart %s", release, chartVersion)
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.Statefulset != nil && rp.Spec.ClusterSpec.Statefulset.Replicas != nil {
+		summary = fmt.Sprintf("%s, %d brokers", summary, *rp.Spec.ClusterSpec.Statefulset.Replicas)
+	}
+	return summary
+}
+
+// event emits a Kubernetes event with the given reason and the type given by
+// the EventPolicies of the reason, and forwards it to the events receiver if
+// configured. Dropped events are only logged.
+func (r *RedpandaReconciler) event(rp *v1alpha1.Redpanda, reason, revision, severity, msg string) {
+	eventType := r.eventPolicy(reason, severity)
+	if eventType == EventPolicyDrop {
+		Debugf(ctrl.Log.WithName("RedpandaReconciler.event"), "dropped %s event for Redpanda '%s/%s': %s", reason, rp.Namespace, rp.Name, msg)
+		return
+	}
+
 	var metaData map[string]string
 	if revision != "" {
 		metaData = map[string]string{v2.GroupVersion.Group + "/revision": revision}
 	}
-	eventType := "Normal"
-	if severity == v1alpha1.EventSeverityError {
-		eventType = "Warning"
+	r.EventRecorder.AnnotatedEventf(rp, metaData, eventType, reason, msg)
+
+	if r.StructuredEventsAddr != "" {
+		r.postStructuredEvent(rp, reason, revision, severity, msg)
 	}
-	r.EventRecorder.AnnotatedEventf(rp, metaData, eventType, severity, msg)
 }
 
+// helmReleaseRequiresUpdate reports whether helmReleaseChanges found any
+// change between the HelmRelease and the template.
 func (r *RedpandaReconciler) helmReleaseRequiresUpdate(ctx context.Context, hr, hrTemplate *helmv2beta1.HelmRelease) bool {
-	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.helmReleaseRequiresUpdate")
-
-	switch {
-	case !reflect.DeepEqual(hr.GetValues(), hrTemplate.GetValues()):
-		log.Info("values found different")
-		return true
-	case helmChartRequiresUpdate(log, &hr.Spec.Chart, &hrTemplate.Spec.Chart):
-		log.Info("chartTemplate found different")
-		return true
-	case hr.Spec.Interval != hrTemplate.Spec.Interval:
-		log.Info("interval found different")
-		return true
-	default:
-		return false
+	changes := helmReleaseChanges(hr, hrTemplate)
+	if len(changes) > 0 {
+		ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.helmReleaseRequiresUpdate").Info("HelmRelease found different", "changes", changes)
+	}
+	return len(changes) > 0
+}
+
+// helmReleaseChanges returns the aspects of the given HelmRelease that
+// differ from the template, e.g. values or chart.version, in a stable order.
+// An empty result means no update is required.
+func helmReleaseChanges(hr, hrTemplate *helmv2beta1.HelmRelease) []string {
+	var changes []string
+	if !reflect.DeepEqual(hr.GetValues(), hrTemplate.GetValues()) {
+		changes = append(changes, "values")
+	}
+	changes = append(changes, helmChartChanges(&hr.Spec.Chart, &hrTemplate.Spec.Chart)...)
+	if hr.Spec.Interval != hrTemplate.Spec.Interval {
+		changes = append(changes, "interval")
+	}
+	if !reflect.DeepEqual(hr.Spec.PostRenderers, hrTemplate.Spec.PostRenderers) {
+		changes = append(changes, "postRenderers")
+	}
+	if !reflect.DeepEqual(hr.Spec.DependsOn, hrTemplate.Spec.DependsOn) {
+		changes = append(changes, "dependsOn")
+	}
+	if hr.Spec.ServiceAccountName != hrTemplate.Spec.ServiceAccountName {
+		changes = append(changes, "serviceAccountName")
 	}
+	if !reflect.DeepEqual(hr.Spec.ValuesFrom, hrTemplate.Spec.ValuesFrom) {
+		changes = append(changes, "valuesFrom")
+	}
+	if hr.Spec.ReleaseName != hrTemplate.Spec.ReleaseName {
+		changes = append(changes, "releaseName")
+	}
+	if valuesFromDataChanged(hr, hrTemplate) {
+		changes = append(changes, "valuesFrom data")
+	}
+	return changes
+}
+
+// valuesFromDataChanged reports whether the data referenced in valuesFrom
+// differs from the data the existing HelmRelease was last updated with.
+func valuesFromDataChanged(hr, hrTemplate *helmv2beta1.HelmRelease) bool {
+	key := v1alpha1.GroupVersion.Group + valuesFromSHAPath
+	return hr.Annotations[key] != hrTemplate.Annotations[key]
 }
 
 // helmChartRequiresUpdate compares the v2beta1.HelmChartTemplate of the
 // v2beta1.HelmRelease to the given v1beta2.HelmChart to determine if an
 // update is required.
 func helmChartRequiresUpdate(log logr.Logger, template, chart *helmv2beta1.HelmChartTemplate) bool {
-	switch {
-	case template.Spec.Chart != chart.Spec.Chart:
-		log.Info("chart is different")
-		return true
-	case template.Spec.Version != "" && template.Spec.Version != chart.Spec.Version:
-		log.Info("spec version is different")
-		return true
-	default:
-		return false
+	changes := helmChartChanges(template, chart)
+	if len(changes) > 0 {
+		log.Info("chartTemplate found different", "changes", changes)
+	}
+	return len(changes) > 0
+}
+
+// helmChartChanges returns the aspects of the given chart template that
+// differ from the desired one, prefixed with "chart".
+func helmChartChanges(template, chart *helmv2beta1.HelmChartTemplate) []string {
+	var changes []string
+	if template.Spec.Chart != chart.Spec.Chart {
+		changes = append(changes, "chart.name")
+	}
+	if template.Spec.Version != "" && template.Spec.Version != chart.Spec.Version {
+		changes = append(changes, "chart.version")
+	}
+	if template.Spec.SourceRef.Kind != chart.Spec.SourceRef.Kind ||
+		template.Spec.SourceRef.Name != chart.Spec.SourceRef.Name ||
+		template.Spec.SourceRef.Namespace != chart.Spec.SourceRef.Namespace {
+		changes = append(changes, "chart.sourceRef")
+	}
+	if !reflect.DeepEqual(template.Spec.Verify, chart.Spec.Verify) {
+		changes = append(changes, "chart.verify")
 	}
+	return changes
 }
 
 func isRedpandaManaged(ctx context.Context, redpandaCluster *v1alpha1.Redpanda) bool {
@@ -903,6 +2075,136 @@ func isRedpandaManaged(ctx context.Context, redpandaCluster *v1alpha1.Redpanda)
 	return true
 }
 
+// reconcileHelmReleaseOwnership makes sure the HelmRelease is owned by the
+// given Redpanda. A missing or foreign owner reference is re-asserted, unless
+// the reassert-ownership annotation is "false", in which case the
+// OwnershipLost condition is set instead.
+func (r *RedpandaReconciler) reconcileHelmReleaseOwnership(ctx context.Context, rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) error {
+	for _, ref := range hr.OwnerReferences {
+		if ref.UID == rp.UID {
+			apimeta.RemoveStatusCondition(rp.GetConditions(), OwnershipLostCondition)
+			return nil
+		}
+	}
+
+	if rp.Annotations[v1alpha1.GroupVersion.Group+reassertOwnershipPath] == "false" {
+		msg := fmt.Sprintf("HelmRelease '%s/%s' is not owned by Redpanda '%s/%s', it will not be garbage collected", hr.Namespace, hr.Name, rp.Namespace, rp.Name)
+		if !apimeta.IsStatusConditionTrue(rp.Status.Conditions, OwnershipLostCondition) {
+			r.event(rp, v1alpha1.OwnerReferenceMissingReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		}
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               OwnershipLostCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.OwnerReferenceMissingReason,
+			Message:            msg,
+		})
+		return nil
+	}
+
+	patch := client.MergeFrom(hr.DeepCopy())
+	owner := rp.OwnerShipRefObj()
+	refs := []metav1.OwnerReference{owner}
+	for _, ref := range hr.OwnerReferences {
+		// drop stale references to a previous Redpanda with the same name
+		if ref.Kind == owner.Kind && ref.Name == owner.Name {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	hr.OwnerReferences = refs
+	if err := r.Client.Patch(ctx, hr, patch); err != nil {
+		return fmt.Errorf("re-asserting owner reference on HelmRelease '%s/%s': %w", hr.Namespace, hr.Name, err)
+	}
+
+	r.event(rp, v1alpha1.OwnerReferenceReassertedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("owner reference of HelmRelease '%s/%s' re-asserted", hr.Namespace, hr.Name))
+	apimeta.RemoveStatusCondition(rp.GetConditions(), OwnershipLostCondition)
+	return nil
+}
+
+// gateChartUpgrade keeps the current chart version on the desired HelmRelease
+// when ChartRef.Approval is 'required' and the new version has not been
+// approved through the approve-upgrade annotation. Value changes still go
+// through. The PendingApproval condition reflects whether an upgrade waits.
+func (r *RedpandaReconciler) gateChartUpgrade(rp *v1alpha1.Redpanda, hr, hrTemplate *helmv2beta1.HelmRelease) {
+	current := hr.Spec.Chart.Spec.Version
+	desired := hrTemplate.Spec.Chart.Spec.Version
+	if rp.Spec.ChartRef.Approval != approvalRequired || current == desired ||
+		rp.Annotations[v1alpha1.GroupVersion.Group+approveUpgradePath] == desired {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), PendingApprovalCondition)
+		return
+	}
+
+	hrTemplate.Spec.Chart.Spec.Version = current
+
+	msg := fmt.Sprintf("chart upgrade from %q to %q requires approval: set the '%s' annotation to %q", current, desired, v1alpha1.GroupVersion.Group+approveUpgradePath, desired)
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, PendingApprovalCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.UpgradeNotApprovedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               PendingApprovalCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.UpgradeNotApprovedReason,
+		Message:            msg,
+	})
+}
+
+// blockChartDowngrade keeps the deployed chart version on the desired
+// HelmRelease when the desired version is lower than the deployed one, as
+// Redpanda does not support downgrades, unless the allow-downgrade annotation
+// is set to "true". The deployed version is the last applied revision of the
+// HelmRelease, or its chart version if it was never applied. Versions that are
+// not semver, e.g. ranges, are not compared.
+func (r *RedpandaReconciler) blockChartDowngrade(rp *v1alpha1.Redpanda, hr, hrTemplate *helmv2beta1.HelmRelease) {
+	deployed := hr.Status.LastAppliedRevision
+	if deployed == "" {
+		deployed = hr.Spec.Chart.Spec.Version
+	}
+	desired := hrTemplate.Spec.Chart.Spec.Version
+
+	deployedVersion, errDeployed := semver.NewVersion(deployed)
+	desiredVersion, errDesired := semver.NewVersion(desired)
+	if errDeployed != nil || errDesired != nil || !desiredVersion.LessThan(deployedVersion) ||
+		rp.Annotations[v1alpha1.GroupVersion.Group+allowDowngradePath] == "true" {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), DowngradeBlockedCondition)
+		return
+	}
+
+	hrTemplate.Spec.Chart.Spec.Version = deployed
+
+	msg := fmt.Sprintf("chart downgrade from %q to %q is blocked as it may lose data: set the '%s' annotation to \"true\" to allow it", deployed, desired, v1alpha1.GroupVersion.Group+allowDowngradePath)
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, DowngradeBlockedCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.DowngradeNotAllowedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               DowngradeBlockedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.DowngradeNotAllowedReason,
+		Message:            msg,
+	})
+}
+
+// isMaterialChangesOnly reports whether the Redpanda opted in to only update
+// its HelmRelease when the values SHA, chart version or chart source change.
+func isMaterialChangesOnly(rp *v1alpha1.Redpanda) bool {
+	return rp.Annotations[v1alpha1.GroupVersion.Group+materialChangesOnlyPath] == "true"
+}
+
+// helmReleaseMateriallyChanged reports whether the values SHA, the valuesFrom
+// data, the chart version or the chart source of the desired HelmRelease
+// differ from the existing one, a chart source failover must not wait for
+// other changes.
+func helmReleaseMateriallyChanged(hr, hrTemplate *helmv2beta1.HelmRelease) bool {
+	key := v1alpha1.GroupVersion.Group + valuesSHAPath
+	return hr.Annotations[key] != hrTemplate.Annotations[key] || valuesFromDataChanged(hr, hrTemplate) ||
+		hr.Spec.Chart.Spec.Version != hrTemplate.Spec.Chart.Spec.Version ||
+		hr.Spec.Chart.Spec.SourceRef.Name != hrTemplate.Spec.Chart.Spec.SourceRef.Name
+}
+
 func disableRedpandaReconciliation(redpandaCluster *vectorzied_v1alpha1.Cluster) {
 	managedAnnotationKey := vectorzied_v1alpha1.GroupVersion.Group + managedPath
 	if redpandaCluster.Annotations == nil {
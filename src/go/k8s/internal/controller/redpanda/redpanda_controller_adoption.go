@@ -0,0 +1,96 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// repairHelmMetadata restores the Helm managed-by label and release
+// annotations of the key chart resources, the internal Service, the
+// ServiceAccount, the PodDisruptionBudget, the StatefulSet and the
+// PodMonitor, when its CRD is installed. Resources of
+// an adopted HelmRelease may lack them, Helm would then refuse to upgrade
+// the release or create duplicates. Unlike the migration, which replaces the
+// metadata, only the missing keys are added. Resources belonging to another
+// release are left alone.
+func (r *RedpandaReconciler) repairHelmMetadata(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.repairHelmMetadata")
+
+	name := internalServiceName(rp)
+	var errs error
+	for _, res := range []struct {
+		kind string
+		obj  client.Object
+	}{
+		{kind: "Service", obj: &corev1.Service{}},
+		{kind: "ServiceAccount", obj: &corev1.ServiceAccount{}},
+		{kind: "PodDisruptionBudget", obj: &policyv1.PodDisruptionBudget{}},
+		{kind: "StatefulSet", obj: &appsv1.StatefulSet{}},
+		{kind: "PodMonitor", obj: newPodMonitor()},
+	} {
+		kind, obj := res.kind, res.obj
+		key := types.NamespacedName{Namespace: rp.Namespace, Name: name}
+		if err := r.Client.Get(ctx, key, obj); err != nil {
+			if !isAbsent(err) {
+				errs = errors.Join(errs, fmt.Errorf("get %s (%s): %w", kind, key, err))
+			}
+			// not created by the chart (yet) or its CRD is not installed
+			continue
+		}
+		if hasLabelsAndAnnotations(obj, rp) {
+			continue
+		}
+		if release, ok := obj.GetAnnotations()["meta.helm.sh/release-name"]; ok && release != rp.GetReleaseName() {
+			log.Info("not repairing helm metadata of a resource of another release", "kind", kind, "name", key, "release", release)
+			continue
+		}
+
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		mergeHelmLabelsAndAnnotations(obj, rp)
+		if err := r.Client.Patch(ctx, obj, patch); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("repairing helm metadata of %s (%s): %w", kind, key, err))
+			continue
+		}
+		r.event(rp, v1alpha1.HelmMetadataRepairedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo,
+			fmt.Sprintf("repaired helm metadata of %s '%s'", kind, key))
+	}
+	return errs
+}
+
+// mergeHelmLabelsAndAnnotations adds the labels and annotations set by
+// setHelmLabelsAndAnnotations to the given object, keeping all others.
+func mergeHelmLabelsAndAnnotations(object client.Object, rp *v1alpha1.Redpanda) {
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels["app.kubernetes.io/managed-by"] = helm
+	object.SetLabels(labels)
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["meta.helm.sh/release-name"] = rp.GetReleaseName()
+	annotations["meta.helm.sh/release-namespace"] = rp.Namespace
+	object.SetAnnotations(annotations)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"fmt"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// reconcileStaleArtifact maintains the StaleArtifact condition of the given
+// Redpanda, set while the artifact of the HelmRepository the chart is
+// fetched from was last updated more than StaleArtifactThreshold ago, e.g.
+// because the source controller stopped refreshing the repository index.
+// Chart versions are then resolved against an outdated index. The condition
+// is only a warning, the reconcile carries on with the stale artifact.
+func (r *RedpandaReconciler) reconcileStaleArtifact(rp *v1alpha1.Redpanda, repo *sourcev1.HelmRepository, now time.Time) {
+	if r.StaleArtifactThreshold <= 0 || repo.Status.Artifact == nil {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), StaleArtifactCondition)
+		return
+	}
+
+	updated := repo.Status.Artifact.LastUpdateTime.Time
+	if now.Sub(updated) <= r.StaleArtifactThreshold {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), StaleArtifactCondition)
+		return
+	}
+
+	msg := fmt.Sprintf("artifact of HelmRepository '%s/%s' was last updated at %s, more than %s ago; chart version resolution may be out of date", repo.Namespace, repo.Name, updated.UTC().Format(time.RFC3339), r.StaleArtifactThreshold)
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, StaleArtifactCondition)
+	if cond == nil || cond.Message != msg {
+		r.reasonEvent(rp, v1alpha1.ArtifactStaleReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               StaleArtifactCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ArtifactStaleReason,
+		Message:            msg,
+	})
+}
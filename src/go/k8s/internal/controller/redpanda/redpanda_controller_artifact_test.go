@@ -0,0 +1,72 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"testing"
+	"time"
+
+	sourcev1api "github.com/fluxcd/source-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestReconcileStaleArtifact(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		updated   *time.Time
+		stale     bool
+	}{
+		{name: "disabled", updated: ptr.To(now.Add(-48 * time.Hour))},
+		{name: "no artifact", threshold: time.Hour},
+		{name: "fresh", threshold: time.Hour, updated: ptr.To(now.Add(-time.Minute))},
+		{name: "stale", threshold: time.Hour, updated: ptr.To(now.Add(-2 * time.Hour)), stale: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			r, recorder := newTestRedpandaReconciler(t)
+			r.StaleArtifactThreshold = tt.threshold
+			repo := &sourcev1.HelmRepository{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-repository", Namespace: "default"}}
+			if tt.updated != nil {
+				repo.Status.Artifact = &sourcev1api.Artifact{LastUpdateTime: metav1.NewTime(*tt.updated)}
+			}
+
+			r.reconcileStaleArtifact(rp, repo, now)
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, StaleArtifactCondition)
+			if !tt.stale {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, v1alpha1.ArtifactStaleReason, cond.Reason)
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, "Warning")
+
+			// the event is only emitted when the artifact changes
+			r.reconcileStaleArtifact(rp, repo, now.Add(time.Minute))
+			assert.Empty(t, recorder.Events)
+
+			repo.Status.Artifact.LastUpdateTime = metav1.NewTime(now)
+			r.reconcileStaleArtifact(rp, repo, now.Add(time.Minute))
+			assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, StaleArtifactCondition))
+		})
+	}
+}
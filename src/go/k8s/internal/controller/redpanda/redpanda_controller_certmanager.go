@@ -0,0 +1,207 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cmapiv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func certManagerTLS(rp *v1alpha1.Redpanda) *v1alpha1.CertManagerTLS {
+	if rp.Spec.TLS == nil {
+		return nil
+	}
+	return rp.Spec.TLS.CertManager
+}
+
+func certManagerIssuerName(rp *v1alpha1.Redpanda, cm *v1alpha1.CertManagerTLS) string {
+	return fmt.Sprintf("%s-%s-selfsigned-issuer", rp.Name, cm.GetCertName())
+}
+
+// certManagerSecretName is the name of both the Certificate and the Secret
+// it issues.
+func certManagerSecretName(rp *v1alpha1.Redpanda, cm *v1alpha1.CertManagerTLS) string {
+	return fmt.Sprintf("%s-%s-operator-cert", rp.Name, cm.GetCertName())
+}
+
+// reconcileCertManager creates or updates the cert-manager Issuer and
+// Certificate requested in Spec.TLS.CertManager.
+func (r *RedpandaReconciler) reconcileCertManager(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	cm := certManagerTLS(rp)
+	if cm == nil {
+		return nil
+	}
+
+	issuerRef := cmmetav1.ObjectReference{
+		Name: certManagerIssuerName(rp, cm),
+		Kind: cmapiv1.IssuerKind,
+	}
+	if cm.IssuerRef != nil {
+		issuerRef = cmmetav1.ObjectReference{
+			Name: cm.IssuerRef.Name,
+			Kind: cm.IssuerRef.Kind,
+		}
+	} else {
+		issuer := &cmapiv1.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      issuerRef.Name,
+				Namespace: rp.Namespace,
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, issuer, func() error {
+			issuer.OwnerReferences = []metav1.OwnerReference{rp.OwnerShipRefObj()}
+			issuer.Spec.IssuerConfig = cmapiv1.IssuerConfig{SelfSigned: &cmapiv1.SelfSignedIssuer{}}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("reconciling Issuer '%s/%s': %w", issuer.Namespace, issuer.Name, err)
+		}
+	}
+
+	cert := &cmapiv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certManagerSecretName(rp, cm),
+			Namespace: rp.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cert, func() error {
+		cert.OwnerReferences = []metav1.OwnerReference{rp.OwnerShipRefObj()}
+		cert.Spec.SecretName = cert.Name
+		cert.Spec.IssuerRef = issuerRef
+		cert.Spec.DNSNames = cm.DNSNames
+		cert.Spec.Duration = cm.Duration
+		return nil
+	}); err != nil {
+		return fmt.Errorf("reconciling Certificate '%s/%s': %w", cert.Namespace, cert.Name, err)
+	}
+
+	return nil
+}
+
+// requiredCertificateSecrets returns the names of the Secrets issued by
+// cert-manager Certificates the HelmRelease of the given Redpanda depends on:
+// the Certificate of Spec.TLS.CertManager and the Secrets referenced by the
+// certificates of the chart TLS values.
+func requiredCertificateSecrets(rp *v1alpha1.Redpanda) map[string]bool {
+	secrets := map[string]bool{}
+	if cm := certManagerTLS(rp); cm != nil {
+		secrets[certManagerSecretName(rp, cm)] = true
+	}
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.TLS != nil && ptr.Deref(rp.Spec.ClusterSpec.TLS.Enabled, true) {
+		for _, cert := range rp.Spec.ClusterSpec.TLS.Certs {
+			if cert != nil && cert.SecretRef != nil && cert.SecretRef.Name != "" {
+				secrets[cert.SecretRef.Name] = true
+			}
+		}
+	}
+	return secrets
+}
+
+// notReadyCertificates returns the sorted names of the cert-manager
+// Certificates issuing a Secret the HelmRelease of the given Redpanda depends
+// on that are not Ready. Secrets not issued by a Certificate are not waited
+// for, see ChartRef.WaitForSecrets.
+func (r *RedpandaReconciler) notReadyCertificates(ctx context.Context, rp *v1alpha1.Redpanda) ([]string, error) {
+	secrets := requiredCertificateSecrets(rp)
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	var list cmapiv1.CertificateList
+	if err := r.Client.List(ctx, &list, client.InNamespace(rp.Namespace)); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			// cert-manager is not installed, there is nothing to wait for
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list certificates: %w", err)
+	}
+
+	var notReady []string
+	issued := map[string]bool{}
+	for i := range list.Items {
+		cert := &list.Items[i]
+		if !secrets[cert.Spec.SecretName] {
+			continue
+		}
+		issued[cert.Spec.SecretName] = true
+		if !certificateReady(cert) {
+			notReady = append(notReady, cert.Name)
+		}
+	}
+	if cm := certManagerTLS(rp); cm != nil && !issued[certManagerSecretName(rp, cm)] {
+		// just created, the cache has not caught up yet
+		notReady = append(notReady, certManagerSecretName(rp, cm))
+	}
+	sort.Strings(notReady)
+	return notReady, nil
+}
+
+// certificateReady reports whether the Ready condition of the given
+// Certificate is true for its current generation.
+func certificateReady(cert *cmapiv1.Certificate) bool {
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type == cmapiv1.CertificateConditionReady {
+			return cond.Status == cmmetav1.ConditionTrue && cond.ObservedGeneration >= cert.Generation
+		}
+	}
+	return false
+}
+
+// deleteCertManager removes the Certificate and Issuer created for the given
+// Redpanda, if any.
+func (r *RedpandaReconciler) deleteCertManager(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	cm := certManagerTLS(rp)
+	if cm == nil {
+		return nil
+	}
+
+	objs := []client.Object{
+		&cmapiv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: certManagerSecretName(rp, cm), Namespace: rp.Namespace}},
+		&cmapiv1.Issuer{ObjectMeta: metav1.ObjectMeta{Name: certManagerIssuerName(rp, cm), Namespace: rp.Namespace}},
+	}
+	for _, obj := range objs {
+		if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting %T '%s/%s': %w", obj, obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// certManagerValues returns the chart values pointing the configured chart
+// certificate at the Secret issued by cert-manager.
+func certManagerValues(rp *v1alpha1.Redpanda) map[string]interface{} {
+	cm := certManagerTLS(rp)
+	if cm == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"tls": map[string]interface{}{
+			"certs": map[string]interface{}{
+				cm.GetCertName(): map[string]interface{}{
+					"secretRef": map[string]interface{}{
+						"name": certManagerSecretName(rp, cm),
+					},
+				},
+			},
+		},
+	}
+}
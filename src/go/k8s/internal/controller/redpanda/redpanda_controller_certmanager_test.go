@@ -0,0 +1,98 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	cmapiv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestNotReadyCertificates(t *testing.T) {
+	certificate := func(name, secretName string, ready bool) *cmapiv1.Certificate {
+		status := cmmetav1.ConditionFalse
+		if ready {
+			status = cmmetav1.ConditionTrue
+		}
+		return &cmapiv1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       cmapiv1.CertificateSpec{SecretName: secretName},
+			Status: cmapiv1.CertificateStatus{
+				Conditions: []cmapiv1.CertificateCondition{{Type: cmapiv1.CertificateConditionReady, Status: status}},
+			},
+		}
+	}
+	withCertManager := func(rp *v1alpha1.Redpanda) {
+		rp.Spec.TLS = &v1alpha1.RedpandaTLS{CertManager: &v1alpha1.CertManagerTLS{}}
+	}
+	withChartCert := func(rp *v1alpha1.Redpanda) {
+		rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{TLS: &v1alpha1.TLS{
+			Certs: map[string]*v1alpha1.Certificate{"default": {SecretRef: &v1alpha1.SecretRef{Name: "redpanda-tls"}}},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		prepare  func(rp *v1alpha1.Redpanda)
+		certs    []client.Object
+		expected []string
+	}{
+		{name: "no certificates", certs: []client.Object{certificate("other", "other", false)}},
+		{name: "operator certificate not created yet", prepare: withCertManager, expected: []string{"redpanda-external-operator-cert"}},
+		{
+			name:     "operator certificate not ready",
+			prepare:  withCertManager,
+			certs:    []client.Object{certificate("redpanda-external-operator-cert", "redpanda-external-operator-cert", false)},
+			expected: []string{"redpanda-external-operator-cert"},
+		},
+		{
+			name:    "operator certificate ready",
+			prepare: withCertManager,
+			certs:   []client.Object{certificate("redpanda-external-operator-cert", "redpanda-external-operator-cert", true)},
+		},
+		{
+			name:     "chart certificate not ready",
+			prepare:  withChartCert,
+			certs:    []client.Object{certificate("tls", "redpanda-tls", false), certificate("other", "other", false)},
+			expected: []string{"tls"},
+		},
+		{name: "chart secret not issued by cert-manager", prepare: withChartCert},
+		{
+			name: "chart TLS disabled",
+			prepare: func(rp *v1alpha1.Redpanda) {
+				withChartCert(rp)
+				rp.Spec.ClusterSpec.TLS.Enabled = ptr.To(false)
+			},
+			certs: []client.Object{certificate("tls", "redpanda-tls", false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			if tt.prepare != nil {
+				tt.prepare(rp)
+			}
+			r, _ := newTestRedpandaReconciler(t, tt.certs...)
+
+			notReady, err := r.notReadyCertificates(context.Background(), rp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, notReady)
+		})
+	}
+}
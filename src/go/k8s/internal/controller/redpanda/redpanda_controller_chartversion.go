@@ -0,0 +1,75 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// DefaultSupportedChartVersions is the range of chart versions the operator
+// is tested against.
+const DefaultSupportedChartVersions = ">=5.0.0 <6.0.0"
+
+// isUnsupportedChartAllowed reports whether the Redpanda accepts chart
+// versions outside of the supported range.
+func isUnsupportedChartAllowed(rp *v1alpha1.Redpanda) bool {
+	return rp.Annotations[v1alpha1.GroupVersion.Group+allowUnsupportedChartPath] == "true"
+}
+
+// checkChartVersion verifies that the chart version of the Redpanda is in
+// the SupportedChartVersions range and maintains the UnsupportedChartVersion
+// condition. It returns false when the reconcile must stop. Empty versions
+// and version ranges cannot be checked and are accepted.
+func (r *RedpandaReconciler) checkChartVersion(rp *v1alpha1.Redpanda) bool {
+	version := rp.Spec.ChartRef.ChartVersion
+	if r.SupportedChartVersions == nil || version == "" {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), UnsupportedChartVersionCondition)
+		return true
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil || r.SupportedChartVersions.Check(v) {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), UnsupportedChartVersionCondition)
+		return true
+	}
+
+	if isUnsupportedChartAllowed(rp) {
+		msg := fmt.Sprintf("chart version %s is outside of the supported range %s, accepted by the %s annotation", version, r.SupportedChartVersions, v1alpha1.GroupVersion.Group+allowUnsupportedChartPath)
+		if !apimeta.IsStatusConditionTrue(rp.Status.Conditions, UnsupportedChartVersionCondition) {
+			r.event(rp, v1alpha1.AcceptedByAnnotationReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		}
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               UnsupportedChartVersionCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.AcceptedByAnnotationReason,
+			Message:            msg,
+		})
+		return true
+	}
+
+	msg := fmt.Sprintf("chart version %s is outside of the supported range %s; set the %s annotation to \"true\" to accept the risk", version, r.SupportedChartVersions, v1alpha1.GroupVersion.Group+allowUnsupportedChartPath)
+	r.event(rp, v1alpha1.UnsupportedChartVersionReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               UnsupportedChartVersionCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.UnsupportedChartVersionReason,
+		Message:            msg,
+	})
+	v1alpha1.RedpandaNotReady(rp, v1alpha1.UnsupportedChartVersionReason, msg)
+	return false
+}
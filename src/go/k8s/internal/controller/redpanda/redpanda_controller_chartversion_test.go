@@ -0,0 +1,69 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestCheckChartVersion(t *testing.T) {
+	supported, err := semver.NewConstraint(DefaultSupportedChartVersions)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		version       string
+		allow         bool
+		constraints   *semver.Constraints
+		wantContinue  bool
+		wantCondition bool
+		wantReason    string
+	}{
+		{name: "no range configured", version: "7.0.0", wantContinue: true},
+		{name: "latest", constraints: supported, wantContinue: true},
+		{name: "version range", version: ">=5.0.0", constraints: supported, wantContinue: true},
+		{name: "supported", version: "5.7.1", constraints: supported, wantContinue: true},
+		{name: "too new", version: "6.0.1", constraints: supported, wantCondition: true, wantReason: v1alpha1.UnsupportedChartVersionReason},
+		{name: "too old", version: "4.0.54", constraints: supported, wantCondition: true, wantReason: v1alpha1.UnsupportedChartVersionReason},
+		{name: "accepted by annotation", version: "6.0.1", allow: true, constraints: supported, wantContinue: true, wantCondition: true, wantReason: v1alpha1.AcceptedByAnnotationReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ChartRef.ChartVersion = tt.version
+			if tt.allow {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + allowUnsupportedChartPath: "true"}
+			}
+			r, recorder := newTestRedpandaReconciler(t, rp)
+			r.SupportedChartVersions = tt.constraints
+
+			assert.Equal(t, tt.wantContinue, r.checkChartVersion(rp))
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, UnsupportedChartVersionCondition)
+			if !tt.wantCondition {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+			assert.Contains(t, cond.Message, DefaultSupportedChartVersions)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
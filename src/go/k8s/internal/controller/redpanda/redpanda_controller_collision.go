@@ -0,0 +1,111 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// releaseNamesCollide reports whether two Redpandas of the same namespace
+// deploy the same Helm release or chart resources of the same name.
+func releaseNamesCollide(a, b *v1alpha1.Redpanda) bool {
+	return a.GetReleaseName() == b.GetReleaseName() || internalServiceName(a) == internalServiceName(b)
+}
+
+// releaseNameCollisions returns the sorted names of the other Redpandas of
+// the namespace of the given Redpanda whose release collides with its own.
+func (r *RedpandaReconciler) releaseNameCollisions(ctx context.Context, rp *v1alpha1.Redpanda) ([]string, error) {
+	var list v1alpha1.RedpandaList
+	if err := r.Client.List(ctx, &list, client.InNamespace(rp.Namespace)); err != nil {
+		return nil, fmt.Errorf("list redpandas: %w", err)
+	}
+
+	var names []string
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name == rp.Name || !releaseNamesCollide(rp, other) {
+			continue
+		}
+		names = append(names, other.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// checkReleaseNameCollision maintains the ReleaseNameCollision condition of
+// the given Redpanda and returns false when the reconcile must stop because
+// another Redpanda of the namespace deploys the same release. Both Redpandas
+// then stop writing, rather than overwriting each other, until the collision
+// is resolved.
+func (r *RedpandaReconciler) checkReleaseNameCollision(ctx context.Context, rp *v1alpha1.Redpanda) (bool, error) {
+	names, err := r.releaseNameCollisions(ctx, rp)
+	if err != nil {
+		return false, err
+	}
+	if len(names) == 0 {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ReleaseNameCollisionCondition)
+		return true, nil
+	}
+
+	msg := fmt.Sprintf("release %s or its resources named %s collide with Redpandas %s, set a distinct chartRef.releaseName or clusterSpec.fullnameOverride", rp.GetReleaseName(), internalServiceName(rp), strings.Join(names, ", "))
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReleaseNameCollisionCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.ReleaseNameCollisionReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ReleaseNameCollisionCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ReleaseNameCollisionReason,
+		Message:            msg,
+	})
+	v1alpha1.RedpandaNotReady(rp, v1alpha1.ReleaseNameCollisionReason, msg)
+	return false, nil
+}
+
+// redpandasForReleaseNameCollision maps a Redpanda to the other Redpandas of
+// its namespace whose release collides with its own or that report a
+// collision, so that they set or clear their ReleaseNameCollision condition
+// when it is created, renamed or deleted.
+func (r *RedpandaReconciler) redpandasForReleaseNameCollision(ctx context.Context, obj client.Object) []reconcile.Request {
+	rp, ok := obj.(*v1alpha1.Redpanda)
+	if !ok {
+		return nil
+	}
+
+	var list v1alpha1.RedpandaList
+	if err := r.Client.List(ctx, &list, client.InNamespace(rp.Namespace)); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "could not list redpandas for release name collisions", "namespace", rp.Namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name == rp.Name {
+			continue
+		}
+		if releaseNamesCollide(rp, other) || apimeta.IsStatusConditionTrue(other.Status.Conditions, ReleaseNameCollisionCondition) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(other)})
+		}
+	}
+	return requests
+}
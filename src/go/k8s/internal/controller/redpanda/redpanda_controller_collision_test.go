@@ -0,0 +1,83 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestCheckReleaseNameCollision(t *testing.T) {
+	other := func(namespace, name, releaseName, fullnameOverride string) client.Object {
+		rp := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		rp.Spec.ChartRef.ReleaseName = releaseName
+		if fullnameOverride != "" {
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{FullNameOverride: fullnameOverride}
+		}
+		return rp
+	}
+
+	tests := []struct {
+		name          string
+		others        []client.Object
+		expectOK      bool
+		expectMessage string
+	}{
+		{name: "alone", expectOK: true},
+		{name: "distinct", others: []client.Object{other("default", "other", "", "")}, expectOK: true},
+		{name: "other namespace", others: []client.Object{other("other", "redpanda", "", "")}, expectOK: true},
+		{
+			name:          "same release name",
+			others:        []client.Object{other("default", "other", "redpanda", "")},
+			expectMessage: "release redpanda or its resources named redpanda collide with Redpandas other, set a distinct chartRef.releaseName or clusterSpec.fullnameOverride",
+		},
+		{
+			name:          "same fullnameOverride",
+			others:        []client.Object{other("default", "b", "", "redpanda"), other("default", "a", "", "redpanda")},
+			expectMessage: "release redpanda or its resources named redpanda collide with Redpandas a, b, set a distinct chartRef.releaseName or clusterSpec.fullnameOverride",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			r, recorder := newTestRedpandaReconciler(t, append(tt.others, rp.DeepCopy())...)
+
+			ok, err := r.checkReleaseNameCollision(context.Background(), rp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectOK, ok)
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReleaseNameCollisionCondition)
+			if tt.expectOK {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.expectMessage, cond.Message)
+			assert.Equal(t, v1alpha1.ReleaseNameCollisionReason, apimeta.FindStatusCondition(rp.Status.Conditions, meta.ReadyCondition).Reason)
+			assert.Len(t, recorder.Events, 1)
+
+			// the event is only emitted when the collision changes
+			ok, err = r.checkReleaseNameCollision(context.Background(), rp)
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+	consolepkg "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/console"
+)
+
+// validateConsoleResources checks that ClusterSpec.Console.Resources is a
+// valid container resources block. Unknown keys and invalid quantities are
+// rejected, as are requests exceeding their limit.
+func validateConsoleResources(rp *v1alpha1.Redpanda) error {
+	if rp.Spec.ClusterSpec == nil || rp.Spec.ClusterSpec.Console == nil || rp.Spec.ClusterSpec.Console.Resources == nil {
+		return nil
+	}
+
+	var res corev1.ResourceRequirements
+	dec := json.NewDecoder(bytes.NewReader(rp.Spec.ClusterSpec.Console.Resources.Raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&res); err != nil {
+		return fmt.Errorf("could not parse console resources: %w", err)
+	}
+
+	for name, request := range res.Requests {
+		if limit, ok := res.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf("console %s request %s exceeds limit %s", name, request.String(), limit.String())
+		}
+	}
+	return nil
+}
+
+// recordMigratedConsoleResources records the resources of the Console
+// Deployment created by the vectorized Console controller in the
+// migratedConsoleResourcesPath annotation of the given Redpanda, before the
+// migration deletes the Deployment. Nothing is recorded when the annotation is
+// already set, the Deployment is gone or it does not set any resources.
+func (r *RedpandaReconciler) recordMigratedConsoleResources(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	if _, ok := rp.Annotations[v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath]; ok {
+		return nil
+	}
+
+	key := migrationRefKey(rp, rp.Spec.Migration.ConsoleRef)
+
+	var deploy appsv1.Deployment
+	if err := r.Client.Get(ctx, key, &deploy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get Console Deployment '%s': %w", key, err)
+	}
+
+	for i := range deploy.Spec.Template.Spec.Containers {
+		c := &deploy.Spec.Template.Spec.Containers[i]
+		if c.Name != consolepkg.ConsoleContainerName {
+			continue
+		}
+		if len(c.Resources.Requests) == 0 && len(c.Resources.Limits) == 0 {
+			return nil
+		}
+
+		raw, err := json.Marshal(c.Resources)
+		if err != nil {
+			return fmt.Errorf("could not marshal Console resources: %w", err)
+		}
+
+		// patch a copy, the status computed so far must not be replaced with
+		// the stored one
+		obj := rp.DeepCopy()
+		patch := client.MergeFrom(obj.DeepCopy())
+		if obj.Annotations == nil {
+			obj.Annotations = map[string]string{}
+		}
+		obj.Annotations[v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath] = string(raw)
+		if err = r.Client.Patch(ctx, obj, patch); err != nil {
+			return fmt.Errorf("recording Console resources: %w", err)
+		}
+		rp.Annotations = obj.Annotations
+		return nil
+	}
+	return nil
+}
+
+// migratedConsoleValues returns the Console resources recorded by
+// recordMigratedConsoleResources as chart values, so that a migration does
+// not silently drop them. Nothing is returned when none were recorded or the
+// Redpanda sets console resources itself.
+func migratedConsoleValues(rp *v1alpha1.Redpanda) (map[string]interface{}, error) {
+	raw, ok := rp.Annotations[v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath]
+	if !ok {
+		return nil, nil
+	}
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.Console != nil && rp.Spec.ClusterSpec.Console.Resources != nil {
+		return nil, nil
+	}
+
+	resources := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(raw), &resources); err != nil {
+		return nil, fmt.Errorf("could not parse the %s annotation: %w", v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath, err)
+	}
+	return map[string]interface{}{
+		"console": map[string]interface{}{"resources": resources},
+	}, nil
+}
+
+const (
+	// migratedConsoleResourcesPath is the annotation path holding the
+	// resources of the vectorized Console Deployment as JSON, recorded before
+	// the migration deletes it.
+	migratedConsoleResourcesPath = "/migrated-console-resources"
+
+	// consoleTLSSecretLabelPath is the path of the label marking the copies
+	// of Spec.ConsoleTLSSecrets.
+	consoleTLSSecretLabelPath = "/console-tls-secret"
+	// consoleTLSSecretSourcePath is the path of the annotation recording the
+	// source of a copy of Spec.ConsoleTLSSecrets as "<namespace>/<name>".
+	consoleTLSSecretSourcePath = "/console-tls-secret-source"
+
+	// consoleTLSSecretsIndex indexes Redpandas by the "<namespace>/<name>" of
+	// the Secrets referenced in Spec.ConsoleTLSSecrets.
+	consoleTLSSecretsIndex = "spec.consoleTLSSecrets[]"
+)
+
+func consoleTLSSecretKeys(obj client.Object) []string {
+	rp, ok := obj.(*v1alpha1.Redpanda)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(rp.Spec.ConsoleTLSSecrets))
+	for _, ref := range rp.Spec.ConsoleTLSSecrets {
+		keys = append(keys, ref.Namespace+"/"+ref.Name)
+	}
+	return keys
+}
+
+// redpandasForConsoleTLSSecret maps a Secret to the Redpandas that copy it
+// for Console so that changes to the source are propagated to the copies.
+func (r *RedpandaReconciler) redpandasForConsoleTLSSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list v1alpha1.RedpandaList
+	if err := r.Client.List(ctx, &list, client.MatchingFields{consoleTLSSecretsIndex: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "could not list redpandas copying console TLS secret", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+	}
+	return requests
+}
+
+// reconcileConsoleTLSSecrets copies the Secrets of Spec.ConsoleTLSSecrets into
+// the namespace of the given Redpanda, where Console is deployed. Copies are
+// owned by the Redpanda, updated when their source changes and deleted once
+// they are no longer listed. Secrets of the same name not created by this
+// function are never overwritten.
+func (r *RedpandaReconciler) reconcileConsoleTLSSecrets(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	if len(rp.Spec.ConsoleTLSSecrets) > 0 && !vectorizedv1alpha1.AllowConsoleAnyNamespace {
+		return errors.New("copying consoleTLSSecrets requires the operator to run with --allow-console-any-ns")
+	}
+
+	wanted := make(map[string]bool, len(rp.Spec.ConsoleTLSSecrets))
+	for _, ref := range rp.Spec.ConsoleTLSSecrets {
+		if ref.Name == "" || ref.Namespace == "" {
+			return fmt.Errorf("consoleTLSSecrets entry %q/%q requires a name and a namespace", ref.Namespace, ref.Name)
+		}
+		if ref.Namespace == rp.Namespace {
+			// Console can mount the Secret as is
+			continue
+		}
+		if err := r.copyConsoleTLSSecret(ctx, rp, ref); err != nil {
+			return err
+		}
+		wanted[ref.Name] = true
+	}
+
+	var copies corev1.SecretList
+	if err := r.Client.List(ctx, &copies, client.InNamespace(rp.Namespace), client.MatchingLabels{v1alpha1.GroupVersion.Group + consoleTLSSecretLabelPath: "true"}); err != nil {
+		return fmt.Errorf("list console TLS secret copies: %w", err)
+	}
+	for i := range copies.Items {
+		cp := &copies.Items[i]
+		if wanted[cp.Name] || !isOwnedBy(cp.OwnerReferences, rp.UID) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, cp); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete console TLS secret copy '%s/%s': %w", cp.Namespace, cp.Name, err)
+		}
+		r.event(rp, v1alpha1.ConsoleTLSSecretsSyncedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("deleted console TLS secret copy '%s/%s'", cp.Namespace, cp.Name))
+	}
+	return nil
+}
+
+// copyConsoleTLSSecret creates or updates the copy of the given Secret in the
+// namespace of the given Redpanda.
+func (r *RedpandaReconciler) copyConsoleTLSSecret(ctx context.Context, rp *v1alpha1.Redpanda, ref corev1.SecretReference) error {
+	var src corev1.Secret
+	srcKey := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if err := r.Client.Get(ctx, srcKey, &src); err != nil {
+		return fmt.Errorf("get console TLS secret '%s': %w", srcKey, err)
+	}
+
+	dstKey := types.NamespacedName{Namespace: rp.Namespace, Name: ref.Name}
+	var existing corev1.Secret
+	if err := r.Client.Get(ctx, dstKey, &existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get console TLS secret copy '%s': %w", dstKey, err)
+		}
+		cp := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            dstKey.Name,
+				Namespace:       dstKey.Namespace,
+				Labels:          map[string]string{v1alpha1.GroupVersion.Group + consoleTLSSecretLabelPath: "true"},
+				Annotations:     map[string]string{v1alpha1.GroupVersion.Group + consoleTLSSecretSourcePath: srcKey.String()},
+				OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
+			},
+			Type: src.Type,
+			Data: src.Data,
+		}
+		if err = r.Client.Create(ctx, cp); err != nil {
+			return fmt.Errorf("create console TLS secret copy '%s': %w", dstKey, err)
+		}
+		r.event(rp, v1alpha1.ConsoleTLSSecretsSyncedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("copied console TLS secret '%s' to '%s'", srcKey, dstKey))
+		return nil
+	}
+
+	if !isOwnedBy(existing.OwnerReferences, rp.UID) || existing.Labels[v1alpha1.GroupVersion.Group+consoleTLSSecretLabelPath] != "true" {
+		return fmt.Errorf("secret '%s' already exists and is not a console TLS secret copy of the Redpanda", dstKey)
+	}
+	if existing.Type == src.Type && equality.Semantic.DeepEqual(existing.Data, src.Data) &&
+		existing.Annotations[v1alpha1.GroupVersion.Group+consoleTLSSecretSourcePath] == srcKey.String() {
+		return nil
+	}
+
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[v1alpha1.GroupVersion.Group+consoleTLSSecretSourcePath] = srcKey.String()
+	existing.Type = src.Type
+	existing.Data = src.Data
+	if err := r.Client.Update(ctx, &existing); err != nil {
+		return fmt.Errorf("update console TLS secret copy '%s': %w", dstKey, err)
+	}
+	r.event(rp, v1alpha1.ConsoleTLSSecretsSyncedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("console TLS secret '%s' changed, updated its copy '%s'", srcKey, dstKey))
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+	consolepkg "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/console"
+)
+
+func withConsoleResources(rp *v1alpha1.Redpanda, resources string) *v1alpha1.Redpanda {
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{
+		Console: &v1alpha1.RedpandaConsole{Resources: &runtime.RawExtension{Raw: []byte(resources)}},
+	}
+	return rp
+}
+
+func TestValidateConsoleResources(t *testing.T) {
+	tests := []struct {
+		name        string
+		resources   string
+		expectError string
+	}{
+		{name: "unset"},
+		{name: "requests and limits", resources: `{"requests":{"cpu":"100m","memory":"256Mi"},"limits":{"cpu":"1","memory":"512Mi"}}`},
+		{name: "unknown key", resources: `{"request":{"cpu":"100m"}}`, expectError: "unknown field"},
+		{name: "invalid quantity", resources: `{"limits":{"memory":"lots"}}`, expectError: "could not parse console resources"},
+		{name: "request exceeds limit", resources: `{"requests":{"memory":"1Gi"},"limits":{"memory":"512Mi"}}`, expectError: "memory request 1Gi exceeds limit 512Mi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			if tt.resources != "" {
+				rp = withConsoleResources(rp, tt.resources)
+			}
+
+			err := validateConsoleResources(rp)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func testConsoleDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: consolepkg.ConsoleContainerName,
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestMigratedConsoleValues(t *testing.T) {
+	recorded := `{"limits":{"memory":"1Gi"}}`
+	expected := map[string]interface{}{
+		"console": map[string]interface{}{
+			"resources": map[string]interface{}{"limits": map[string]interface{}{"memory": "1Gi"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		rp          *v1alpha1.Redpanda
+		annotation  *string
+		expected    map[string]interface{}
+		expectError bool
+	}{
+		{name: "nothing recorded", rp: testMigratingRedpanda()},
+		{name: "resources preserved", rp: testMigratingRedpanda(), annotation: &recorded, expected: expected},
+		{name: "preserved once migration is disabled", rp: testRedpanda(), annotation: &recorded, expected: expected},
+		{name: "spec resources win", rp: withConsoleResources(testMigratingRedpanda(), `{"limits":{"memory":"2Gi"}}`), annotation: &recorded},
+		{name: "invalid annotation", rp: testMigratingRedpanda(), annotation: ptr.To("{"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.annotation != nil {
+				tt.rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + migratedConsoleResourcesPath: *tt.annotation}
+			}
+
+			values, err := migratedConsoleValues(tt.rp)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestMigratedConsoleResourcesSurviveMigration(t *testing.T) {
+	rp := testMigratingRedpanda()
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Console: &v1alpha1.RedpandaConsole{}}
+	deploy := testConsoleDeployment()
+	r, _ := newTestRedpandaReconciler(t, rp.DeepCopy(), deploy)
+
+	_ = r.tryMigration(context.Background(), ctrl.Log, rp)
+	err := r.Client.Get(context.Background(), client.ObjectKeyFromObject(deploy), &appsv1.Deployment{})
+	require.True(t, apierrors.IsNotFound(err), "the migration deletes the Console Deployment")
+
+	var stored v1alpha1.Redpanda
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), &stored))
+	assert.Equal(t, rp.Annotations[v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath], stored.Annotations[v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath])
+
+	// every later render, including the ones once migration is disabled,
+	// keeps the resources of the deleted Deployment
+	for _, enabled := range []bool{true, false} {
+		stored.Spec.Migration.Enabled = enabled
+		values, err := r.buildValues(context.Background(), &stored)
+		require.NoError(t, err)
+
+		rendered := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(values.Raw, &rendered))
+		assert.Equal(t, map[string]interface{}{"limits": map[string]interface{}{"memory": "1Gi"}}, rendered["console"].(map[string]interface{})["resources"])
+	}
+}
+
+func TestMigrationKeepsAdoptedConsoleDeployment(t *testing.T) {
+	rp := testMigratingRedpanda()
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Console: &v1alpha1.RedpandaConsole{}}
+	deploy := testConsoleDeployment()
+	setHelmLabelsAndAnnotations(deploy, rp)
+	r, _ := newTestRedpandaReconciler(t, rp.DeepCopy(), deploy)
+
+	// the Deployment carries the Helm metadata, it is already the chart's
+	_ = r.tryMigration(context.Background(), ctrl.Log, rp)
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(deploy), &appsv1.Deployment{}))
+
+	var stored v1alpha1.Redpanda
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), &stored))
+	assert.NotContains(t, stored.Annotations, v1alpha1.GroupVersion.Group+migratedConsoleResourcesPath)
+}
+
+func TestConsoleResourcesTriggerHelmReleaseUpdate(t *testing.T) {
+	rp := withConsoleResources(testRedpanda(), `{"limits":{"memory":"512Mi"}}`)
+	r, _ := newTestRedpandaReconciler(t)
+
+	hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+
+	hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.False(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+
+	rp = withConsoleResources(rp, `{"limits":{"memory":"1Gi"}}`)
+	hrTemplate, err = r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.True(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+
+	values := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(hrTemplate.Spec.Values.Raw, &values))
+	assert.Equal(t, map[string]interface{}{"limits": map[string]interface{}{"memory": "1Gi"}}, values["console"].(map[string]interface{})["resources"])
+}
+
+func TestReconcileConsoleTLSSecrets(t *testing.T) {
+	allow := vectorizedv1alpha1.AllowConsoleAnyNamespace
+	t.Cleanup(func() { vectorizedv1alpha1.AllowConsoleAnyNamespace = allow })
+
+	rp := testRedpanda()
+	rp.UID = "rp-uid"
+	owner := rp.OwnerShipRefObj()
+	label := map[string]string{v1alpha1.GroupVersion.Group + consoleTLSSecretLabelPath: "true"}
+
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "console-tls", Namespace: "cert-manager"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": []byte("new")},
+	}
+	staleCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "console-tls", Namespace: "default", Labels: label, OwnerReferences: []metav1.OwnerReference{owner}},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{"tls.crt": []byte("old")},
+	}
+	unlistedCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "old-tls", Namespace: "default", Labels: label, OwnerReferences: []metav1.OwnerReference{owner}},
+	}
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "console-tls", Namespace: "default"}}
+	ref := []corev1.SecretReference{{Namespace: "cert-manager", Name: "console-tls"}}
+
+	tests := []struct {
+		name     string
+		disallow bool
+		refs     []corev1.SecretReference
+		objs     []client.Object
+		err      string
+		copied   bool
+		deleted  []string
+	}{
+		{name: "not set"},
+		{name: "not allowed", disallow: true, refs: ref, objs: []client.Object{source}, err: "--allow-console-any-ns"},
+		{name: "missing namespace", refs: []corev1.SecretReference{{Name: "console-tls"}}, err: "requires a name and a namespace"},
+		{name: "missing source", refs: ref, err: "get console TLS secret 'cert-manager/console-tls'"},
+		{name: "copy", refs: ref, objs: []client.Object{source}, copied: true},
+		{name: "source changed", refs: ref, objs: []client.Object{source, staleCopy}, copied: true},
+		{name: "not owned", refs: ref, objs: []client.Object{source, foreign}, err: "is not a console TLS secret copy"},
+		{name: "unlisted copy", refs: ref, objs: []client.Object{source, unlistedCopy}, copied: true, deleted: []string{"old-tls"}},
+		{name: "same namespace", refs: []corev1.SecretReference{{Namespace: "default", Name: "console-tls"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vectorizedv1alpha1.AllowConsoleAnyNamespace = !tt.disallow
+			r, recorder := newTestRedpandaReconciler(t, tt.objs...)
+			rp := rp.DeepCopy()
+			rp.Spec.ConsoleTLSSecrets = tt.refs
+
+			err := r.reconcileConsoleTLSSecrets(context.Background(), rp)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			var cp corev1.Secret
+			err = r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "console-tls"}, &cp)
+			if !tt.copied {
+				assert.True(t, apierrors.IsNotFound(err))
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, source.Type, cp.Type)
+			assert.Equal(t, source.Data, cp.Data)
+			assert.True(t, isOwnedBy(cp.OwnerReferences, rp.UID))
+			assert.Equal(t, "cert-manager/console-tls", cp.Annotations[v1alpha1.GroupVersion.Group+consoleTLSSecretSourcePath])
+			assert.Len(t, recorder.Events, 1+len(tt.deleted))
+
+			for _, name := range tt.deleted {
+				err = r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, &corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err))
+			}
+
+			// an up to date copy is left alone
+			require.NoError(t, r.reconcileConsoleTLSSecrets(context.Background(), rp))
+			assert.Len(t, recorder.Events, 1+len(tt.deleted))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func (r *RedpandaReconciler) deletionBlockedTimeout() time.Duration {
+	if r.DeletionBlockedTimeout > 0 {
+		return r.DeletionBlockedTimeout
+	}
+	return defaultDeletionBlockedTimeout
+}
+
+// preDeleteLists returns the list types of the kinds of
+// ChartRef.PreDeleteKinds.
+var preDeleteLists = map[v1alpha1.PreDeleteKind]func() client.ObjectList{
+	"Service":             func() client.ObjectList { return &corev1.ServiceList{} },
+	"Ingress":             func() client.ObjectList { return &networkingv1.IngressList{} },
+	"StatefulSet":         func() client.ObjectList { return &appsv1.StatefulSetList{} },
+	"Deployment":          func() client.ObjectList { return &appsv1.DeploymentList{} },
+	"PodDisruptionBudget": func() client.ObjectList { return &policyv1.PodDisruptionBudgetList{} },
+}
+
+// releaseListOptions selects the resources of the release of the given
+// HelmRelease.
+func releaseListOptions(hr *helmv2beta1.HelmRelease) []client.ListOption {
+	return []client.ListOption{
+		client.InNamespace(hr.GetReleaseNamespace()),
+		client.MatchingLabels{"app.kubernetes.io/instance": hr.GetReleaseName()},
+	}
+}
+
+// preDeleteResources deletes the resources of the release of the kinds of
+// ChartRef.PreDeleteKinds, one kind at a time in order, and reports whether
+// all of them are gone so that the HelmRelease can be deleted. Helm deletes
+// the resources of a release in an order of its own, which for instance
+// removes the external Service only after the StatefulSet. In safe mode the
+// deletions are dry runs and the HelmRelease waits.
+func (r *RedpandaReconciler) preDeleteResources(ctx context.Context, rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) (bool, error) {
+	if hr.DeletionTimestamp != nil {
+		// already handed over to Helm
+		return true, nil
+	}
+
+	background := metav1.DeletePropagationBackground
+	for _, kind := range rp.Spec.ChartRef.PreDeleteKinds {
+		newList, ok := preDeleteLists[kind]
+		if !ok {
+			continue
+		}
+		list := newList()
+		if err := r.Client.List(ctx, list, releaseListOptions(hr)...); err != nil {
+			return false, fmt.Errorf("listing %s: %w", kind, err)
+		}
+		if apimeta.LenList(list) == 0 {
+			continue
+		}
+		if err := apimeta.EachListItem(list, func(obj runtime.Object) error {
+			o, ok := obj.(client.Object)
+			if !ok || o.GetDeletionTimestamp() != nil {
+				return nil
+			}
+			action := fmt.Sprintf("delete %s '%s/%s' ahead of HelmRelease '%s/%s'", kind, o.GetNamespace(), o.GetName(), hr.Namespace, hr.Name)
+			if !destructiveActionAllowed(ctrl.LoggerFrom(ctx), r.SafeMode, rp, action) {
+				r.event(rp, v1alpha1.DestructiveActionBlockedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, safeModeMessage(action))
+				return nil
+			}
+			if err := r.Client.Delete(ctx, o, &client.DeleteOptions{PropagationPolicy: &background}); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("deleting %s/%s: %w", kind, o.GetName(), err)
+			}
+			r.event(rp, v1alpha1.DeletedAheadOfReleaseReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("%s '%s/%s' deleted ahead of HelmRelease '%s/%s'", kind, o.GetNamespace(), o.GetName(), hr.Namespace, hr.Name))
+			return nil
+		}); err != nil {
+			return false, err
+		}
+		// wait for the kind to be gone before moving on to the next one
+		return false, nil
+	}
+	return true, nil
+}
+
+// deletionBlockers returns the resources of the release that still exist,
+// e.g. "PersistentVolumeClaim/datadir-redpanda-0 (finalizers: kubernetes.io/pvc-protection)".
+func (r *RedpandaReconciler) deletionBlockers(ctx context.Context, hr *helmv2beta1.HelmRelease) ([]string, error) {
+	opts := releaseListOptions(hr)
+
+	lists := []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{kind: "StatefulSet", list: &appsv1.StatefulSetList{}},
+		{kind: "Pod", list: &corev1.PodList{}},
+		{kind: "PersistentVolumeClaim", list: &corev1.PersistentVolumeClaimList{}},
+		{kind: "Service", list: &corev1.ServiceList{}},
+	}
+
+	var blockers []string
+	for _, l := range lists {
+		if err := r.Client.List(ctx, l.list, opts...); err != nil {
+			return nil, fmt.Errorf("listing %s: %w", l.kind, err)
+		}
+		if err := apimeta.EachListItem(l.list, func(obj runtime.Object) error {
+			o, err := apimeta.Accessor(obj)
+			if err != nil {
+				return err
+			}
+			blocker := fmt.Sprintf("%s/%s", l.kind, o.GetName())
+			if len(o.GetFinalizers()) > 0 {
+				blocker = fmt.Sprintf("%s (finalizers: %s)", blocker, strings.Join(o.GetFinalizers(), ","))
+			}
+			blockers = append(blockers, blocker)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(blockers)
+	return blockers, nil
+}
+
+// reportDeletionBlocked sets the DeletionBlocked condition and emits an event
+// listing the resources of the release that still exist once the HelmRelease
+// has been terminating for longer than the deletion blocked timeout. The
+// foreground deletion otherwise only surfaces as a repeated retry.
+func (r *RedpandaReconciler) reportDeletionBlocked(ctx context.Context, rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) error {
+	if hr.DeletionTimestamp == nil || time.Since(hr.DeletionTimestamp.Time) < r.deletionBlockedTimeout() {
+		return nil
+	}
+
+	blockers, err := r.deletionBlockers(ctx, hr)
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("HelmRelease '%s/%s' is terminating since %s", hr.Namespace, hr.Name, hr.DeletionTimestamp.UTC().Format(time.RFC3339))
+	if len(blockers) > 0 {
+		msg = fmt.Sprintf("%s, remaining resources: %s", msg, strings.Join(blockers, ", "))
+	}
+
+	if cond := apimeta.FindStatusCondition(rp.Status.Conditions, DeletionBlockedCondition); cond == nil || cond.Message != msg {
+		r.reasonEvent(rp, DeletionBlockedCondition, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               DeletionBlockedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ResourcesRemainingReason,
+		Message:            msg,
+	})
+
+	if err := r.patchRedpandaStatus(ctx, rp); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to update status with blocked deletion")
+	}
+	return nil
+}
+
+// finalizerTimeout returns the time the deletion of the given Redpanda may
+// take before its finalizer is force-removed. The finalizer-timeout
+// annotation overrides FinalizerTimeout, zero disables it.
+func (r *RedpandaReconciler) finalizerTimeout(rp *v1alpha1.Redpanda) (time.Duration, error) {
+	key := v1alpha1.GroupVersion.Group + finalizerTimeoutPath
+	value, ok := rp.Annotations[key]
+	if !ok {
+		return r.FinalizerTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q: %w", key, value, err)
+	}
+	return timeout, nil
+}
+
+// forceFinalizeAfterTimeout removes the finalizer of the given Redpanda once
+// its deletion, which failed with deleteErr, has taken longer than the
+// finalizer timeout, so that a deletion that can never complete, e.g. a
+// HelmRelease stuck terminating, does not make the Redpanda undeletable. The
+// resources left behind are logged and reported with a Warning event and the
+// ForcedFinalization condition. Otherwise deleteErr is returned to retry.
+func (r *RedpandaReconciler) forceFinalizeAfterTimeout(ctx context.Context, rp *v1alpha1.Redpanda, deleteErr error) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.forceFinalizeAfterTimeout")
+
+	timeout, err := r.finalizerTimeout(rp)
+	if err != nil {
+		log.Error(err, "ignoring finalizer timeout")
+		return ctrl.Result{}, deleteErr
+	}
+	if timeout <= 0 || !controllerutil.ContainsFinalizer(rp, FinalizerKey) || time.Since(rp.DeletionTimestamp.Time) < timeout {
+		return ctrl.Result{}, deleteErr
+	}
+
+	orphans, err := r.orphanedResources(ctx, rp)
+	if err != nil {
+		return ctrl.Result{}, errors.Join(deleteErr, err)
+	}
+	msg := fmt.Sprintf("deletion did not complete within the finalizer timeout of %s, finalizer %s removed: %s", timeout, FinalizerKey, deleteErr)
+	if len(orphans) > 0 {
+		msg = fmt.Sprintf("%s, orphaned resources: %s", msg, strings.Join(orphans, ", "))
+	}
+	log.Error(deleteErr, "forcing finalization", "timeout", timeout, "orphans", orphans)
+	r.event(rp, v1alpha1.FinalizerTimeoutReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ForcedFinalizationCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.FinalizerTimeoutReason,
+		Message:            msg,
+	})
+	if err = r.patchRedpandaStatus(ctx, rp); err != nil {
+		log.Error(err, "unable to update status with forced finalization")
+	}
+
+	patch := client.MergeFrom(rp.DeepCopy())
+	controllerutil.RemoveFinalizer(rp, FinalizerKey)
+	if err = r.Client.Patch(ctx, rp, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("removing finalizer: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// orphanedResources returns the HelmRelease of the given Redpanda, if it
+// still exists, followed by the resources of its release.
+func (r *RedpandaReconciler) orphanedResources(ctx context.Context, rp *v1alpha1.Redpanda) ([]string, error) {
+	if rp.Status.HelmRelease == "" {
+		return nil, nil
+	}
+
+	var hr helmv2beta1.HelmRelease
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: rp.Status.GetHelmRelease()}, &hr); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	blockers, err := r.deletionBlockers(ctx, &hr)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{fmt.Sprintf("HelmRelease/%s", hr.Name)}, blockers...), nil
+}
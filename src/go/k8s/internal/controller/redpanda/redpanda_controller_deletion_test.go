@@ -0,0 +1,221 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestReportDeletionBlocked(t *testing.T) {
+	instance := map[string]string{"app.kubernetes.io/instance": "redpanda"}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "datadir-redpanda-0",
+			Namespace:  "default",
+			Labels:     instance,
+			Finalizers: []string{"kubernetes.io/pvc-protection"},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Labels: instance},
+	}
+	other := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{"app.kubernetes.io/instance": "other"}},
+	}
+
+	tests := []struct {
+		name        string
+		terminating time.Duration
+		expected    string
+	}{
+		{name: "not terminating"},
+		{name: "within timeout", terminating: time.Minute},
+		{
+			name:        "blocked",
+			terminating: time.Hour,
+			expected:    "remaining resources: PersistentVolumeClaim/datadir-redpanda-0 (finalizers: kubernetes.io/pvc-protection), Service/redpanda",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			r, recorder := newTestRedpandaReconciler(t, rp, pvc.DeepCopy(), svc.DeepCopy(), other.DeepCopy())
+
+			hr := &helmv2beta1.HelmRelease{
+				ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+			}
+			if tt.terminating > 0 {
+				hr.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-tt.terminating)}
+			}
+
+			require.NoError(t, r.reportDeletionBlocked(context.Background(), rp, hr))
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, DeletionBlockedCondition)
+			if tt.expected == "" {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Contains(t, cond.Message, tt.expected)
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, tt.expected)
+
+			// an unchanged message is not reported again
+			require.NoError(t, r.reportDeletionBlocked(context.Background(), rp, hr))
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestPreDeleteResources(t *testing.T) {
+	instance := map[string]string{"app.kubernetes.io/instance": "redpanda"}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-external", Namespace: "default", Labels: instance}}
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Labels: instance}}
+	other := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+	hr := &helmv2beta1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+
+	exists := func(r *RedpandaReconciler, obj client.Object) bool {
+		err := r.Client.Get(context.Background(), client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+		require.True(t, err == nil || apierrors.IsNotFound(err), err)
+		return err == nil
+	}
+
+	t.Run("in order", func(t *testing.T) {
+		rp := testRedpanda()
+		rp.Spec.ChartRef.PreDeleteKinds = []v1alpha1.PreDeleteKind{"Service", "StatefulSet"}
+		r, recorder := newTestRedpandaReconciler(t, svc.DeepCopy(), sts.DeepCopy(), other.DeepCopy())
+
+		done, err := r.preDeleteResources(context.Background(), rp, hr)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.False(t, exists(r, svc))
+		assert.True(t, exists(r, sts))
+		assert.True(t, exists(r, other))
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "Service 'default/redpanda-external' deleted ahead of HelmRelease 'default/redpanda'")
+
+		done, err = r.preDeleteResources(context.Background(), rp, hr)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.False(t, exists(r, sts))
+
+		done, err = r.preDeleteResources(context.Background(), rp, hr)
+		require.NoError(t, err)
+		assert.True(t, done)
+	})
+
+	t.Run("safe mode", func(t *testing.T) {
+		rp := testRedpanda()
+		rp.Spec.ChartRef.PreDeleteKinds = []v1alpha1.PreDeleteKind{"Service"}
+		r, recorder := newTestRedpandaReconciler(t, svc.DeepCopy())
+		r.SafeMode = true
+
+		done, err := r.preDeleteResources(context.Background(), rp, hr)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.True(t, exists(r, svc))
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "safe mode: would delete Service 'default/redpanda-external' ahead of HelmRelease 'default/redpanda'")
+
+		rp.Annotations = allowDestructiveActions
+		done, err = r.preDeleteResources(context.Background(), rp, hr)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.False(t, exists(r, svc))
+	})
+
+	t.Run("HelmRelease terminating", func(t *testing.T) {
+		rp := testRedpanda()
+		rp.Spec.ChartRef.PreDeleteKinds = []v1alpha1.PreDeleteKind{"Service"}
+		r, _ := newTestRedpandaReconciler(t, svc.DeepCopy())
+		terminating := hr.DeepCopy()
+		terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		done, err := r.preDeleteResources(context.Background(), rp, terminating)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.True(t, exists(r, svc))
+	})
+}
+
+func TestForceFinalizeAfterTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		annotations map[string]string
+		forced      bool
+	}{
+		{name: "disabled"},
+		{name: "within timeout", timeout: 2 * time.Hour},
+		{name: "timed out", timeout: 30 * time.Minute, forced: true},
+		{name: "annotation overrides", timeout: 2 * time.Hour, annotations: map[string]string{v1alpha1.GroupVersion.Group + finalizerTimeoutPath: "30m"}, forced: true},
+		{name: "annotation disables", timeout: 30 * time.Minute, annotations: map[string]string{v1alpha1.GroupVersion.Group + finalizerTimeoutPath: "0s"}},
+		{name: "invalid annotation", timeout: 30 * time.Minute, annotations: map[string]string{v1alpha1.GroupVersion.Group + finalizerTimeoutPath: "soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Annotations = tt.annotations
+			rp.Finalizers = []string{FinalizerKey}
+			rp.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			rp.Status.HelmRelease = "redpanda"
+			// stuck terminating
+			hr := &helmv2beta1.HelmRelease{ObjectMeta: metav1.ObjectMeta{
+				Name:              "redpanda",
+				Namespace:         "default",
+				Finalizers:        []string{"finalizers.fluxcd.io"},
+				DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+			}}
+			r, recorder := newTestRedpandaReconciler(t, rp, hr)
+			r.FinalizerTimeout = tt.timeout
+
+			_, err := r.reconcileDelete(context.Background(), rp)
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ForcedFinalizationCondition)
+			if !tt.forced {
+				assert.ErrorContains(t, err, "wait for helm release deletion")
+				assert.Nil(t, cond)
+				require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), &v1alpha1.Redpanda{}))
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, cond)
+			assert.Equal(t, v1alpha1.FinalizerTimeoutReason, cond.Reason)
+			assert.Contains(t, cond.Message, "orphaned resources: HelmRelease/redpanda")
+			assert.True(t, apierrors.IsNotFound(r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), &v1alpha1.Redpanda{})))
+
+			var forcedEvent bool
+			for len(recorder.Events) > 0 {
+				if e := <-recorder.Events; strings.HasPrefix(e, "Warning") && strings.Contains(e, "finalizer timeout of 30m0s") {
+					forcedEvent = true
+				}
+			}
+			assert.True(t, forcedEvent)
+		})
+	}
+}
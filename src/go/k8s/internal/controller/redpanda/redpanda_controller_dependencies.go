@@ -0,0 +1,130 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// helmChartLabelKey is the label Helm charts set to "<chart>-<version>".
+const helmChartLabelKey = "helm.sh/chart"
+
+// deployedChartVersions returns the versions of the given charts found in
+// the helm.sh/chart label of the Deployments and StatefulSets of the release
+// of the given Redpanda, by chart name. A chart deployed with several
+// versions, e.g. during an upgrade, lists all of them.
+func (r *RedpandaReconciler) deployedChartVersions(ctx context.Context, rp *v1alpha1.Redpanda, charts []string) (map[string][]string, error) {
+	opts := []client.ListOption{client.InNamespace(rp.Namespace), client.MatchingLabels{K8sInstanceLabelKey: rp.GetReleaseName()}}
+
+	var labels []map[string]string
+	var deploys appsv1.DeploymentList
+	if err := r.Client.List(ctx, &deploys, opts...); err != nil {
+		return nil, fmt.Errorf("list deployments of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range deploys.Items {
+		labels = append(labels, deploys.Items[i].Labels)
+	}
+	var stss appsv1.StatefulSetList
+	if err := r.Client.List(ctx, &stss, opts...); err != nil {
+		return nil, fmt.Errorf("list statefulsets of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range stss.Items {
+		labels = append(labels, stss.Items[i].Labels)
+	}
+
+	versions := map[string][]string{}
+	for _, l := range labels {
+		for _, chart := range charts {
+			version, ok := strings.CutPrefix(l[helmChartLabelKey], chart+"-")
+			if !ok {
+				continue
+			}
+			// Helm replaces the "+" of build metadata, which labels cannot hold
+			version = strings.ReplaceAll(version, "_", "+")
+			if _, err := semver.NewVersion(version); err != nil {
+				// another chart sharing the prefix, e.g. console-plugins
+				continue
+			}
+			if !slices.Contains(versions[chart], version) {
+				versions[chart] = append(versions[chart], version)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// reconcileDependencyVersions checks the deployed subchart versions against
+// Spec.ChartRef.DependencyVersions and maintains the DependencyVersionSkew
+// condition. Subcharts that are not deployed, e.g. a disabled Console, are
+// not reported.
+func (r *RedpandaReconciler) reconcileDependencyVersions(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	pins := rp.Spec.ChartRef.DependencyVersions
+	if len(pins) == 0 {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), DependencyVersionSkewCondition)
+		return nil
+	}
+
+	charts := make([]string, 0, len(pins))
+	for chart := range pins {
+		charts = append(charts, chart)
+	}
+	sort.Strings(charts)
+
+	deployed, err := r.deployedChartVersions(ctx, rp, charts)
+	if err != nil {
+		return err
+	}
+
+	reason := v1alpha1.DependencyVersionSkewReason
+	var skews []string
+	for _, chart := range charts {
+		constraint, err := semver.NewConstraint(pins[chart])
+		if err != nil {
+			reason = v1alpha1.InvalidDependencyConstraintReason
+			skews = append(skews, fmt.Sprintf("%s: invalid constraint %q: %s", chart, pins[chart], err))
+			continue
+		}
+		for _, version := range deployed[chart] {
+			if !constraint.Check(semver.MustParse(version)) {
+				skews = append(skews, fmt.Sprintf("%s %s does not satisfy %s", chart, version, pins[chart]))
+			}
+		}
+	}
+	if len(skews) == 0 {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), DependencyVersionSkewCondition)
+		return nil
+	}
+
+	msg := strings.Join(skews, "; ")
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, DependencyVersionSkewCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.DependencyVersionSkewReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("dependency version skew: %s", msg))
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               DependencyVersionSkewCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             reason,
+		Message:            msg,
+	})
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestReconcileDependencyVersions(t *testing.T) {
+	objectMeta := func(name, chart string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{
+			K8sInstanceLabelKey: "redpanda",
+			helmChartLabelKey:   chart,
+		}}
+	}
+	sts := &appsv1.StatefulSet{ObjectMeta: objectMeta("redpanda", "redpanda-5.8.0")}
+	console := func(chart string) client.Object {
+		return &appsv1.Deployment{ObjectMeta: objectMeta("redpanda-console", chart)}
+	}
+	otherRelease := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other-console", Namespace: "default", Labels: map[string]string{
+		K8sInstanceLabelKey: "other",
+		helmChartLabelKey:   "console-0.1.0",
+	}}}
+
+	tests := []struct {
+		name    string
+		pins    map[string]string
+		objs    []client.Object
+		reason  string
+		message string
+	}{
+		{name: "not set", objs: []client.Object{sts, console("console-0.6.0")}},
+		{name: "satisfied", pins: map[string]string{"console": "~0.7"}, objs: []client.Object{sts, console("console-0.7.4")}},
+		{name: "not deployed", pins: map[string]string{"console": "~0.7"}, objs: []client.Object{sts}},
+		{name: "other release ignored", pins: map[string]string{"console": "~0.7"}, objs: []client.Object{sts, otherRelease}},
+		{
+			name:    "skew",
+			pins:    map[string]string{"console": "~0.7"},
+			objs:    []client.Object{sts, console("console-0.6.2")},
+			reason:  v1alpha1.DependencyVersionSkewReason,
+			message: "console 0.6.2 does not satisfy ~0.7",
+		},
+		{
+			name:    "build metadata",
+			pins:    map[string]string{"console": "<0.7.0"},
+			objs:    []client.Object{sts, console("console-0.7.0_build.1")},
+			reason:  v1alpha1.DependencyVersionSkewReason,
+			message: "console 0.7.0+build.1 does not satisfy <0.7.0",
+		},
+		{
+			name:    "invalid constraint",
+			pins:    map[string]string{"console": "latest"},
+			objs:    []client.Object{sts, console("console-0.7.0")},
+			reason:  v1alpha1.InvalidDependencyConstraintReason,
+			message: `console: invalid constraint "latest"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, recorder := newTestRedpandaReconciler(t, tt.objs...)
+			rp := testRedpanda()
+			rp.Spec.ChartRef.DependencyVersions = tt.pins
+
+			require.NoError(t, r.reconcileDependencyVersions(context.Background(), rp))
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, DependencyVersionSkewCondition)
+			if tt.reason == "" {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.reason, cond.Reason)
+			assert.Contains(t, cond.Message, tt.message)
+			assert.Len(t, recorder.Events, 1)
+
+			// the event is not repeated while the message is unchanged
+			require.NoError(t, r.reconcileDependencyVersions(context.Background(), rp))
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+const (
+	structuredEventTimeout = 5 * time.Second
+	// structuredEventQueueSize is the number of structured events waiting
+	// for delivery past which new events are dropped.
+	structuredEventQueueSize = 100
+
+	// EventPolicyNormal and EventPolicyWarning emit the events of a reason
+	// with the Normal and Warning type, EventPolicyDrop does not emit them.
+	EventPolicyNormal  = "Normal"
+	EventPolicyWarning = "Warning"
+	EventPolicyDrop    = "Drop"
+)
+
+var structuredEventClient = &http.Client{Timeout: structuredEventTimeout}
+
+// reasonEvent emits an event like event, unless its reason is suppressed by
+// the suppress-events annotation of the Redpanda.
+func (r *RedpandaReconciler) reasonEvent(rp *v1alpha1.Redpanda, reason, revision, severity, msg string) {
+	if isEventSuppressed(rp, reason, time.Now()) {
+		Debugf(ctrl.Log.WithName("RedpandaReconciler.reasonEvent"), "suppressed %s event for Redpanda '%s/%s': %s", reason, rp.Namespace, rp.Name, msg)
+		return
+	}
+	r.event(rp, reason, revision, severity, msg)
+}
+
+// eventPolicy returns the policy of the reason, falling back to the policy
+// of the severity and to Warning for errors and Normal otherwise.
+func (r *RedpandaReconciler) eventPolicy(reason, severity string) string {
+	if policy, ok := r.EventPolicies[reason]; ok {
+		return policy
+	}
+	if policy, ok := r.EventPolicies[severity]; ok {
+		return policy
+	}
+	if severity == v1alpha1.EventSeverityError {
+		return EventPolicyWarning
+	}
+	return EventPolicyNormal
+}
+
+// ParseEventPolicies validates a table of event reasons, or severities, to
+// Normal, Warning or Drop, e.g. as given by --event-policy.
+func ParseEventPolicies(policies map[string]string) (map[string]string, error) {
+	parsed := make(map[string]string, len(policies))
+	for reason, policy := range policies {
+		if reason == "" {
+			return nil, fmt.Errorf("empty reason for event policy %q", policy)
+		}
+		switch policy {
+		case EventPolicyNormal, EventPolicyWarning, EventPolicyDrop:
+			parsed[reason] = policy
+		default:
+			return nil, fmt.Errorf("invalid event policy %q for reason %q, expected %s, %s or %s", policy, reason, EventPolicyNormal, EventPolicyWarning, EventPolicyDrop)
+		}
+	}
+	return parsed, nil
+}
+
+// isEventSuppressed reports whether events with the given reason fall in a
+// suppression window of the suppress-events annotation at the given time.
+// Malformed entries are ignored.
+func isEventSuppressed(rp *v1alpha1.Redpanda, reason string, now time.Time) bool {
+	value, ok := rp.GetAnnotations()[v1alpha1.GroupVersion.Group+suppressEventsPath]
+	if !ok {
+		return false
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, window, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name != reason {
+			continue
+		}
+		d, err := time.ParseDuration(window)
+		if err != nil {
+			continue
+		}
+		if now.Before(rp.CreationTimestamp.Add(d)) {
+			return true
+		}
+	}
+	return false
+}
+
+// StructuredEvent is the JSON document posted to the events receiver when
+// structured events are enabled. Fields are only ever added to this schema.
+type StructuredEvent struct {
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
+	Revision  string    `json:"revision,omitempty"`
+	Severity  string    `json:"severity"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// structuredEventQueue holds the structured events waiting to be posted by
+// a single worker, started with the first event.
+type structuredEventQueue struct {
+	once   sync.Once
+	events chan StructuredEvent
+}
+
+// postStructuredEvent queues a StructuredEvent for the given Redpanda to be
+// sent to StructuredEventsAddr. Delivery is best effort and does not block
+// the reconcile loop, events are dropped while the queue is full.
+func (r *RedpandaReconciler) postStructuredEvent(rp *v1alpha1.Redpanda, reason, revision, severity, msg string) {
+	event := StructuredEvent{
+		Kind:      "Redpanda",
+		Namespace: rp.Namespace,
+		Name:      rp.Name,
+		Reason:    reason,
+		Revision:  revision,
+		Severity:  severity,
+		Message:   msg,
+		Timestamp: time.Now().UTC(),
+	}
+
+	q := &r.structuredEvents
+	q.once.Do(func() {
+		q.events = make(chan StructuredEvent, structuredEventQueueSize)
+		go r.sendStructuredEvents(q.events)
+	})
+
+	select {
+	case q.events <- event:
+	default:
+		Debugf(ctrl.Log.WithName("RedpandaReconciler.postStructuredEvent"), "dropped structured %s event for Redpanda '%s/%s', the queue is full", reason, rp.Namespace, rp.Name)
+	}
+}
+
+// sendStructuredEvents posts the queued events one at a time.
+func (r *RedpandaReconciler) sendStructuredEvents(events <-chan StructuredEvent) {
+	log := ctrl.Log.WithName("RedpandaReconciler.sendStructuredEvents")
+	for event := range events {
+		event := event
+		if err := sendStructuredEvent(r.StructuredEventsAddr, &event); err != nil {
+			Debugf(log, "could not post structured event for Redpanda '%s/%s': %s", event.Namespace, event.Name, err)
+		}
+	}
+}
+
+func sendStructuredEvent(addr string, event *StructuredEvent) error {
+	return postJSON(context.Background(), structuredEventClient, addr, event)
+}
+
+// postJSON posts v as a JSON document to url, within the timeout of the
+// client. Responses with a 4xx or 5xx status are returned as errors.
+func postJSON(ctx context.Context, c *http.Client, url string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshaling payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting payload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// externalServiceName returns the name of the external Service created by
+// the chart for the given Redpanda.
+func externalServiceName(rp *v1alpha1.Redpanda) string {
+	return internalServiceName(rp) + "-external"
+}
+
+// externalAccessEnabled reports whether the chart creates the external
+// Service with the given values, external.enabled and
+// external.service.enabled both default to true.
+func externalAccessEnabled(values map[string]interface{}) bool {
+	for _, path := range [][]string{{"external", "enabled"}, {"external", "service", "enabled"}} {
+		if enabled, ok, err := unstructured.NestedBool(values, path...); err == nil && ok && !enabled {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileExternalEndpoints maintains the ExternalReady condition when
+// ChartRef.WaitForExternalEndpoints is set and external access is enabled in
+// the values of the release, including valuesFrom and values overlays, and
+// reports whether the external Service has ready endpoints. A HelmRelease is
+// ready regardless of a LoadBalancer without backends, so this is the only
+// signal clients outside the cluster can actually connect.
+func (r *RedpandaReconciler) reconcileExternalEndpoints(ctx context.Context, rp *v1alpha1.Redpanda) (bool, error) {
+	if !rp.Spec.ChartRef.WaitForExternalEndpoints {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ExternalReadyCondition)
+		return true, nil
+	}
+	values, err := r.releaseValues(ctx, rp)
+	if err != nil {
+		return false, err
+	}
+	if !externalAccessEnabled(values) {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ExternalReadyCondition)
+		return true, nil
+	}
+
+	var endpoints corev1.Endpoints
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: externalServiceName(rp)}
+	if err := r.Client.Get(ctx, key, &endpoints); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("get endpoints (%s): %w", key, err)
+	}
+
+	ready := 0
+	for _, subset := range endpoints.Subsets {
+		ready += len(subset.Addresses)
+	}
+
+	cond := metav1.Condition{
+		Type:               ExternalReadyCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.EndpointsReadyReason,
+		Message:            fmt.Sprintf("external Service '%s' has %d ready endpoints", key, ready),
+	}
+	if ready == 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = v1alpha1.NoReadyEndpointsReason
+		cond.Message = fmt.Sprintf("external Service '%s' has no ready endpoints", key)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), cond)
+	return ready > 0, nil
+}
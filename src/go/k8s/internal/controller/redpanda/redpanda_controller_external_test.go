@@ -0,0 +1,118 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestReconcileExternalEndpoints(t *testing.T) {
+	endpoints := func(addresses ...string) *corev1.Endpoints {
+		ep := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-external", Namespace: "default"}}
+		if len(addresses) > 0 {
+			subset := corev1.EndpointSubset{}
+			for _, ip := range addresses {
+				subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{IP: ip})
+			}
+			ep.Subsets = []corev1.EndpointSubset{subset}
+		}
+		return ep
+	}
+
+	configMap := func(name, data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string]string{"values.yaml": data},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		wait       bool
+		values     string
+		overlays   []v1alpha1.ValuesOverlay
+		valuesFrom []helmv2beta1.ValuesReference
+		objs       []client.Object
+		ready      bool
+		// reason is the expected reason of the ExternalReady condition, empty
+		// if the condition is expected to be absent
+		reason string
+	}{
+		{name: "not requested", objs: []client.Object{endpoints()}, ready: true},
+		{name: "external disabled", wait: true, values: `{"external":{"enabled":false}}`, ready: true},
+		{name: "external service disabled", wait: true, values: `{"external":{"service":{"enabled":false}}}`, ready: true},
+		{
+			name:     "external disabled in an overlay",
+			wait:     true,
+			overlays: []v1alpha1.ValuesOverlay{{Kind: "ConfigMap", Name: "overlay"}},
+			objs:     []client.Object{configMap("overlay", "external:\n  enabled: false\n")},
+			ready:    true,
+		},
+		{
+			name:       "external disabled in valuesFrom",
+			wait:       true,
+			valuesFrom: []helmv2beta1.ValuesReference{{Kind: "ConfigMap", Name: "values"}},
+			objs:       []client.Object{configMap("values", "external:\n  enabled: false\n")},
+			ready:      true,
+		},
+		{
+			name:       "external disabled in a valuesFrom target path",
+			wait:       true,
+			valuesFrom: []helmv2beta1.ValuesReference{{Kind: "ConfigMap", Name: "values", TargetPath: "external.enabled"}},
+			objs:       []client.Object{configMap("values", "false")},
+			ready:      true,
+		},
+		{
+			name:       "inline values take precedence over valuesFrom",
+			wait:       true,
+			values:     `{"external":{"enabled":true}}`,
+			valuesFrom: []helmv2beta1.ValuesReference{{Kind: "ConfigMap", Name: "values"}},
+			objs:       []client.Object{configMap("values", "external:\n  enabled: false\n")},
+			reason:     v1alpha1.NoReadyEndpointsReason,
+		},
+		{name: "endpoints missing", wait: true, reason: v1alpha1.NoReadyEndpointsReason},
+		{name: "no ready endpoints", wait: true, objs: []client.Object{endpoints()}, reason: v1alpha1.NoReadyEndpointsReason},
+		{name: "ready endpoints", wait: true, objs: []client.Object{endpoints("10.0.0.1", "10.0.0.2")}, ready: true, reason: v1alpha1.EndpointsReadyReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRedpandaReconciler(t, tt.objs...)
+			rp := testRedpanda()
+			rp.Spec.ChartRef.WaitForExternalEndpoints = tt.wait
+			rp.Spec.ChartRef.RawValues = tt.values
+			rp.Spec.ChartRef.ValuesOverlays = tt.overlays
+			rp.Spec.ChartRef.ValuesFrom = tt.valuesFrom
+
+			ready, err := r.reconcileExternalEndpoints(context.Background(), rp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ready, ready)
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ExternalReadyCondition)
+			if tt.reason == "" {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.reason, cond.Reason)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// reconcileChartSourceFailover creates or updates the HelmRepositories of the
+// fallback chart repository URLs and returns the HelmRepository the
+// HelmRelease fetches the chart from, see selectActiveHelmRepository. A
+// SourceFailover event is emitted whenever the active HelmRepository changes.
+func (r *RedpandaReconciler) reconcileChartSourceFailover(ctx context.Context, rp *v1alpha1.Redpanda, primary *sourcev1.HelmRepository) (*sourcev1.HelmRepository, error) {
+	repos := []*sourcev1.HelmRepository{primary}
+	for i, url := range rp.Spec.ChartRef.FallbackRepositoryURLs {
+		repo, err := r.applyHelmRepository(ctx, rp, helmRepositoryFromTemplate(rp, rp.GetFallbackHelmRepositoryName(i), url))
+		if err != nil {
+			return repo, err
+		}
+		repos = append(repos, repo)
+	}
+	if err := r.deleteStaleFallbackHelmRepositories(ctx, rp, len(rp.Spec.ChartRef.FallbackRepositoryURLs)); err != nil {
+		return primary, err
+	}
+
+	previous := rp.GetActiveHelmRepositoryName()
+	active := selectActiveHelmRepository(rp, repos, time.Now())
+	rp.Status.ActiveHelmRepository = active.Name
+	if active.Name != previous {
+		severity := v1alpha1.EventSeverityError
+		if active == primary {
+			severity = v1alpha1.EventSeverityInfo
+		}
+		msg := fmt.Sprintf("chart source switched from HelmRepository '%s/%s' to '%s/%s' (%s)", rp.Namespace, previous, active.Namespace, active.Name, active.Spec.URL)
+		r.reasonEvent(rp, "SourceFailover", rp.Status.LastAttemptedRevision, severity, msg)
+	}
+	return active, nil
+}
+
+// selectActiveHelmRepository returns the HelmRepository, out of the given
+// ones in order of preference, the HelmRelease fetches the chart from. It
+// fails back to the first ready HelmRepository preceding the active one, and
+// fails over to the next one once the active one has not been ready for
+// ChartRef.FailoverAfter. The period is tracked with the
+// HelmRepositoryNotReady condition.
+func selectActiveHelmRepository(rp *v1alpha1.Redpanda, repos []*sourcev1.HelmRepository, now time.Time) *sourcev1.HelmRepository {
+	current := 0
+	for i, repo := range repos {
+		if repo.Name == rp.GetActiveHelmRepositoryName() {
+			current = i
+			break
+		}
+	}
+
+	for _, repo := range repos[:current+1] {
+		if isHelmRepositoryReady(repo) {
+			apimeta.RemoveStatusCondition(rp.GetConditions(), HelmRepositoryNotReadyCondition)
+			return repo
+		}
+	}
+
+	active := repos[current]
+	if len(repos) == 1 {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), HelmRepositoryNotReadyCondition)
+		return active
+	}
+
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, HelmRepositoryNotReadyCondition)
+	if cond != nil && cond.Message == helmRepositoryNotReadyMessage(active) &&
+		current+1 < len(repos) && now.Sub(cond.LastTransitionTime.Time) >= failoverAfter(rp) {
+		active = repos[current+1]
+		if isHelmRepositoryReady(active) {
+			apimeta.RemoveStatusCondition(rp.GetConditions(), HelmRepositoryNotReadyCondition)
+			return active
+		}
+		cond = nil
+	}
+	if cond == nil || cond.Message != helmRepositoryNotReadyMessage(active) {
+		// restart the period for the newly active HelmRepository
+		apimeta.RemoveStatusCondition(rp.GetConditions(), HelmRepositoryNotReadyCondition)
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               HelmRepositoryNotReadyCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			LastTransitionTime: metav1.NewTime(now),
+			Reason:             v1alpha1.ArtifactFailedReason,
+			Message:            helmRepositoryNotReadyMessage(active),
+		})
+	}
+	return active
+}
+
+func isHelmRepositoryReady(repo *sourcev1.HelmRepository) bool {
+	return apimeta.IsStatusConditionTrue(repo.Status.Conditions, meta.ReadyCondition)
+}
+
+func helmRepositoryNotReadyMessage(repo *sourcev1.HelmRepository) string {
+	return fmt.Sprintf("HelmRepository '%s/%s' (%s) is not ready", repo.Namespace, repo.Name, repo.Spec.URL)
+}
+
+// failoverAfter returns ChartRef.FailoverAfter or its default.
+func failoverAfter(rp *v1alpha1.Redpanda) time.Duration {
+	if rp.Spec.ChartRef.FailoverAfter != nil {
+		return rp.Spec.ChartRef.FailoverAfter.Duration
+	}
+	return defaultFailoverAfter
+}
+
+// deleteStaleFallbackHelmRepositories deletes the HelmRepositories of the
+// fallback URLs removed from the Redpanda, i.e. those past the given number
+// of fallback URLs. HelmRepositories not owned by the Redpanda are kept.
+func (r *RedpandaReconciler) deleteStaleFallbackHelmRepositories(ctx context.Context, rp *v1alpha1.Redpanda, keep int) error {
+	for i := keep; ; i++ {
+		repo := &sourcev1.HelmRepository{}
+		key := types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetFallbackHelmRepositoryName(i)}
+		if err := r.Client.Get(ctx, key, repo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("error getting HelmRepository '%s': %w", key, err)
+		}
+		if !isOwnedBy(repo.OwnerReferences, rp.UID) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, repo); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("error deleting HelmRepository '%s': %w", key, err)
+		}
+		r.event(rp, v1alpha1.FallbackRepositoryDeletedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("HelmRepository '%s' of a removed fallback URL deleted", key))
+	}
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestSelectActiveHelmRepository(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := func(name string, ready bool) *sourcev1.HelmRepository {
+		status := metav1.ConditionFalse
+		if ready {
+			status = metav1.ConditionTrue
+		}
+		return &sourcev1.HelmRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: sourcev1.HelmRepositoryStatus{
+				Conditions: []metav1.Condition{{Type: meta.ReadyCondition, Status: status}},
+			},
+		}
+	}
+	notReadySince := func(repo *sourcev1.HelmRepository, d time.Duration) *metav1.Condition {
+		return &metav1.Condition{
+			Type:               HelmRepositoryNotReadyCondition,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-d)),
+			Message:            helmRepositoryNotReadyMessage(repo),
+		}
+	}
+
+	primaryDown, fallbackDown := repo("redpanda-repository", false), repo("redpanda-repository-fallback-1", false)
+	primaryUp, fallbackUp := repo("redpanda-repository", true), repo("redpanda-repository-fallback-1", true)
+
+	tests := []struct {
+		name      string
+		repos     []*sourcev1.HelmRepository
+		active    string
+		condition *metav1.Condition
+		expected  string
+		// notReadySince is the expected start of the not ready period, nil
+		// if the condition is expected to be removed
+		notReadySince *time.Time
+	}{
+		{
+			name:     "primary ready",
+			repos:    []*sourcev1.HelmRepository{primaryUp, fallbackUp},
+			expected: "redpanda-repository",
+		},
+		{
+			name:     "no fallback",
+			repos:    []*sourcev1.HelmRepository{primaryDown},
+			expected: "redpanda-repository",
+		},
+		{
+			name:          "primary starts failing",
+			repos:         []*sourcev1.HelmRepository{primaryDown, fallbackUp},
+			expected:      "redpanda-repository",
+			notReadySince: &now,
+		},
+		{
+			name:          "primary failing within failoverAfter",
+			repos:         []*sourcev1.HelmRepository{primaryDown, fallbackUp},
+			condition:     notReadySince(primaryDown, 4*time.Minute),
+			expected:      "redpanda-repository",
+			notReadySince: ptr.To(now.Add(-4 * time.Minute)),
+		},
+		{
+			name:      "failover",
+			repos:     []*sourcev1.HelmRepository{primaryDown, fallbackUp},
+			condition: notReadySince(primaryDown, 5*time.Minute),
+			expected:  "redpanda-repository-fallback-1",
+		},
+		{
+			name:          "failover to not ready fallback",
+			repos:         []*sourcev1.HelmRepository{primaryDown, fallbackDown},
+			condition:     notReadySince(primaryDown, 10*time.Minute),
+			expected:      "redpanda-repository-fallback-1",
+			notReadySince: &now,
+		},
+		{
+			name:          "last fallback failing",
+			repos:         []*sourcev1.HelmRepository{primaryDown, fallbackDown},
+			active:        "redpanda-repository-fallback-1",
+			condition:     notReadySince(fallbackDown, time.Hour),
+			expected:      "redpanda-repository-fallback-1",
+			notReadySince: ptr.To(now.Add(-time.Hour)),
+		},
+		{
+			name:     "fail back",
+			repos:    []*sourcev1.HelmRepository{primaryUp, fallbackUp},
+			active:   "redpanda-repository-fallback-1",
+			expected: "redpanda-repository",
+		},
+		{
+			name:     "active fallback removed",
+			repos:    []*sourcev1.HelmRepository{primaryUp},
+			active:   "redpanda-repository-fallback-2",
+			expected: "redpanda-repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Status.ActiveHelmRepository = tt.active
+			if tt.condition != nil {
+				rp.Status.Conditions = []metav1.Condition{*tt.condition}
+			}
+
+			active := selectActiveHelmRepository(rp, tt.repos, now)
+			assert.Equal(t, tt.expected, active.Name)
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, HelmRepositoryNotReadyCondition)
+			if tt.notReadySince == nil {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, helmRepositoryNotReadyMessage(active), cond.Message)
+			assert.Equal(t, *tt.notReadySince, cond.LastTransitionTime.Time)
+		})
+	}
+}
+
+func TestReconcileChartSourceFailover(t *testing.T) {
+	primary := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda-repository", Namespace: "default"},
+		Spec:       sourcev1.HelmRepositorySpec{URL: v1alpha1.RedpandaChartRepository},
+	}
+	rp := testRedpanda()
+	rp.UID = "rp-uid"
+	rp.Spec.ChartRef.FallbackRepositoryURLs = []string{"oci://mirror.example.com/charts"}
+	rp.Spec.ChartRef.FailoverAfter = &metav1.Duration{Duration: time.Minute}
+	rp.Status.Conditions = []metav1.Condition{{
+		Type:               HelmRepositoryNotReadyCondition,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+		Message:            helmRepositoryNotReadyMessage(primary),
+	}}
+	stale := helmRepositoryFromTemplate(rp, rp.GetFallbackHelmRepositoryName(1), "https://stale.example.com/charts")
+	r, recorder := newTestRedpandaReconciler(t, primary, stale)
+
+	active, err := r.reconcileChartSourceFailover(context.Background(), rp, primary)
+	require.NoError(t, err)
+	assert.Equal(t, "redpanda-repository-fallback-1", active.Name)
+	assert.Equal(t, "redpanda-repository-fallback-1", rp.Status.ActiveHelmRepository)
+
+	var fallback sourcev1.HelmRepository
+	require.NoError(t, r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "redpanda-repository-fallback-1"}, &fallback))
+	assert.Equal(t, "oci://mirror.example.com/charts", fallback.Spec.URL)
+	assert.Equal(t, sourcev1.HelmRepositoryTypeOCI, fallback.Spec.Type)
+
+	err = r.Client.Get(context.Background(), client.ObjectKeyFromObject(stale), &sourcev1.HelmRepository{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"), "chart source switched from HelmRepository 'default/redpanda-repository' to 'default/redpanda-repository-fallback-1' (oci://mirror.example.com/charts)")
+
+	hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, "redpanda-repository-fallback-1", hr.Spec.Chart.Spec.SourceRef.Name)
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// Lifecycle phases a hook can be configured for with the hook-<phase>
+// annotation of a Redpanda.
+const (
+	// hookPhasePreUpgrade is called before the HelmRelease is updated.
+	hookPhasePreUpgrade = "pre-upgrade"
+	// hookPhasePostReady is called when the Redpanda becomes ready.
+	hookPhasePostReady = "post-ready"
+	// hookPhasePreDelete is called before the HelmRelease is deleted.
+	hookPhasePreDelete = "pre-delete"
+
+	hookFailClosed = "fail-closed"
+
+	hookTimeout = 10 * time.Second
+)
+
+// hookClient does not follow redirects, as checkHookURL only vets the URL of
+// the annotation and a redirect could point anywhere.
+var hookClient = &http.Client{
+	Timeout: hookTimeout,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return errHookRedirect
+	},
+}
+
+var errHookRedirect = errors.New("hooks must not redirect")
+
+// HookPayload is the JSON document posted to the URL of a lifecycle hook.
+// Fields are only ever added to this schema.
+type HookPayload struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	Revision  string `json:"revision,omitempty"`
+	// ChartVersion is the chart version of the HelmRelease, for the
+	// pre-upgrade phase the version it is about to be updated to.
+	ChartVersion string    `json:"chartVersion,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// isHookFailClosed reports whether a failing hook of the given Redpanda
+// blocks the transition it is called for.
+func isHookFailClosed(rp *v1alpha1.Redpanda) bool {
+	return rp.Annotations[v1alpha1.GroupVersion.Group+hookFailurePolicyPath] == hookFailClosed
+}
+
+// runHook posts a HookPayload to the URL of the hook-<phase> annotation of
+// the given Redpanda, if any. Failures, including URLs whose host is not in
+// HookAllowedHosts, are logged and reported with an event, they are only
+// returned with the fail-closed hook failure policy.
+func (r *RedpandaReconciler) runHook(ctx context.Context, rp *v1alpha1.Redpanda, phase, chartVersion string) error {
+	hookURL, ok := rp.Annotations[v1alpha1.GroupVersion.Group+hookPathPrefix+phase]
+	if !ok || hookURL == "" {
+		return nil
+	}
+
+	payload := HookPayload{
+		Namespace:    rp.Namespace,
+		Name:         rp.Name,
+		Phase:        phase,
+		Revision:     rp.Status.LastAttemptedRevision,
+		ChartVersion: chartVersion,
+		Timestamp:    time.Now().UTC(),
+	}
+	err := r.checkHookURL(hookURL)
+	if err == nil {
+		err = postJSON(ctx, hookClient, hookURL, &payload)
+	}
+	if err == nil {
+		Debugf(ctrl.LoggerFrom(ctx), "%s hook of Redpanda '%s/%s' succeeded", phase, rp.Namespace, rp.Name)
+		return nil
+	}
+
+	err = fmt.Errorf("%s hook failed: %w", phase, err)
+	ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.runHook").Error(err, "calling lifecycle hook", "url", hookURL)
+	if !isHookFailClosed(rp) {
+		r.event(rp, v1alpha1.HookFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("%s, continuing", err))
+		return nil
+	}
+	r.event(rp, v1alpha1.HookFailedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, err.Error())
+	return err
+}
+
+// checkHookURL rejects hook URLs that are not http or https, or whose host
+// is not in HookAllowedHosts, so that the annotations of a Redpanda cannot
+// make the operator post to arbitrary endpoints.
+func (r *RedpandaReconciler) checkHookURL(hookURL string) error {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	if !slices.Contains(r.HookAllowedHosts, u.Host) && !slices.Contains(r.HookAllowedHosts, u.Hostname()) {
+		return fmt.Errorf("host %q is not an allowed hook host", u.Host)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestRunHook(t *testing.T) {
+	tests := []struct {
+		name       string
+		noHook     bool
+		notAllowed bool
+		status     int
+		policy     string
+		wantErr    bool
+		event      bool
+	}{
+		{name: "no hook", noHook: true},
+		{name: "succeeded", status: http.StatusOK},
+		{name: "failed open", status: http.StatusServiceUnavailable, event: true},
+		{name: "failed closed", status: http.StatusForbidden, policy: "fail-closed", wantErr: true, event: true},
+		{name: "host not allowed", notAllowed: true, event: true},
+		{name: "host not allowed fail closed", notAllowed: true, policy: "fail-closed", wantErr: true, event: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan HookPayload, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var payload HookPayload
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+				received <- payload
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			r, recorder := newTestRedpandaReconciler(t)
+			if !tt.notAllowed {
+				r.HookAllowedHosts = []string{server.Listener.Addr().String()}
+			}
+			rp := testRedpanda()
+			rp.Status.LastAttemptedRevision = "rev-1"
+			rp.Annotations = map[string]string{}
+			if !tt.noHook {
+				rp.Annotations[v1alpha1.GroupVersion.Group+hookPathPrefix+hookPhasePreUpgrade] = server.URL
+			}
+			if tt.policy != "" {
+				rp.Annotations[v1alpha1.GroupVersion.Group+hookFailurePolicyPath] = tt.policy
+			}
+
+			err := r.runHook(context.Background(), rp, hookPhasePreUpgrade, "5.8.0")
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "pre-upgrade hook failed")
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.event {
+				require.Len(t, recorder.Events, 1)
+			} else {
+				assert.Empty(t, recorder.Events)
+			}
+
+			if tt.noHook || tt.notAllowed {
+				assert.Empty(t, received)
+				return
+			}
+			payload := <-received
+			assert.Equal(t, "redpanda", payload.Name)
+			assert.Equal(t, hookPhasePreUpgrade, payload.Phase)
+			assert.Equal(t, "rev-1", payload.Revision)
+			assert.Equal(t, "5.8.0", payload.ChartVersion)
+		})
+	}
+}
+
+func TestCheckHookURL(t *testing.T) {
+	r := &RedpandaReconciler{HookAllowedHosts: []string{"hooks.example.svc", "10.0.0.1:8080"}}
+
+	assert.NoError(t, r.checkHookURL("http://hooks.example.svc/redpanda"))
+	assert.NoError(t, r.checkHookURL("https://hooks.example.svc:8443/redpanda"))
+	assert.NoError(t, r.checkHookURL("http://10.0.0.1:8080/redpanda"))
+	assert.Error(t, r.checkHookURL("http://10.0.0.1:9090/redpanda"))
+	assert.Error(t, r.checkHookURL("http://169.254.169.254/latest/meta-data"))
+	assert.Error(t, r.checkHookURL("file:///etc/passwd"))
+	assert.Error(t, (&RedpandaReconciler{}).checkHookURL("http://hooks.example.svc/redpanda"))
+}
+
+func TestRunHookRedirect(t *testing.T) {
+	var redirected atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		redirected.Add(1)
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	r, recorder := newTestRedpandaReconciler(t)
+	r.HookAllowedHosts = []string{server.Listener.Addr().String()}
+	rp := testRedpanda()
+	rp.Annotations = map[string]string{
+		v1alpha1.GroupVersion.Group + hookPathPrefix + hookPhasePreUpgrade: server.URL,
+		v1alpha1.GroupVersion.Group + hookFailurePolicyPath:                hookFailClosed,
+	}
+
+	err := r.runHook(context.Background(), rp, hookPhasePreUpgrade, "5.8.0")
+	assert.ErrorIs(t, err, errHookRedirect)
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, int32(0), redirected.Load())
+}
+
+func TestPreDeleteHookCalledOnce(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	rp := testRedpanda()
+	rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + hookPathPrefix + hookPhasePreDelete: server.URL}
+	rp.Spec.ChartRef.PreDeleteKinds = []v1alpha1.PreDeleteKind{"Service"}
+	rp.Status.HelmRelease = rp.GetHelmReleaseName()
+	hr := &helmv2beta1.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: rp.GetHelmReleaseName(), Namespace: rp.Namespace},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redpanda-external",
+			Namespace: rp.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/instance": hr.GetReleaseName()},
+		},
+	}
+	r, _ := newTestRedpandaReconciler(t, rp, hr, svc)
+	r.HookAllowedHosts = []string{server.Listener.Addr().String()}
+
+	// the first call deletes the Service and waits for it to be gone
+	require.Error(t, r.deleteHelmRelease(context.Background(), rp))
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), hr))
+	assert.Equal(t, "true", hr.Annotations[v1alpha1.GroupVersion.Group+hookPreDeleteCalledPath])
+
+	require.NoError(t, r.deleteHelmRelease(context.Background(), rp))
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"strings"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+const (
+	defaultRedpandaChartName = "redpanda"
+	defaultConsoleChartName  = "console"
+)
+
+// truncName mirrors `trunc n | trimSuffix "-"` from the chart helpers.
+func truncName(name string, n int) string {
+	if len(name) > n {
+		name = name[:n]
+	}
+	return strings.TrimSuffix(name, "-")
+}
+
+// redpandaChartName returns the app.kubernetes.io/name label value the
+// redpanda chart uses, following its "redpanda.name" helper.
+func redpandaChartName(rp *v1alpha1.Redpanda) string {
+	name := defaultRedpandaChartName
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.NameOverride != "" {
+		name = rp.Spec.ClusterSpec.NameOverride
+	}
+	return truncName(name, 63)
+}
+
+// redpandaComponentLabel returns the app.kubernetes.io/component label value
+// of the redpanda chart StatefulSet Pods.
+func redpandaComponentLabel(rp *v1alpha1.Redpanda) string {
+	return truncName(redpandaChartName(rp), 51) + "-statefulset"
+}
+
+// consoleChartName returns the app.kubernetes.io/name label value the
+// console subchart uses, following its "console.name" helper.
+func consoleChartName(rp *v1alpha1.Redpanda) string {
+	name := defaultConsoleChartName
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.Console != nil &&
+		rp.Spec.ClusterSpec.Console.NameOverride != nil && *rp.Spec.ClusterSpec.Console.NameOverride != "" {
+		name = *rp.Spec.ClusterSpec.Console.NameOverride
+	}
+	return truncName(name, 63)
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	adminutils "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/admin"
+)
+
+// redpandaAdminAPI builds an Admin API client for the brokers of the given
+// Redpanda from the values of its Helm release.
+func redpandaAdminAPI(ctx context.Context, rp *v1alpha1.Redpanda) (adminutils.AdminAPIClient, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("redpandaAdminAPI")
+
+	values, err := getHelmValues(log, rp.GetReleaseName(), rp.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve values of release %s/%s: %w", rp.Namespace, rp.GetReleaseName(), err)
+	}
+	return buildAdminAPI(rp.GetReleaseName(), rp.Namespace, desiredReplicas(rp), values)
+}
+
+func (r *RedpandaReconciler) licenseExpiryWarning() time.Duration {
+	if r.LicenseExpiryWarning > 0 {
+		return r.LicenseExpiryWarning
+	}
+	return defaultLicenseExpiryWarning
+}
+
+// reconcileLicense records the license loaded in the cluster in the status of
+// the Redpanda when license checks are enabled. Failing to reach the Admin API
+// leaves the previously recorded license untouched.
+func (r *RedpandaReconciler) reconcileLicense(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	if !r.LicenseCheck {
+		return nil
+	}
+
+	newAdminAPI := r.adminAPI
+	if newAdminAPI == nil {
+		newAdminAPI = redpandaAdminAPI
+	}
+	adminAPI, err := newAdminAPI(ctx, rp)
+	if err != nil {
+		return fmt.Errorf("could not create admin API client: %w", err)
+	}
+
+	info, err := adminAPI.GetLicenseInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("get license info: %w", err)
+	}
+
+	r.setLicenseStatus(rp, info, time.Now())
+	return nil
+}
+
+// setLicenseStatus sets Status.License and the LicenseInvalid and
+// LicenseExpiringSoon conditions from the license reported by the Admin API.
+func (r *RedpandaReconciler) setLicenseStatus(rp *v1alpha1.Redpanda, info admin.License, now time.Time) {
+	status := &v1alpha1.RedpandaLicenseStatus{Loaded: info.Loaded}
+	if info.Loaded {
+		status.Organization = info.Properties.Organization
+		status.Type = info.Properties.Type
+		if info.Properties.Expires > 0 {
+			status.Expiration = &metav1.Time{Time: time.Unix(info.Properties.Expires, 0).UTC()}
+		}
+	}
+	rp.Status.License = status
+
+	var reason, msg string
+	switch {
+	case !status.Loaded:
+		reason, msg = v1alpha1.LicenseNotLoadedReason, "no license is loaded in the cluster"
+	case status.Expiration != nil && !now.Before(status.Expiration.Time):
+		reason, msg = v1alpha1.LicenseExpiredReason, fmt.Sprintf("license expired at %s", status.Expiration.Format(time.RFC3339))
+	}
+	r.setLicenseCondition(rp, LicenseInvalidCondition, reason, msg)
+
+	reason, msg = "", ""
+	if status.Expiration != nil && now.Before(status.Expiration.Time) && status.Expiration.Sub(now) < r.licenseExpiryWarning() {
+		reason, msg = v1alpha1.LicenseExpiringReason, fmt.Sprintf("license expires at %s", status.Expiration.Format(time.RFC3339))
+	}
+	r.setLicenseCondition(rp, LicenseExpiringSoonCondition, reason, msg)
+}
+
+// setLicenseCondition sets the given condition to True with an event when a
+// reason is given, or removes it otherwise.
+func (r *RedpandaReconciler) setLicenseCondition(rp *v1alpha1.Redpanda, conditionType, reason, msg string) {
+	if reason == "" {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), conditionType)
+		return
+	}
+	if !apimeta.IsStatusConditionTrue(rp.Status.Conditions, conditionType) {
+		r.reasonEvent(rp, conditionType, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             reason,
+		Message:            msg,
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	adminutils "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/admin"
+)
+
+type licenseAdminAPI struct {
+	adminutils.AdminAPIClient
+	license admin.License
+}
+
+func (a *licenseAdminAPI) GetLicenseInfo(context.Context) (admin.License, error) {
+	return a.license, nil
+}
+
+func TestSetLicenseStatus(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	license := func(expires time.Time) admin.License {
+		return admin.License{
+			Loaded: true,
+			Properties: admin.LicenseProperties{
+				Organization: "redpanda",
+				Type:         "enterprise",
+				Expires:      expires.Unix(),
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		license  admin.License
+		invalid  string
+		expiring string
+	}{
+		{name: "valid", license: license(now.Add(365 * 24 * time.Hour))},
+		{name: "expiring soon", license: license(now.Add(7 * 24 * time.Hour)), expiring: v1alpha1.LicenseExpiringReason},
+		{name: "expired", license: license(now.Add(-time.Hour)), invalid: v1alpha1.LicenseExpiredReason},
+		{name: "not loaded", license: admin.License{}, invalid: v1alpha1.LicenseNotLoadedReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			r, recorder := newTestRedpandaReconciler(t)
+
+			r.setLicenseStatus(rp, tt.license, now)
+
+			require.NotNil(t, rp.Status.License)
+			assert.Equal(t, tt.license.Loaded, rp.Status.License.Loaded)
+			if tt.license.Loaded {
+				assert.Equal(t, "enterprise", rp.Status.License.Type)
+				assert.Equal(t, time.Unix(tt.license.Properties.Expires, 0).UTC(), rp.Status.License.Expiration.Time)
+			}
+
+			for conditionType, reason := range map[string]string{
+				LicenseInvalidCondition:      tt.invalid,
+				LicenseExpiringSoonCondition: tt.expiring,
+			} {
+				cond := apimeta.FindStatusCondition(rp.Status.Conditions, conditionType)
+				if reason == "" {
+					assert.Nil(t, cond, conditionType)
+					continue
+				}
+				require.NotNil(t, cond, conditionType)
+				assert.Equal(t, reason, cond.Reason)
+			}
+
+			if tt.invalid != "" || tt.expiring != "" {
+				assert.Len(t, recorder.Events, 1)
+			} else {
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestReconcileLicense(t *testing.T) {
+	rp := testRedpanda()
+	r, _ := newTestRedpandaReconciler(t)
+	r.adminAPI = func(context.Context, *v1alpha1.Redpanda) (adminutils.AdminAPIClient, error) {
+		return &licenseAdminAPI{license: admin.License{
+			Loaded:     true,
+			Properties: admin.LicenseProperties{Type: "enterprise", Expires: time.Now().Add(365 * 24 * time.Hour).Unix()},
+		}}, nil
+	}
+
+	// disabled by default
+	require.NoError(t, r.reconcileLicense(context.Background(), rp))
+	assert.Nil(t, rp.Status.License)
+
+	r.LicenseCheck = true
+	require.NoError(t, r.reconcileLicense(context.Background(), rp))
+	require.NotNil(t, rp.Status.License)
+	assert.True(t, rp.Status.License.Loaded)
+	assert.Empty(t, rp.Status.Conditions)
+}
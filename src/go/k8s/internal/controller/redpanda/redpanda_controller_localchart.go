@@ -0,0 +1,146 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	helmrepo "helm.sh/helm/v3/pkg/repo"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// localChartsPath is the directory of the artifact storage, and the path of
+// the file server, local charts are staged at as a chart repository.
+const localChartsPath = "local-charts"
+
+var errLocalChartsDisabled = errors.New("local charts are disabled, start the operator in v2 mode with --local-charts-dir")
+
+// reconcileLocalChartRepository stages the chart of ChartRef.LocalChartPath
+// as a chart repository in the artifact storage of the operator, and points
+// the HelmRepository at it, so the chart is never fetched from a remote
+// source.
+func (r *RedpandaReconciler) reconcileLocalChartRepository(ctx context.Context, rp *v1alpha1.Redpanda) (*v1alpha1.Redpanda, *sourcev1.HelmRepository, error) {
+	url, err := r.stageLocalChart(rp)
+	if err != nil {
+		r.event(rp, v1alpha1.LocalChartInvalidReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("invalid localChartPath: %s", err))
+		return v1alpha1.RedpandaNotReady(rp, v1alpha1.LocalChartInvalidReason, err.Error()), &sourcev1.HelmRepository{}, err
+	}
+
+	repoTemplate := helmRepositoryFromTemplate(rp, rp.GetHelmRepositoryName(), url)
+	// the file server of the operator neither authenticates nor serves TLS
+	repoTemplate.Spec.SecretRef = nil
+	repoTemplate.Spec.CertSecretRef = nil
+	repo, err := r.applyHelmRepository(ctx, rp, repoTemplate)
+	if err != nil {
+		return rp, repo, err
+	}
+	rp.Status.HelmRepository = repo.Name
+	rp.Status.ActiveHelmRepository = repo.Name
+	apimeta.RemoveStatusCondition(rp.GetConditions(), HelmRepositoryNotReadyCondition)
+
+	// fallback URLs are ignored for a local chart
+	if err := r.deleteStaleFallbackHelmRepositories(ctx, rp, 0); err != nil {
+		return rp, repo, err
+	}
+	return rp, repo, nil
+}
+
+// stageLocalChart validates the chart of ChartRef.LocalChartPath and writes
+// it, with an index, below the artifact storage. It returns the URL the
+// chart repository is served at. The chart is only staged again once its
+// files change.
+func (r *RedpandaReconciler) stageLocalChart(rp *v1alpha1.Redpanda) (string, error) {
+	if r.LocalChartsDir == "" || r.StoragePath == "" {
+		return "", errLocalChartsDisabled
+	}
+
+	// the path may not leave the local charts directory
+	path := filepath.Join(r.LocalChartsDir, filepath.Clean("/"+rp.Spec.ChartRef.LocalChartPath))
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("local chart %q: %w", rp.Spec.ChartRef.LocalChartPath, err)
+	}
+
+	dir := filepath.Join(r.StoragePath, localChartsPath, rp.Namespace, rp.Name)
+	url := fmt.Sprintf("http://%s/%s/%s/%s", r.StorageAdvAddr, localChartsPath, rp.Namespace, rp.Name)
+	index := filepath.Join(dir, "index.yaml")
+	if staged, err := os.Stat(index); err == nil {
+		modified, err := modifiedSince(path, staged.ModTime())
+		if err != nil {
+			return "", fmt.Errorf("local chart %q: %w", rp.Spec.ChartRef.LocalChartPath, err)
+		}
+		if !modified {
+			return url, nil
+		}
+	}
+
+	chart, err := loader.Load(path)
+	if err != nil {
+		return "", fmt.Errorf("local chart %q is not a valid chart: %w", rp.Spec.ChartRef.LocalChartPath, err)
+	}
+	// the HelmRelease always installs the redpanda chart
+	if chart.Name() != defaultRedpandaChartName {
+		return "", fmt.Errorf("local chart %q is named %q, expected %q", rp.Spec.ChartRef.LocalChartPath, chart.Name(), defaultRedpandaChartName)
+	}
+	if v := rp.Spec.ChartRef.ChartVersion; v != "" && v != chart.Metadata.Version {
+		return "", fmt.Errorf("chartVersion %q does not match the version %q of local chart %q", v, chart.Metadata.Version, rp.Spec.ChartRef.LocalChartPath)
+	}
+
+	// drop the charts staged before
+	if err = os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("removing staged local chart: %w", err)
+	}
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("staging local chart: %w", err)
+	}
+	if _, err = chartutil.Save(chart, dir); err != nil {
+		return "", fmt.Errorf("packaging local chart: %w", err)
+	}
+	indexFile, err := helmrepo.IndexDirectory(dir, url)
+	if err != nil {
+		return "", fmt.Errorf("indexing local chart: %w", err)
+	}
+	indexFile.SortEntries()
+	if err = indexFile.WriteFile(index, 0o600); err != nil {
+		return "", fmt.Errorf("writing local chart index: %w", err)
+	}
+	return url, nil
+}
+
+// modifiedSince reports whether the given file, or any file below the given
+// directory, was modified after t.
+func modifiedSince(path string, t time.Time) (bool, error) {
+	modified := false
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(t) {
+			modified = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return modified, err
+}
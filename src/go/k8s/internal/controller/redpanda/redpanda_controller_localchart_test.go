@@ -0,0 +1,89 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	helmrepo "helm.sh/helm/v3/pkg/repo"
+)
+
+func writeLocalChart(t *testing.T, dir, name, version string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	chartYAML := "apiVersion: v2\nname: " + name + "\nversion: " + version + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chartYAML), 0o600))
+}
+
+func TestStageLocalChart(t *testing.T) {
+	charts := t.TempDir()
+	writeLocalChart(t, filepath.Join(charts, "redpanda"), "redpanda", "5.8.0")
+	writeLocalChart(t, filepath.Join(charts, "console"), "console", "0.7.0")
+	require.NoError(t, os.WriteFile(filepath.Join(charts, "broken.tgz"), []byte("not a chart"), 0o600))
+
+	tests := []struct {
+		name     string
+		disabled bool
+		path     string
+		version  string
+		err      string
+	}{
+		{name: "directory", path: "redpanda"},
+		{name: "matching version", path: "redpanda", version: "5.8.0"},
+		{name: "path cannot escape", path: "../../redpanda"},
+		{name: "disabled", disabled: true, path: "redpanda", err: "local charts are disabled"},
+		{name: "missing", path: "redpanda-5.9.0.tgz", err: "no such file or directory"},
+		{name: "invalid chart", path: "broken.tgz", err: "is not a valid chart"},
+		{name: "other chart", path: "console", err: `is named "console"`},
+		{name: "version mismatch", path: "redpanda", version: "5.9.0", err: `chartVersion "5.9.0" does not match`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRedpandaReconciler(t)
+			r.StoragePath = t.TempDir()
+			r.StorageAdvAddr = "operator:9090"
+			if !tt.disabled {
+				r.LocalChartsDir = charts
+			}
+			rp := testRedpanda()
+			rp.Spec.ChartRef.LocalChartPath = tt.path
+			rp.Spec.ChartRef.ChartVersion = tt.version
+
+			url, err := r.stageLocalChart(rp)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "http://operator:9090/local-charts/default/redpanda", url)
+
+			index := filepath.Join(r.StoragePath, localChartsPath, "default", "redpanda", "index.yaml")
+			indexFile, err := helmrepo.LoadIndexFile(index)
+			require.NoError(t, err)
+			version, err := indexFile.Get("redpanda", "5.8.0")
+			require.NoError(t, err)
+			assert.Equal(t, []string{url + "/redpanda-5.8.0.tgz"}, version.URLs)
+
+			// unchanged charts are not staged again
+			staged, err := os.Stat(index)
+			require.NoError(t, err)
+			_, err = r.stageLocalChart(rp)
+			require.NoError(t, err)
+			again, err := os.Stat(index)
+			require.NoError(t, err)
+			assert.Equal(t, staged.ModTime(), again.ModTime())
+		})
+	}
+}
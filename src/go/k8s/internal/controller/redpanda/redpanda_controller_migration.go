@@ -0,0 +1,274 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+// Migration steps that can be rerun with the migration-rerun annotation, each
+// one adopts a resource of the Cluster or Console custom resource.
+const (
+	migrationStepPods                  = "pods"
+	migrationStepService               = "service"
+	migrationStepExternalService       = "external-service"
+	migrationStepServiceAccount        = "serviceaccount"
+	migrationStepPDB                   = "pdb"
+	migrationStepStatefulSet           = "statefulset"
+	migrationStepPodMonitor            = "podmonitor"
+	migrationStepConsoleServiceAccount = "console-serviceaccount"
+	migrationStepConsoleService        = "console-service"
+	migrationStepConsoleDeployment     = "console-deployment"
+	migrationStepConsoleIngress        = "console-ingress"
+)
+
+var migrationSteps = []string{
+	migrationStepPods,
+	migrationStepService,
+	migrationStepExternalService,
+	migrationStepServiceAccount,
+	migrationStepPDB,
+	migrationStepStatefulSet,
+	migrationStepPodMonitor,
+	migrationStepConsoleServiceAccount,
+	migrationStepConsoleService,
+	migrationStepConsoleDeployment,
+	migrationStepConsoleIngress,
+}
+
+// parseMigrationSteps returns the sorted, deduplicated steps of the given
+// comma separated list.
+func parseMigrationSteps(value string) []string {
+	var steps []string
+	for _, step := range strings.Split(value, ",") {
+		step = strings.ToLower(strings.TrimSpace(step))
+		if step != "" && !slices.Contains(steps, step) {
+			steps = append(steps, step)
+		}
+	}
+	sort.Strings(steps)
+	return steps
+}
+
+// pendingMigrationRerun returns the steps of the migration-rerun annotation
+// that have not been rerun yet, see Status.RerunMigrationSteps. The listed
+// steps skip the check whether their resource is already adopted. Removing
+// the annotation clears Status.RerunMigrationSteps, so that the same steps
+// can be requested again.
+func (r *RedpandaReconciler) pendingMigrationRerun(rp *v1alpha1.Redpanda) map[string]bool {
+	value, ok := rp.Annotations[v1alpha1.GroupVersion.Group+migrationRerunPath]
+	if !ok {
+		rp.Status.RerunMigrationSteps = nil
+		return nil
+	}
+
+	steps := parseMigrationSteps(value)
+	if len(steps) == 0 || slices.Equal(steps, rp.Status.RerunMigrationSteps) {
+		return nil
+	}
+
+	pending := map[string]bool{}
+	var unknown []string
+	for _, step := range steps {
+		pending[step] = true
+		if !slices.Contains(migrationSteps, step) {
+			unknown = append(unknown, step)
+		}
+	}
+	if len(unknown) > 0 {
+		r.event(rp, v1alpha1.InvalidMigrationRerunReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, fmt.Sprintf("unknown migration steps in the %s annotation: %s, valid steps are: %s", v1alpha1.GroupVersion.Group+migrationRerunPath, strings.Join(unknown, ", "), strings.Join(migrationSteps, ", ")))
+	}
+	return pending
+}
+
+// recordMigrationRerun records the given rerun steps in
+// Status.RerunMigrationSteps.
+func (r *RedpandaReconciler) recordMigrationRerun(rp *v1alpha1.Redpanda, rerun map[string]bool) {
+	if len(rerun) == 0 {
+		return
+	}
+	steps := make([]string, 0, len(rerun))
+	for step := range rerun {
+		steps = append(steps, step)
+	}
+	sort.Strings(steps)
+	rp.Status.RerunMigrationSteps = steps
+	r.event(rp, v1alpha1.MigrationRerunReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("migration steps rerun: %s", strings.Join(steps, ", ")))
+}
+
+// migrationCompleted reports whether the migration of the given Redpanda
+// completed, see Status.Migration, and no step is pending in the
+// migration-rerun annotation. Status.RerunMigrationSteps is cleared once the
+// annotation is removed, as tryMigration would.
+func migrationCompleted(rp *v1alpha1.Redpanda) bool {
+	if rp.Status.Migration == nil || !rp.Status.Migration.Completed {
+		return false
+	}
+	value, ok := rp.Annotations[v1alpha1.GroupVersion.Group+migrationRerunPath]
+	if !ok {
+		rp.Status.RerunMigrationSteps = nil
+		return true
+	}
+	steps := parseMigrationSteps(value)
+	return len(steps) == 0 || slices.Equal(steps, rp.Status.RerunMigrationSteps)
+}
+
+// reconcileMigration runs the migration of the given Redpanda unless its
+// HelmRelease already existed, and records its completion once every step
+// ran. Steps blocked by safe mode keep the migration pending.
+func (r *RedpandaReconciler) reconcileMigration(ctx context.Context, log logr.Logger, rp *v1alpha1.Redpanda) {
+	conflict, err := r.checkMigrationConflict(ctx, rp)
+	if err != nil {
+		log.Error(err, "checking migration conflict")
+		return
+	}
+	if conflict {
+		return
+	}
+
+	err = r.tryMigration(ctx, log, rp)
+	switch {
+	case errors.Is(err, errDestructiveActionBlocked):
+		log.Info("migration pending", "reason", err.Error())
+	case err != nil:
+		log.Error(err, "migration")
+	default:
+		if err = r.completeMigration(ctx, rp); err != nil {
+			log.Error(err, "completing migration")
+		}
+	}
+}
+
+// completeMigration records the completion of the migration of the given
+// Redpanda, once every step of tryMigration succeeded, so that it is not run
+// on every reconcile. With DisableMigrationOnCompletion, Spec.Migration.Enabled
+// is also turned off.
+func (r *RedpandaReconciler) completeMigration(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	if rp.Status.Migration == nil || !rp.Status.Migration.Completed {
+		rp.Status.Migration = &v1alpha1.RedpandaMigrationStatus{
+			Completed:      true,
+			CompletionTime: ptr.To(metav1.Now()),
+		}
+		r.event(rp, v1alpha1.MigrationCompletedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("migration of Cluster %s and Console %s completed", migrationRefKey(rp, rp.Spec.Migration.ClusterRef), migrationRefKey(rp, rp.Spec.Migration.ConsoleRef)))
+	}
+	if !r.DisableMigrationOnCompletion || !rp.Spec.Migration.Enabled {
+		return nil
+	}
+
+	// patch a copy, the status computed so far must not be replaced with the
+	// stored one
+	obj := rp.DeepCopy()
+	patch := client.MergeFrom(obj.DeepCopy())
+	obj.Spec.Migration.Enabled = false
+	if err := r.Client.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("disabling migration: %w", err)
+	}
+	rp.Spec.Migration.Enabled = false
+	r.event(rp, v1alpha1.MigrationDisabledReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, "migration disabled after its completion")
+	return nil
+}
+
+// checkDualOwnership reports whether the given v1 Cluster is still reconciled
+// by the Cluster controller, either because disabling its reconciliation did
+// not go through or because an operation it started, like a rolling restart
+// or a decommission, is still in progress. The DualOwnership condition
+// reflects the result, while it is set neither the migration nor the
+// HelmRelease touch the resources of the Cluster.
+func (r *RedpandaReconciler) checkDualOwnership(log logr.Logger, rp *v1alpha1.Redpanda, cluster *vectorizedv1alpha1.Cluster) bool {
+	var activity []string
+	if isRedpandaClusterManaged(log, cluster) {
+		activity = append(activity, "its reconciliation is not disabled")
+	}
+	if cluster.Status.Restarting {
+		activity = append(activity, "brokers are restarting")
+	}
+	if cluster.Status.DeprecatedUpgrading {
+		activity = append(activity, "brokers are upgrading")
+	}
+	if cluster.Status.DecommissioningNode != nil {
+		activity = append(activity, fmt.Sprintf("broker %d is decommissioning", *cluster.Status.DecommissioningNode))
+	}
+
+	if len(activity) == 0 {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), DualOwnershipCondition)
+		return false
+	}
+
+	msg := fmt.Sprintf("Cluster '%s/%s' is still reconciled: %s; waiting before taking over its resources", cluster.Namespace, cluster.Name, strings.Join(activity, ", "))
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, DualOwnershipCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.ClusterStillReconcilingReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               DualOwnershipCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ClusterStillReconcilingReason,
+		Message:            msg,
+	})
+	return true
+}
+
+// migrationRefKey returns the key of the given migration reference, which
+// defaults to the namespace and name of the Redpanda.
+func migrationRefKey(rp *v1alpha1.Redpanda, ref vectorizedv1alpha1.NamespaceNameRef) types.NamespacedName {
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if key.Namespace == "" {
+		key.Namespace = rp.Namespace
+	}
+	if key.Name == "" {
+		key.Name = rp.Name
+	}
+	return key
+}
+
+// migrationSelfReference returns why the Cluster or Console referenced by
+// spec.migration is a resource generated for the Redpanda itself, empty if
+// neither is. Migrating from such a resource would disable and relabel what
+// the Redpanda deploys on every reconcile.
+func (r *RedpandaReconciler) migrationSelfReference(ctx context.Context, rp *v1alpha1.Redpanda) (string, error) {
+	refs := []struct {
+		field string
+		ref   vectorizedv1alpha1.NamespaceNameRef
+		obj   client.Object
+	}{
+		{field: "clusterRef", ref: rp.Spec.Migration.ClusterRef, obj: &vectorizedv1alpha1.Cluster{}},
+		{field: "consoleRef", ref: rp.Spec.Migration.ConsoleRef, obj: &vectorizedv1alpha1.Console{}},
+	}
+	for _, ref := range refs {
+		key := migrationRefKey(rp, ref.ref)
+		if err := r.Client.Get(ctx, key, ref.obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("get %s (%s): %w", ref.field, key, err)
+		}
+		if isOwnedBy(ref.obj.GetOwnerReferences(), rp.UID) || hasLabelsAndAnnotations(ref.obj, rp) {
+			return fmt.Sprintf("spec.migration.%s '%s' is a resource of the Redpanda itself, skipping migration; set it to the v1 resource to migrate from", ref.field, key), nil
+		}
+	}
+	return "", nil
+}
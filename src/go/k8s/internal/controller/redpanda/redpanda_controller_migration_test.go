@@ -0,0 +1,224 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+func TestParseMigrationSteps(t *testing.T) {
+	assert.Nil(t, parseMigrationSteps(""))
+	assert.Equal(t, []string{"pdb", "statefulset"}, parseMigrationSteps("StatefulSet, pdb,,statefulset"))
+}
+
+func TestPendingMigrationRerun(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation *string
+		recorded   []string
+		expected   map[string]bool
+		// recordedAfter is the expected Status.RerunMigrationSteps
+		recordedAfter []string
+		event         bool
+	}{
+		{name: "no annotation"},
+		{name: "annotation removed", recorded: []string{"pdb"}},
+		{
+			name:          "requested",
+			annotation:    ptr.To("statefulset,pdb"),
+			expected:      map[string]bool{"pdb": true, "statefulset": true},
+			recordedAfter: []string{"pdb", "statefulset"},
+		},
+		{
+			name:          "already rerun",
+			annotation:    ptr.To("pdb, statefulset"),
+			recorded:      []string{"pdb", "statefulset"},
+			recordedAfter: []string{"pdb", "statefulset"},
+		},
+		{
+			name:          "steps changed",
+			annotation:    ptr.To("pdb,service"),
+			recorded:      []string{"pdb", "statefulset"},
+			expected:      map[string]bool{"pdb": true, "service": true},
+			recordedAfter: []string{"pdb", "service"},
+		},
+		{
+			name:          "unknown step",
+			annotation:    ptr.To("sts"),
+			expected:      map[string]bool{"sts": true},
+			recordedAfter: []string{"sts"},
+			event:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, recorder := newTestRedpandaReconciler(t)
+			rp := testRedpanda()
+			if tt.annotation != nil {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + migrationRerunPath: *tt.annotation}
+			}
+			rp.Status.RerunMigrationSteps = tt.recorded
+
+			rerun := r.pendingMigrationRerun(rp)
+			assert.Equal(t, tt.expected, rerun)
+			if tt.event {
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, "unknown migration steps")
+			}
+
+			r.recordMigrationRerun(rp, rerun)
+			assert.Equal(t, tt.recordedAfter, rp.Status.RerunMigrationSteps)
+		})
+	}
+}
+
+func TestTryMigrationRerun(t *testing.T) {
+	rp := testMigratingRedpanda()
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Console: &v1alpha1.RedpandaConsole{Enabled: ptr.To(false)}}
+	rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + migrationRerunPath: "statefulset"}
+
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: rp.GetReleaseName(), Namespace: rp.Namespace}}
+	setHelmLabelsAndAnnotations(sts, rp)
+	r, _ := newTestRedpandaReconciler(t, sts)
+
+	// the StatefulSet is already adopted, only the rerun deletes it
+	_ = r.tryMigration(context.Background(), ctrl.Log, rp)
+	err := r.Client.Get(context.Background(), client.ObjectKeyFromObject(sts), &appsv1.StatefulSet{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Equal(t, []string{"statefulset"}, rp.Status.RerunMigrationSteps)
+
+	// rerun once per annotation
+	sts.ResourceVersion = ""
+	require.NoError(t, r.Client.Create(context.Background(), sts))
+	_ = r.tryMigration(context.Background(), ctrl.Log, rp)
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(sts), &appsv1.StatefulSet{}))
+}
+
+func TestMigrationCompleted(t *testing.T) {
+	tests := []struct {
+		name       string
+		completed  bool
+		annotation *string
+		recorded   []string
+		expected   bool
+	}{
+		{name: "not completed"},
+		{name: "completed", completed: true, recorded: []string{"pdb"}, expected: true},
+		{name: "rerun requested", completed: true, annotation: ptr.To("statefulset")},
+		{name: "rerun done", completed: true, annotation: ptr.To("statefulset"), recorded: []string{"statefulset"}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testMigratingRedpanda()
+			if tt.completed {
+				rp.Status.Migration = &v1alpha1.RedpandaMigrationStatus{Completed: true}
+			}
+			if tt.annotation != nil {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + migrationRerunPath: *tt.annotation}
+			}
+			rp.Status.RerunMigrationSteps = tt.recorded
+
+			assert.Equal(t, tt.expected, migrationCompleted(rp))
+			if tt.completed && tt.annotation == nil {
+				assert.Nil(t, rp.Status.RerunMigrationSteps)
+			}
+		})
+	}
+}
+
+func TestCompleteMigration(t *testing.T) {
+	for _, disable := range []bool{false, true} {
+		rp := testMigratingRedpanda()
+		r, recorder := newTestRedpandaReconciler(t, rp.DeepCopy())
+		r.DisableMigrationOnCompletion = disable
+
+		require.NoError(t, r.completeMigration(context.Background(), rp))
+		require.NotNil(t, rp.Status.Migration)
+		assert.True(t, rp.Status.Migration.Completed)
+		assert.NotNil(t, rp.Status.Migration.CompletionTime)
+		assert.Contains(t, <-recorder.Events, "migration of Cluster")
+
+		var stored v1alpha1.Redpanda
+		require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), &stored))
+		assert.Equal(t, !disable, stored.Spec.Migration.Enabled)
+		assert.Equal(t, !disable, rp.Spec.Migration.Enabled)
+
+		// the completion is only reported once
+		completion := rp.Status.Migration.CompletionTime
+		require.NoError(t, r.completeMigration(context.Background(), rp))
+		assert.Equal(t, completion, rp.Status.Migration.CompletionTime)
+		if disable {
+			assert.Contains(t, <-recorder.Events, "migration disabled")
+		}
+		assert.Empty(t, recorder.Events)
+	}
+}
+
+func TestCheckDualOwnership(t *testing.T) {
+	disabled := map[string]string{vectorizedv1alpha1.GroupVersion.Group + managedPath: NotManaged}
+
+	tests := []struct {
+		name    string
+		cluster vectorizedv1alpha1.Cluster
+		dual    bool
+	}{
+		{name: "disabled", cluster: vectorizedv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Annotations: disabled}}},
+		{name: "still managed", cluster: vectorizedv1alpha1.Cluster{}, dual: true},
+		{
+			name: "restarting",
+			cluster: vectorizedv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Annotations: disabled},
+				Status:     vectorizedv1alpha1.ClusterStatus{Restarting: true},
+			},
+			dual: true,
+		},
+		{
+			name: "decommissioning",
+			cluster: vectorizedv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Annotations: disabled},
+				Status:     vectorizedv1alpha1.ClusterStatus{DecommissioningNode: ptr.To(int32(2))},
+			},
+			dual: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, recorder := newTestRedpandaReconciler(t)
+			rp := testMigratingRedpanda()
+
+			assert.Equal(t, tt.dual, r.checkDualOwnership(ctrl.Log, rp, &tt.cluster))
+			assert.Equal(t, tt.dual, apimeta.IsStatusConditionTrue(rp.Status.Conditions, DualOwnershipCondition))
+			if !tt.dual {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+
+			// reported once
+			assert.True(t, r.checkDualOwnership(ctrl.Log, rp, &tt.cluster))
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// podMonitorGVK is the kind of the prometheus-operator PodMonitor. The
+// operator does not depend on the prometheus-operator API, PodMonitors are
+// handled as unstructured objects.
+var podMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+
+// newPodMonitor returns an empty unstructured PodMonitor.
+func newPodMonitor() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(podMonitorGVK)
+	return u
+}
+
+// isKindMissing reports whether the given error means the kind is unknown
+// to the API server, e.g. because the PodMonitor CRD is not installed, or to
+// the scheme.
+func isKindMissing(err error) bool {
+	return apimeta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)
+}
+
+// isAbsent reports whether the given error of a Get means the object does
+// not exist or cannot exist, as its kind is missing.
+func isAbsent(err error) bool {
+	return apierrors.IsNotFound(err) || isKindMissing(err)
+}
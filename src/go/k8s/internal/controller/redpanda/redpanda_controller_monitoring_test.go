@@ -0,0 +1,53 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIsAbsent(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		absent bool
+	}{
+		{name: "not found", err: apierrors.NewNotFound(schema.GroupResource{Group: "monitoring.coreos.com", Resource: "podmonitors"}, "redpanda"), absent: true},
+		{name: "crd not installed", err: &apimeta.NoKindMatchError{GroupKind: podMonitorGVK.GroupKind()}, absent: true},
+		{name: "forbidden", err: apierrors.NewForbidden(schema.GroupResource{Group: "monitoring.coreos.com", Resource: "podmonitors"}, "redpanda", errors.New("rbac"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.absent, isAbsent(tt.err))
+		})
+	}
+}
+
+func TestPodMonitorWithoutCRD(t *testing.T) {
+	r, recorder := newTestRedpandaReconciler(t)
+
+	// the test scheme does not know PodMonitors, as a cluster without the CRD
+	err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "redpanda"}, newPodMonitor())
+	require.Error(t, err)
+	assert.True(t, isAbsent(err))
+
+	require.NoError(t, r.repairHelmMetadata(context.Background(), testRedpanda()))
+	assert.Empty(t, recorder.Events)
+}
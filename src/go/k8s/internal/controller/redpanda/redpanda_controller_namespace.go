@@ -0,0 +1,66 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// isNamespaceTerminating reports whether the given namespace is being
+// deleted. A missing namespace is not reported as terminating, nor is one the
+// operator may not get, e.g. with the namespace scoped Role of the chart,
+// which cannot grant access to Namespaces.
+func isNamespaceTerminating(ctx context.Context, c client.Client, name string) (bool, error) {
+	var ns corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if apierrors.IsForbidden(err) {
+			Debugf(ctrl.LoggerFrom(ctx), "not allowed to get Namespace '%s', assuming it is not terminating: %s", name, err)
+			return false, nil
+		}
+		return false, fmt.Errorf("get Namespace '%s': %w", name, err)
+	}
+	return ns.Status.Phase == corev1.NamespaceTerminating || !ns.DeletionTimestamp.IsZero(), nil
+}
+
+// reconcileTerminatingNamespace short-circuits the reconcile of a Redpanda
+// whose namespace is being deleted. The resources of the release are removed
+// along with the namespace, so only the finalizer is dropped to not hold
+// the namespace deletion back. Events are not emitted, they can no longer be
+// created in a terminating namespace.
+func (r *RedpandaReconciler) reconcileTerminatingNamespace(ctx context.Context, rp *v1alpha1.Redpanda) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.reconcileTerminatingNamespace")
+	log.Info("namespace is terminating, skipping reconcile", "namespace", rp.Namespace)
+
+	if !controllerutil.ContainsFinalizer(rp, FinalizerKey) {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(rp.DeepCopy())
+	controllerutil.RemoveFinalizer(rp, FinalizerKey)
+	if err := r.Client.Patch(ctx, rp, patch); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestIsNamespaceTerminating(t *testing.T) {
+	tests := []struct {
+		name     string
+		ns       *corev1.Namespace
+		expected bool
+	}{
+		{name: "missing"},
+		{
+			name: "active",
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+			},
+		},
+		{
+			name: "terminating",
+			ns: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			if tt.ns != nil {
+				objs = append(objs, tt.ns)
+			}
+			r, _ := newTestRedpandaReconciler(t, objs...)
+
+			terminating, err := isNamespaceTerminating(context.Background(), r.Client, "default")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, terminating)
+		})
+	}
+}
+
+func TestIsNamespaceTerminatingForbidden(t *testing.T) {
+	r, _ := newTestRedpandaReconciler(t)
+	c := interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			return apierrors.NewForbidden(corev1.Resource("namespaces"), key.Name, errors.New("no RBAC rule"))
+		},
+	})
+
+	terminating, err := isNamespaceTerminating(context.Background(), c, "default")
+	require.NoError(t, err)
+	assert.False(t, terminating)
+}
+
+func TestReconcileTerminatingNamespace(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+	}
+	rp := testRedpanda()
+	rp.Finalizers = []string{FinalizerKey}
+	r, recorder := newTestRedpandaReconciler(t, ns, rp)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rp)})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Empty(t, recorder.Events)
+
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), rp))
+	assert.Empty(t, rp.Finalizers)
+
+	err = r.Client.Get(context.Background(), client.ObjectKeyFromObject(rp), &helmv2beta1.HelmRelease{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	states := r.states.list()
+	require.Len(t, states, 1)
+	assert.Equal(t, reconcileModeNamespaceTerminating, states[0].Mode)
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+const (
+	// defaultKafkaPort and defaultAdminPort are the chart defaults of
+	// listeners.kafka.port and listeners.admin.port.
+	defaultKafkaPort = 9093
+	defaultAdminPort = 9644
+
+	maxPort = 65535
+)
+
+// networkPolicyName returns the name of the NetworkPolicy managed for the
+// given Redpanda.
+func networkPolicyName(rp *v1alpha1.Redpanda) string {
+	return rp.GetReleaseName() + "-operator"
+}
+
+// listenerPort returns the port of the given listener in the values of the
+// HelmRelease, or the given default.
+func listenerPort(values map[string]interface{}, listener string, def int32) int32 {
+	// unstructured numbers are float64, see json.Unmarshal
+	port, ok, err := unstructured.NestedFloat64(values, "listeners", listener, "port")
+	if !ok || err != nil || port <= 0 || port > maxPort {
+		return def
+	}
+	return int32(port)
+}
+
+// validateNetworkPolicy checks the CIDRs of Spec.NetworkPolicy.
+func validateNetworkPolicy(rp *v1alpha1.Redpanda) error {
+	if rp.Spec.NetworkPolicy == nil {
+		return nil
+	}
+	for _, cidr := range rp.Spec.NetworkPolicy.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid allowedCIDRs entry %q: %w", cidr, err)
+		}
+	}
+	return nil
+}
+
+// networkPolicyFromTemplate returns the NetworkPolicy restricting the Kafka
+// and Admin API ports of the brokers to the namespace of the Redpanda and
+// the allowed namespaces and CIDRs. A NetworkPolicy denies any port it does
+// not allow, so a second rule keeps all other ports, like the RPC and
+// external listeners, open to everyone.
+func networkPolicyFromTemplate(rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) *networkingv1.NetworkPolicy {
+	values := hr.GetValues()
+	restricted := []int32{listenerPort(values, "kafka", defaultKafkaPort), listenerPort(values, "admin", defaultAdminPort)}
+	sort.Slice(restricted, func(i, j int) bool { return restricted[i] < restricted[j] })
+
+	tcp := corev1.ProtocolTCP
+	var restrictedPorts, openPorts []networkingv1.NetworkPolicyPort
+	next := int32(1)
+	for _, port := range restricted {
+		if port < next {
+			// both listeners on the same port
+			continue
+		}
+		restrictedPorts = append(restrictedPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: ptr.To(intstr.FromInt32(port))})
+		if port > next {
+			openPorts = append(openPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: ptr.To(intstr.FromInt32(next)), EndPort: ptr.To(port - 1)})
+		}
+		next = port + 1
+	}
+	if next <= maxPort {
+		openPorts = append(openPorts, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: ptr.To(intstr.FromInt32(next)), EndPort: ptr.To(int32(maxPort))})
+	}
+
+	peers := []networkingv1.NetworkPolicyPeer{
+		// Pods of the namespace of the Redpanda
+		{PodSelector: &metav1.LabelSelector{}},
+	}
+	if namespaces := rp.Spec.NetworkPolicy.AllowedNamespaces; len(namespaces) > 0 {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      corev1.LabelMetadataName,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   append([]string{}, namespaces...),
+				}},
+			},
+		})
+	}
+	for _, cidr := range rp.Spec.NetworkPolicy.AllowedCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            networkPolicyName(rp),
+			Namespace:       rp.Namespace,
+			Labels:          expectedInternalServiceSelector(rp),
+			OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: expectedInternalServiceSelector(rp)},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{Ports: restrictedPorts, From: peers},
+				{Ports: openPorts},
+			},
+		},
+	}
+}
+
+// reconcileNetworkPolicy creates the NetworkPolicy of Spec.NetworkPolicy and
+// reverts changes made to it. When Spec.NetworkPolicy is not set the
+// NetworkPolicy is deleted, if the operator created it.
+func (r *RedpandaReconciler) reconcileNetworkPolicy(ctx context.Context, rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) error {
+	var existing networkingv1.NetworkPolicy
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: networkPolicyName(rp)}
+	err := r.Client.Get(ctx, key, &existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("get network policy (%s): %w", key, err)
+	}
+	found := err == nil
+
+	if rp.Spec.NetworkPolicy == nil {
+		if !found || !isOwnedBy(existing.OwnerReferences, rp.UID) {
+			return nil
+		}
+		if err = r.Client.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete network policy (%s): %w", key, err)
+		}
+		r.event(rp, v1alpha1.NetworkPolicyDeletedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("NetworkPolicy '%s' deleted", key))
+		return nil
+	}
+
+	if err = validateNetworkPolicy(rp); err != nil {
+		return err
+	}
+
+	desired := networkPolicyFromTemplate(rp, hr)
+	if !found {
+		if err = r.Client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("create network policy (%s): %w", key, err)
+		}
+		r.event(rp, v1alpha1.NetworkPolicyCreatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("NetworkPolicy '%s' created", key))
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) && isOwnedBy(existing.OwnerReferences, rp.UID) {
+		return nil
+	}
+	Debugf(ctrl.LoggerFrom(ctx), "network policy (%s) drifted, updating it", key)
+	existing.Spec = desired.Spec
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	if err = r.Client.Update(ctx, &existing); err != nil {
+		return fmt.Errorf("update network policy (%s): %w", key, err)
+	}
+	r.event(rp, v1alpha1.NetworkPolicyUpdatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("NetworkPolicy '%s' updated", key))
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestNetworkPolicyFromTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     string
+		restricted []int32
+		// open are the port ranges left open to everyone
+		open [][2]int32
+	}{
+		{name: "chart defaults", restricted: []int32{9093, 9644}, open: [][2]int32{{1, 9092}, {9094, 9643}, {9645, 65535}}},
+		{name: "custom ports", values: `{"listeners":{"kafka":{"port":19092},"admin":{"port":9644}}}`, restricted: []int32{9644, 19092}, open: [][2]int32{{1, 9643}, {9645, 19091}, {19093, 65535}}},
+		{name: "same port", values: `{"listeners":{"kafka":{"port":9644}}}`, restricted: []int32{9644}, open: [][2]int32{{1, 9643}, {9645, 65535}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.NetworkPolicy = &v1alpha1.NetworkPolicy{
+				AllowedNamespaces: []string{"clients"},
+				AllowedCIDRs:      []string{"10.0.0.0/8"},
+			}
+			hr := &helmv2beta1.HelmRelease{}
+			if tt.values != "" {
+				hr.Spec.Values = &apiextensionsv1.JSON{Raw: []byte(tt.values)}
+			}
+
+			np := networkPolicyFromTemplate(rp, hr)
+			assert.Equal(t, expectedInternalServiceSelector(rp), np.Spec.PodSelector.MatchLabels)
+			require.Len(t, np.Spec.Ingress, 2)
+
+			var restricted []int32
+			for _, port := range np.Spec.Ingress[0].Ports {
+				restricted = append(restricted, port.Port.IntVal)
+				assert.Nil(t, port.EndPort)
+			}
+			assert.Equal(t, tt.restricted, restricted)
+			require.Len(t, np.Spec.Ingress[0].From, 3)
+			assert.Equal(t, []string{"clients"}, np.Spec.Ingress[0].From[1].NamespaceSelector.MatchExpressions[0].Values)
+			assert.Equal(t, "10.0.0.0/8", np.Spec.Ingress[0].From[2].IPBlock.CIDR)
+
+			var open [][2]int32
+			for _, port := range np.Spec.Ingress[1].Ports {
+				open = append(open, [2]int32{port.Port.IntVal, *port.EndPort})
+			}
+			assert.Equal(t, tt.open, open)
+			assert.Empty(t, np.Spec.Ingress[1].From)
+		})
+	}
+}
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	rp := testRedpanda()
+	rp.UID = "redpanda-uid"
+	hr := &helmv2beta1.HelmRelease{}
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: networkPolicyName(rp)}
+	ctx := context.Background()
+
+	// not configured: nothing is created
+	r, _ := newTestRedpandaReconciler(t)
+	require.NoError(t, r.reconcileNetworkPolicy(ctx, rp, hr))
+	var np networkingv1.NetworkPolicy
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(ctx, key, &np)))
+
+	rp.Spec.NetworkPolicy = &v1alpha1.NetworkPolicy{AllowedCIDRs: []string{"10.0.0.0/8"}}
+	require.NoError(t, r.reconcileNetworkPolicy(ctx, rp, hr))
+	require.NoError(t, r.Client.Get(ctx, key, &np))
+	assert.True(t, isOwnedBy(np.OwnerReferences, rp.UID))
+
+	// drift is reverted
+	np.Spec.Ingress[0].Ports[0].Port = ptr.To(intstr.FromInt32(1))
+	np.Spec.Ingress[0].From = nil
+	require.NoError(t, r.Client.Update(ctx, &np))
+	require.NoError(t, r.reconcileNetworkPolicy(ctx, rp, hr))
+	require.NoError(t, r.Client.Get(ctx, key, &np))
+	assert.Equal(t, networkPolicyFromTemplate(rp, hr).Spec, np.Spec)
+
+	rp.Spec.NetworkPolicy.AllowedCIDRs = []string{"not-a-cidr"}
+	assert.ErrorContains(t, r.reconcileNetworkPolicy(ctx, rp, hr), "not-a-cidr")
+
+	// unset: the policy is deleted
+	rp.Spec.NetworkPolicy = nil
+	require.NoError(t, r.reconcileNetworkPolicy(ctx, rp, hr))
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(ctx, key, &np)))
+
+	// a policy the operator does not own is left alone
+	foreign := &networkingv1.NetworkPolicy{}
+	foreign.Name, foreign.Namespace = key.Name, key.Namespace
+	r, _ = newTestRedpandaReconciler(t, foreign)
+	require.NoError(t, r.reconcileNetworkPolicy(ctx, rp, hr))
+	require.NoError(t, r.Client.Get(ctx, client.ObjectKeyFromObject(foreign), &np))
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"gopkg.in/yaml.v3"
+)
+
+var validJSON6902Ops = map[string]bool{
+	"add":     true,
+	"remove":  true,
+	"replace": true,
+	"move":    true,
+	"copy":    true,
+	"test":    true,
+}
+
+// validatePostRenderers checks the structure of the kustomize patches of the
+// given post renderers, so that malformed patches are reported by the
+// operator instead of failing later in the helm controller.
+func validatePostRenderers(postRenderers []helmv2beta1.PostRenderer) error {
+	var errs error
+	for i, pr := range postRenderers {
+		if pr.Kustomize == nil {
+			continue
+		}
+		k := pr.Kustomize
+
+		for j, p := range k.Patches {
+			if p.Patch == "" {
+				errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patches[%d]: patch is empty", i, j))
+				continue
+			}
+			var doc interface{}
+			if err := yaml.Unmarshal([]byte(p.Patch), &doc); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patches[%d]: invalid patch: %w", i, j, err))
+			}
+		}
+
+		for j, p := range k.PatchesStrategicMerge {
+			var obj map[string]interface{}
+			if err := json.Unmarshal(p.Raw, &obj); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patchesStrategicMerge[%d]: patch must be an object: %w", i, j, err))
+				continue
+			}
+			metadata, _ := obj["metadata"].(map[string]interface{})
+			if obj["apiVersion"] == nil || obj["kind"] == nil || metadata["name"] == nil {
+				errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patchesStrategicMerge[%d]: apiVersion, kind and metadata.name are required", i, j))
+			}
+		}
+
+		for j, p := range k.PatchesJSON6902 {
+			if p.Target.Kind == "" || p.Target.Name == "" {
+				errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patchesJson6902[%d]: target kind and name are required", i, j))
+			}
+			for o, op := range p.Patch {
+				if !validJSON6902Ops[op.Op] {
+					errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patchesJson6902[%d].patch[%d]: unsupported op %q", i, j, o, op.Op))
+				}
+				if op.Path == "" {
+					errs = errors.Join(errs, fmt.Errorf("postRenderers[%d].kustomize.patchesJson6902[%d].patch[%d]: path is required", i, j, o))
+				}
+			}
+		}
+	}
+	return errs
+}
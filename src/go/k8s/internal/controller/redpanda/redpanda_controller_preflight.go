@@ -0,0 +1,131 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+const chartArtifactTimeout = 30 * time.Second
+
+var chartArtifactClient = &http.Client{Timeout: chartArtifactTimeout}
+
+// preflightValues renders the chart of the given HelmRelease with the values
+// of hrTemplate, validating them against the chart schema, before the
+// HelmRelease is updated. A failure sets the ValuesInvalid condition and is
+// returned, so that the HelmRelease is left untouched instead of failing on
+// install. The chart is taken from the HelmChart artifact of the HelmRelease,
+// so nothing is checked until the HelmRelease fetched its chart once.
+func (r *RedpandaReconciler) preflightValues(ctx context.Context, rp *v1alpha1.Redpanda, hr, hrTemplate *helmv2beta1.HelmRelease) error {
+	log := ctrl.LoggerFrom(ctx).WithName("RedpandaReconciler.preflightValues")
+
+	c, err := r.loadHelmReleaseChart(ctx, hr)
+	if err != nil {
+		// the HelmRelease reports chart fetching failures on its own
+		Debugf(log, "skipping values pre-flight of HelmRelease '%s/%s': %s", hr.Namespace, hr.Name, err)
+		return nil
+	}
+
+	var raw []byte
+	if hrTemplate.Spec.Values != nil {
+		raw = hrTemplate.Spec.Values.Raw
+	}
+	if err = renderChart(c, raw, hr.GetReleaseName(), hr.GetReleaseNamespace()); err != nil {
+		msg := fmt.Sprintf("values rejected by chart %s-%s: %s", c.Name(), c.Metadata.Version, err)
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               ValuesInvalidCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.RenderFailedReason,
+			Message:            msg,
+		})
+		r.event(rp, v1alpha1.ValuesInvalidReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		v1alpha1.RedpandaNotReady(rp, v1alpha1.ValuesInvalidReason, msg)
+		return errors.New(msg)
+	}
+
+	apimeta.RemoveStatusCondition(rp.GetConditions(), ValuesInvalidCondition)
+	return nil
+}
+
+// loadHelmReleaseChart downloads and loads the chart artifact of the
+// HelmChart created for the given HelmRelease.
+func (r *RedpandaReconciler) loadHelmReleaseChart(ctx context.Context, hr *helmv2beta1.HelmRelease) (*chart.Chart, error) {
+	namespace, name := hr.Status.GetHelmChart()
+	if name == "" {
+		return nil, errors.New("no HelmChart created yet")
+	}
+
+	var hc sourcev1.HelmChart
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &hc); err != nil {
+		return nil, fmt.Errorf("get HelmChart '%s/%s': %w", namespace, name, err)
+	}
+	if hc.Status.Artifact == nil {
+		return nil, fmt.Errorf("HelmChart '%s/%s' has no artifact", namespace, name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hc.Status.Artifact.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := chartArtifactClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching chart artifact: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching chart artifact: %s", resp.Status)
+	}
+
+	return loader.LoadArchive(resp.Body)
+}
+
+// renderChart renders all templates of the chart with the given JSON values,
+// the way an install of the release would, including the validation of the
+// values against the chart JSON schema.
+func renderChart(c *chart.Chart, rawValues []byte, releaseName, namespace string) error {
+	values, err := chartutil.ReadValues(rawValues)
+	if err != nil {
+		return fmt.Errorf("reading values: %w", err)
+	}
+	if err = chartutil.ProcessDependencies(c, values); err != nil {
+		return fmt.Errorf("processing dependencies: %w", err)
+	}
+
+	renderValues, err := chartutil.ToRenderValues(c, values, chartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, chartutil.DefaultCapabilities)
+	if err != nil {
+		return err
+	}
+
+	_, err = engine.Render(c, renderValues)
+	return err
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func testChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "redpanda", Version: "5.7.1"},
+		Values:   map[string]interface{}{"statefulset": map[string]interface{}{"replicas": 3}},
+		Schema: []byte(`{
+  "$schema": "http://json-schema.org/schema#",
+  "type": "object",
+  "properties": {
+    "statefulset": {
+      "type": "object",
+      "properties": {"replicas": {"type": "integer"}}
+    }
+  }
+}`),
+		Templates: []*chart.File{{
+			Name: "templates/statefulset.yaml",
+			Data: []byte(`replicas: {{ required "statefulset.replicas is required" .Values.statefulset.replicas }}
+{{- if .Values.fail }}{{ fail "rendering failed" }}{{ end }}
+`),
+		}},
+	}
+}
+
+func TestRenderChart(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  string
+		wantErr string
+	}{
+		{name: "defaults"},
+		{name: "valid values", values: `{"statefulset":{"replicas":5}}`},
+		{name: "schema violation", values: `{"statefulset":{"replicas":"five"}}`, wantErr: "replicas"},
+		{name: "template failure", values: `{"fail":true}`, wantErr: "rendering failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := renderChart(testChart(), []byte(tt.values), "redpanda", "default")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// isConsoleEnabled reports whether the chart deploys Console, which it does
+// unless disabled in the values.
+func isConsoleEnabled(rp *v1alpha1.Redpanda) bool {
+	if rp.Spec.ClusterSpec == nil || rp.Spec.ClusterSpec.Console == nil {
+		return true
+	}
+	return ptr.Deref(rp.Spec.ClusterSpec.Console.Enabled, true)
+}
+
+// checkSubResourcesReady returns the reason and message of the first
+// sub-resource of the given Redpanda that is not ready, in the order the
+// HelmRepository, the HelmRelease, the rollout of the broker StatefulSet and
+// the Console Deployment if enabled. Both are empty once everything is
+// ready, so that the Ready condition summarizes them all.
+func (r *RedpandaReconciler) checkSubResourcesReady(ctx context.Context, rp *v1alpha1.Redpanda) (string, string, error) {
+	if !ptr.Deref(rp.Status.HelmRepositoryReady, false) {
+		return v1alpha1.ArtifactFailedReason, fmt.Sprintf(resourceNotReadyStrFmt, resourceTypeHelmRepository, rp.Namespace, rp.GetActiveHelmRepositoryName()), nil
+	}
+	if !ptr.Deref(rp.Status.HelmReleaseReady, false) {
+		return v1alpha1.ArtifactFailedReason, fmt.Sprintf(resourceNotReadyStrFmt, resourceTypeHelmRelease, rp.Namespace, rp.GetHelmReleaseName()), nil
+	}
+
+	var sts appsv1.StatefulSet
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: internalServiceName(rp)}
+	if err := r.Client.Get(ctx, key, &sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return v1alpha1.RolloutInProgressReason, fmt.Sprintf("StatefulSet '%s' does not exist yet", key), nil
+		}
+		return "", "", fmt.Errorf("get statefulset (%s): %w", key, err)
+	}
+	if msg := statefulSetRolloutStatus(&sts); msg != "" {
+		return v1alpha1.RolloutInProgressReason, fmt.Sprintf("StatefulSet '%s': %s", key, msg), nil
+	}
+
+	if !isConsoleEnabled(rp) {
+		return "", "", nil
+	}
+	var deployments appsv1.DeploymentList
+	if err := r.Client.List(ctx, &deployments, client.InNamespace(rp.Namespace), client.MatchingLabels{
+		K8sInstanceLabelKey: rp.GetReleaseName(),
+		K8sNameLabelKey:     consoleChartName(rp),
+	}); err != nil {
+		return "", "", fmt.Errorf("listing console deployments: %w", err)
+	}
+	if len(deployments.Items) == 0 {
+		return v1alpha1.ConsoleNotReadyReason, "Console Deployment does not exist yet", nil
+	}
+	for i := range deployments.Items {
+		if msg := deploymentRolloutStatus(&deployments.Items[i]); msg != "" {
+			return v1alpha1.ConsoleNotReadyReason, fmt.Sprintf("Deployment '%s/%s': %s", rp.Namespace, deployments.Items[i].Name, msg), nil
+		}
+	}
+	return "", "", nil
+}
+
+// statefulSetRolloutStatus returns why the rollout of the given StatefulSet
+// is not complete, empty once it is, like kubectl rollout status.
+func statefulSetRolloutStatus(sts *appsv1.StatefulSet) string {
+	replicas := ptr.Deref(sts.Spec.Replicas, 1)
+	switch {
+	case sts.Status.ObservedGeneration < sts.Generation:
+		return "waiting for the spec update to be observed"
+	case sts.Status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("%d of %d pods updated", sts.Status.UpdatedReplicas, replicas)
+	case sts.Status.ReadyReplicas < replicas:
+		return fmt.Sprintf("%d of %d pods ready", sts.Status.ReadyReplicas, replicas)
+	case sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision:
+		return fmt.Sprintf("waiting for revision %s to be rolled out", sts.Status.UpdateRevision)
+	}
+	return ""
+}
+
+// deploymentRolloutStatus returns why the rollout of the given Deployment is
+// not complete, empty once it is, like kubectl rollout status.
+func deploymentRolloutStatus(deploy *appsv1.Deployment) string {
+	replicas := ptr.Deref(deploy.Spec.Replicas, 1)
+	switch {
+	case deploy.Status.ObservedGeneration < deploy.Generation:
+		return "waiting for the spec update to be observed"
+	case deploy.Status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("%d of %d pods updated", deploy.Status.UpdatedReplicas, replicas)
+	case deploy.Status.Replicas > deploy.Status.UpdatedReplicas:
+		return fmt.Sprintf("%d old pods pending termination", deploy.Status.Replicas-deploy.Status.UpdatedReplicas)
+	case deploy.Status.AvailableReplicas < replicas:
+		return fmt.Sprintf("%d of %d pods available", deploy.Status.AvailableReplicas, replicas)
+	}
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse {
+			return cond.Message
+		}
+	}
+	return ""
+}
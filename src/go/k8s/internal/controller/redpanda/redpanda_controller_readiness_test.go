@@ -0,0 +1,102 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestCheckSubResourcesReady(t *testing.T) {
+	rolledOutSTS := func() *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(3))},
+			Status: appsv1.StatefulSetStatus{
+				UpdatedReplicas: 3,
+				ReadyReplicas:   3,
+				CurrentRevision: "redpanda-1",
+				UpdateRevision:  "redpanda-1",
+			},
+		}
+	}
+	availableConsole := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "redpanda-console",
+				Namespace: "default",
+				Labels:    map[string]string{K8sInstanceLabelKey: "redpanda", K8sNameLabelKey: "console"},
+			},
+			Spec:   appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+			Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		repoNotReady   bool
+		objs           func() []client.Object
+		consoleEnabled bool
+		reason         string
+	}{
+		{name: "HelmRepository not ready", repoNotReady: true, objs: func() []client.Object { return nil }, reason: v1alpha1.ArtifactFailedReason},
+		{name: "no StatefulSet", objs: func() []client.Object { return nil }, reason: v1alpha1.RolloutInProgressReason},
+		{
+			name: "rolling out",
+			objs: func() []client.Object {
+				sts := rolledOutSTS()
+				sts.Status.UpdateRevision = "redpanda-2"
+				return []client.Object{sts}
+			},
+			reason: v1alpha1.RolloutInProgressReason,
+		},
+		{name: "console disabled", objs: func() []client.Object { return []client.Object{rolledOutSTS()} }},
+		{name: "no console", consoleEnabled: true, objs: func() []client.Object { return []client.Object{rolledOutSTS()} }, reason: v1alpha1.ConsoleNotReadyReason},
+		{
+			name:           "console unavailable",
+			consoleEnabled: true,
+			objs: func() []client.Object {
+				deploy := availableConsole()
+				deploy.Status.AvailableReplicas = 0
+				return []client.Object{rolledOutSTS(), deploy}
+			},
+			reason: v1alpha1.ConsoleNotReadyReason,
+		},
+		{name: "ready", consoleEnabled: true, objs: func() []client.Object { return []client.Object{rolledOutSTS(), availableConsole()} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRedpandaReconciler(t, tt.objs()...)
+			rp := testRedpanda()
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Console: &v1alpha1.RedpandaConsole{Enabled: ptr.To(tt.consoleEnabled)}}
+			rp.Status.HelmRepositoryReady = ptr.To(!tt.repoNotReady)
+			rp.Status.HelmReleaseReady = ptr.To(true)
+
+			reason, msg, err := r.checkSubResourcesReady(context.Background(), rp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.reason, reason)
+			if tt.reason == "" {
+				assert.Empty(t, msg)
+			} else {
+				assert.NotEmpty(t, msg)
+			}
+		})
+	}
+}
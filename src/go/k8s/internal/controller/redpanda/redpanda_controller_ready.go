@@ -0,0 +1,35 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// recordTimeToReady records in the status and in the time to ready metric
+// the duration from the creation of the given Redpanda to its first readiness
+// transition. It must be called before the Redpanda is marked ready. Nothing
+// is recorded once ReadyDuration is set or when the Redpanda was already
+// ready, e.g. it became ready before the operator recorded the duration.
+func recordTimeToReady(rp *v1alpha1.Redpanda, now time.Time) {
+	if rp.Status.ReadyDuration != nil || apimeta.IsStatusConditionTrue(rp.Status.Conditions, meta.ReadyCondition) {
+		return
+	}
+
+	d := now.Sub(rp.CreationTimestamp.Time)
+	rp.Status.ReadyDuration = &metav1.Duration{Duration: d}
+	redpandaTimeToReady.Observe(d.Seconds())
+}
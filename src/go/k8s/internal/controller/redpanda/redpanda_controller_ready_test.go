@@ -0,0 +1,70 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestRecordTimeToReady(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(5 * time.Minute)
+
+	tests := []struct {
+		name     string
+		prepare  func(rp *v1alpha1.Redpanda)
+		expected *metav1.Duration
+	}{
+		{
+			name:     "first readiness",
+			prepare:  func(rp *v1alpha1.Redpanda) { v1alpha1.RedpandaNotReady(rp, v1alpha1.ArtifactFailedReason, "not ready") },
+			expected: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		{
+			name:     "no ready condition",
+			prepare:  func(*v1alpha1.Redpanda) {},
+			expected: &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		{
+			name: "already recorded",
+			prepare: func(rp *v1alpha1.Redpanda) {
+				rp.Status.ReadyDuration = &metav1.Duration{Duration: time.Minute}
+				v1alpha1.RedpandaNotReady(rp, v1alpha1.ArtifactFailedReason, "not ready")
+			},
+			expected: &metav1.Duration{Duration: time.Minute},
+		},
+		{
+			name:    "already ready",
+			prepare: func(rp *v1alpha1.Redpanda) { v1alpha1.RedpandaReady(rp) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.CreationTimestamp = metav1.NewTime(created)
+			tt.prepare(rp)
+
+			recordTimeToReady(rp, now)
+			assert.Equal(t, tt.expected, rp.Status.ReadyDuration)
+
+			// only the first readiness transition is recorded
+			v1alpha1.RedpandaReady(rp)
+			recordTimeToReady(rp, now.Add(time.Hour))
+			assert.Equal(t, tt.expected, rp.Status.ReadyDuration)
+		})
+	}
+}
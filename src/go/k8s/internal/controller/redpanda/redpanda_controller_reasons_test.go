@@ -0,0 +1,105 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestReconcileReadyReason(t *testing.T) {
+	readyRepo := func(rp *v1alpha1.Redpanda) *sourcev1.HelmRepository {
+		repo := helmRepositoryFromTemplate(rp, rp.GetHelmRepositoryName(), rp.Spec.ChartRef.GetRepositoryURL())
+		repo.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionTrue}}
+		return repo
+	}
+
+	tests := []struct {
+		name    string
+		prepare func(rp *v1alpha1.Redpanda) []client.Object
+		reason  string
+	}{
+		{
+			name: "invalid requeue interval",
+			prepare: func(rp *v1alpha1.Redpanda) []client.Object {
+				rp.Spec.ChartRef.RequeueInterval = &metav1.Duration{Duration: time.Millisecond}
+				return nil
+			},
+			reason: v1alpha1.InvalidRequeueIntervalReason,
+		},
+		{
+			name: "registry secret not found",
+			prepare: func(rp *v1alpha1.Redpanda) []client.Object {
+				rp.Spec.ChartRef.RegistrySecretRef = &meta.LocalObjectReference{Name: "registry"}
+				return nil
+			},
+			reason: v1alpha1.RegistrySecretNotFoundReason,
+		},
+		{
+			name: "existing HelmRepository not found",
+			prepare: func(rp *v1alpha1.Redpanda) []client.Object {
+				rp.Spec.ChartRef.ExistingRepositoryName = "charts"
+				return nil
+			},
+			reason: v1alpha1.HelmRepositoryNotFoundReason,
+		},
+		{
+			name:    "HelmRepository not ready",
+			prepare: func(rp *v1alpha1.Redpanda) []client.Object { return nil },
+			reason:  v1alpha1.ArtifactFailedReason,
+		},
+		{
+			name: "waiting for secret",
+			prepare: func(rp *v1alpha1.Redpanda) []client.Object {
+				rp.Spec.ChartRef.WaitForSecrets = []meta.LocalObjectReference{{Name: "values"}}
+				return []client.Object{readyRepo(rp)}
+			},
+			reason: v1alpha1.WaitingForSecretReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			r, _ := newTestRedpandaReconciler(t, tt.prepare(rp)...)
+
+			rp, _, _ = r.reconcile(context.Background(), rp)
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, meta.ReadyCondition)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Equal(t, tt.reason, cond.Reason)
+		})
+	}
+}
+
+func TestReconcileTeardownReason(t *testing.T) {
+	rp := testRedpanda()
+	r, _ := newTestRedpandaReconciler(t, rp)
+
+	_, err := r.reconcileTeardown(context.Background(), rp)
+	require.NoError(t, err)
+
+	for _, condType := range []string{meta.ReadyCondition, TeardownCondition} {
+		cond := apimeta.FindStatusCondition(rp.Status.Conditions, condType)
+		require.NotNil(t, cond, condType)
+		assert.Equal(t, v1alpha1.TeardownCompletedReason, cond.Reason, condType)
+	}
+}
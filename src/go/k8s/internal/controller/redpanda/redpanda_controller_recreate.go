@@ -0,0 +1,80 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// recreateHelmRelease deletes the HelmRelease of the given Redpanda when the
+// recreate-helmrelease annotation holds a token other than
+// Status.LastHandledRecreateToken, and reports whether it did. The token is
+// recorded so that the HelmRelease is recreated once per token: the next
+// reconciles wait for its deletion with the ReleaseUninstalling condition and
+// create it again. Deleting the HelmRelease uninstalls the release, which is
+// then installed again. While safe mode blocks the recreation the
+// RecreateBlocked condition is set, its event is emitted once per token.
+func (r *RedpandaReconciler) recreateHelmRelease(ctx context.Context, rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) (bool, error) {
+	token := rp.Annotations[v1alpha1.GroupVersion.Group+recreateHelmReleasePath]
+	if token == "" || token == rp.Status.LastHandledRecreateToken {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), RecreateBlockedCondition)
+		return false, nil
+	}
+	if rp.Status.HelmRelease == "" || !hr.DeletionTimestamp.IsZero() {
+		// nothing to recreate, the HelmRelease is about to be created
+		rp.Status.LastHandledRecreateToken = token
+		apimeta.RemoveStatusCondition(rp.GetConditions(), RecreateBlockedCondition)
+		return false, nil
+	}
+
+	action := fmt.Sprintf("recreate HelmRelease '%s/%s'", hr.Namespace, hr.Name)
+	if !destructiveActionAllowed(ctrl.LoggerFrom(ctx), r.SafeMode, rp, action) {
+		msg := fmt.Sprintf("%s, requested by the %s token %q", safeModeMessage(action), v1alpha1.GroupVersion.Group+recreateHelmReleasePath, token)
+		if cond := apimeta.FindStatusCondition(rp.Status.Conditions, RecreateBlockedCondition); cond == nil || cond.Message != msg {
+			r.event(rp, v1alpha1.DestructiveActionBlockedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msg)
+		}
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               RecreateBlockedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.DestructiveActionBlockedReason,
+			Message:            msg,
+		})
+		return false, nil
+	}
+	apimeta.RemoveStatusCondition(rp.GetConditions(), RecreateBlockedCondition)
+
+	foregroundDeletePropagation := metav1.DeletePropagationForeground
+	if err := r.Client.Delete(ctx, hr, &client.DeleteOptions{PropagationPolicy: &foregroundDeletePropagation}); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("deleting HelmRelease '%s/%s' to recreate it: %w", hr.Namespace, hr.Name, err)
+	}
+	rp.Status.LastHandledRecreateToken = token
+
+	msg := fmt.Sprintf("HelmRelease '%s/%s' deleted to be recreated for the %s token %q, the release is uninstalled and installed again", hr.Namespace, hr.Name, v1alpha1.GroupVersion.Group+recreateHelmReleasePath, token)
+	r.event(rp, v1alpha1.HelmReleaseRecreatedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ReleaseUninstallingCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.HelmReleaseDeletingReason,
+		Message:            msg,
+	})
+	return true, nil
+}
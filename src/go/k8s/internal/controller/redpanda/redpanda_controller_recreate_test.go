@@ -0,0 +1,100 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestRecreateHelmRelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		handled       string
+		noHelmRelease bool
+		safeMode      bool
+		expectDeleted bool
+		expectHandled string
+	}{
+		{name: "no token"},
+		{name: "token handled", token: "a", handled: "a", expectHandled: "a"},
+		{name: "new token", token: "b", handled: "a", expectDeleted: true, expectHandled: "b"},
+		{name: "first token", token: "a", expectDeleted: true, expectHandled: "a"},
+		{name: "no HelmRelease yet", token: "a", noHelmRelease: true, expectHandled: "a"},
+		{name: "safe mode", token: "a", safeMode: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			if tt.token != "" {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + recreateHelmReleasePath: tt.token}
+			}
+			rp.Status.LastHandledRecreateToken = tt.handled
+			hr := &helmv2beta1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: rp.GetHelmReleaseName(), Namespace: rp.Namespace}}
+			var objs []client.Object
+			if !tt.noHelmRelease {
+				rp.Status.HelmRelease = hr.Name
+				objs = append(objs, hr)
+			}
+			r, _ := newTestRedpandaReconciler(t, objs...)
+			r.SafeMode = tt.safeMode
+
+			deleted, err := r.recreateHelmRelease(context.Background(), rp, hr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDeleted, deleted)
+			assert.Equal(t, tt.expectHandled, rp.Status.LastHandledRecreateToken)
+			assert.Equal(t, tt.expectDeleted, apimeta.IsStatusConditionTrue(rp.Status.Conditions, ReleaseUninstallingCondition))
+			assert.Equal(t, tt.safeMode, apimeta.IsStatusConditionTrue(rp.Status.Conditions, RecreateBlockedCondition))
+
+			err = r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), &helmv2beta1.HelmRelease{})
+			assert.Equal(t, tt.expectDeleted || tt.noHelmRelease, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestRecreateHelmReleaseBlockedOncePerToken(t *testing.T) {
+	rp := testRedpanda()
+	rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + recreateHelmReleasePath: "a"}
+	hr := &helmv2beta1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: rp.GetHelmReleaseName(), Namespace: rp.Namespace}}
+	rp.Status.HelmRelease = hr.Name
+	r, recorder := newTestRedpandaReconciler(t, hr)
+	r.SafeMode = true
+
+	for i := 0; i < 3; i++ {
+		deleted, err := r.recreateHelmRelease(context.Background(), rp, hr)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+	}
+	assert.Len(t, recorder.Events, 1)
+
+	// a new token is reported again
+	rp.Annotations[v1alpha1.GroupVersion.Group+recreateHelmReleasePath] = "b"
+	_, err := r.recreateHelmRelease(context.Background(), rp, hr)
+	require.NoError(t, err)
+	assert.Len(t, recorder.Events, 2)
+
+	// allowing destructive actions recreates the HelmRelease
+	rp.Annotations[v1alpha1.GroupVersion.Group+allowDestructiveActionsPath] = "true"
+	deleted, err := r.recreateHelmRelease(context.Background(), rp, hr)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, RecreateBlockedCondition))
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// helmRepositoryType returns the HelmRepository type matching the given
+// chart repository URL.
+func helmRepositoryType(url string) string {
+	if strings.HasPrefix(url, "oci://") {
+		return sourcev1.HelmRepositoryTypeOCI
+	}
+	return sourcev1.HelmRepositoryTypeDefault
+}
+
+// validateRegistrySecret checks that the Secret referenced by
+// Spec.ChartRef.RegistrySecretRef exists in the namespace of the Redpanda.
+func (r *RedpandaReconciler) validateRegistrySecret(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	ref := rp.Spec.ChartRef.RegistrySecretRef
+	if ref == nil {
+		return nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: ref.Name}, &secret); err != nil {
+		return fmt.Errorf("registry secret '%s/%s': %w", rp.Namespace, ref.Name, err)
+	}
+	return nil
+}
+
+// caBundleKey is the key of the CA bundle in the Secret referenced by
+// Spec.ChartRef.CABundleSecretRef, as expected by the source controller.
+const caBundleKey = "ca.crt"
+
+// validateCABundleSecret checks that the Secret referenced by
+// Spec.ChartRef.CABundleSecretRef exists in the namespace of the Redpanda and
+// holds at least one PEM encoded certificate under the 'ca.crt' key.
+func (r *RedpandaReconciler) validateCABundleSecret(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	ref := rp.Spec.ChartRef.CABundleSecretRef
+	if ref == nil {
+		return nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: ref.Name}, &secret); err != nil {
+		return fmt.Errorf("CA bundle secret '%s/%s': %w", rp.Namespace, ref.Name, err)
+	}
+	if err := validateCABundle(secret.Data[caBundleKey]); err != nil {
+		return fmt.Errorf("CA bundle secret '%s/%s': key '%s': %w", rp.Namespace, ref.Name, caBundleKey, err)
+	}
+	return nil
+}
+
+// validateCABundle checks that the given PEM data holds certificates only,
+// and at least one.
+func validateCABundle(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("no CA bundle")
+	}
+
+	certs := 0
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+		certs++
+	}
+	if certs == 0 {
+		return errors.New("no PEM encoded certificate")
+	}
+	return nil
+}
+
+// helmRepositoryRequiresUpdate reports whether the repository settings
+// derived from the Redpanda differ from the existing HelmRepository.
+func helmRepositoryRequiresUpdate(repo, repoTemplate *sourcev1.HelmRepository) bool {
+	return repo.Spec.URL != repoTemplate.Spec.URL ||
+		normalizedRepositoryType(repo) != normalizedRepositoryType(repoTemplate) ||
+		!equalLocalObjectReference(repo.Spec.SecretRef, repoTemplate.Spec.SecretRef) ||
+		!equalLocalObjectReference(repo.Spec.CertSecretRef, repoTemplate.Spec.CertSecretRef)
+}
+
+func normalizedRepositoryType(repo *sourcev1.HelmRepository) string {
+	if repo.Spec.Type == "" {
+		return sourcev1.HelmRepositoryTypeDefault
+	}
+	return repo.Spec.Type
+}
+
+func equalLocalObjectReference(a, b *meta.LocalObjectReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name == b.Name
+}
+
+// helmRepositoryAuthFailureMessage returns a message explaining an
+// authentication failure reported by the source controller, or an empty
+// string if the HelmRepository did not fail to authenticate.
+func helmRepositoryAuthFailureMessage(rp *v1alpha1.Redpanda, repo *sourcev1.HelmRepository) string {
+	for _, condType := range []string{"Ready", "FetchFailed"} {
+		cond := apimeta.FindStatusCondition(repo.Status.Conditions, condType)
+		if cond == nil || cond.Reason != sourcev1.AuthenticationFailedReason {
+			continue
+		}
+		secret := "no registry secret"
+		if rp.Spec.ChartRef.RegistrySecretRef != nil {
+			secret = fmt.Sprintf("registry secret '%s/%s'", rp.Namespace, rp.Spec.ChartRef.RegistrySecretRef.Name)
+		}
+		return fmt.Sprintf("HelmRepository '%s/%s' failed to authenticate against %s using %s: %s", repo.Namespace, repo.Name, repo.Spec.URL, secret, cond.Message)
+	}
+	return ""
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestHelmRepositoryType(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{name: "default repository", url: "", expected: sourcev1.HelmRepositoryTypeDefault},
+		{name: "https repository", url: "https://charts.example.com/", expected: sourcev1.HelmRepositoryTypeDefault},
+		{name: "oci registry", url: "oci://registry.example.com/charts", expected: sourcev1.HelmRepositoryTypeOCI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &v1alpha1.Redpanda{}
+			rp.Spec.ChartRef.RepositoryURL = tt.url
+			assert.Equal(t, tt.expected, helmRepositoryType(rp.Spec.ChartRef.GetRepositoryURL()))
+		})
+	}
+}
+
+func TestHelmRepositoryRequiresUpdate(t *testing.T) {
+	repo := func(url, repoType, secret string) *sourcev1.HelmRepository {
+		r := &sourcev1.HelmRepository{}
+		r.Spec.URL = url
+		r.Spec.Type = repoType
+		if secret != "" {
+			r.Spec.SecretRef = &meta.LocalObjectReference{Name: secret}
+		}
+		return r
+	}
+	withCA := func(r *sourcev1.HelmRepository, secret string) *sourcev1.HelmRepository {
+		r.Spec.CertSecretRef = &meta.LocalObjectReference{Name: secret}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		existing *sourcev1.HelmRepository
+		template *sourcev1.HelmRepository
+		expected bool
+	}{
+		{
+			name:     "unchanged",
+			existing: repo("https://charts.redpanda.com/", "", ""),
+			template: repo("https://charts.redpanda.com/", sourcev1.HelmRepositoryTypeDefault, ""),
+		},
+		{
+			name:     "url changed",
+			existing: repo("https://charts.redpanda.com/", "", ""),
+			template: repo("https://charts.example.com/", "", ""),
+			expected: true,
+		},
+		{
+			name:     "type changed",
+			existing: repo("oci://registry.example.com/charts", "", ""),
+			template: repo("oci://registry.example.com/charts", sourcev1.HelmRepositoryTypeOCI, ""),
+			expected: true,
+		},
+		{
+			name:     "secret added",
+			existing: repo("https://charts.redpanda.com/", "", ""),
+			template: repo("https://charts.redpanda.com/", "", "registry"),
+			expected: true,
+		},
+		{
+			name:     "secret renamed",
+			existing: repo("https://charts.redpanda.com/", "", "registry"),
+			template: repo("https://charts.redpanda.com/", "", "other"),
+			expected: true,
+		},
+		{
+			name:     "same secret",
+			existing: repo("https://charts.redpanda.com/", "", "registry"),
+			template: repo("https://charts.redpanda.com/", "", "registry"),
+		},
+		{
+			name:     "CA bundle added",
+			existing: repo("https://charts.example.com/", "", ""),
+			template: withCA(repo("https://charts.example.com/", "", ""), "registry-ca"),
+			expected: true,
+		},
+		{
+			name:     "same CA bundle",
+			existing: withCA(repo("https://charts.example.com/", "", ""), "registry-ca"),
+			template: withCA(repo("https://charts.example.com/", "", ""), "registry-ca"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, helmRepositoryRequiresUpdate(tt.existing, tt.template))
+		})
+	}
+}
+
+func TestHelmRepositoryAuthFailureMessage(t *testing.T) {
+	withCondition := func(condType, reason string) *sourcev1.HelmRepository {
+		repo := &sourcev1.HelmRepository{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-repository", Namespace: "default"}}
+		repo.Spec.URL = "oci://registry.example.com/charts"
+		repo.Status.Conditions = []metav1.Condition{{Type: condType, Status: metav1.ConditionFalse, Reason: reason, Message: "401 Unauthorized"}}
+		return repo
+	}
+
+	tests := []struct {
+		name      string
+		repo      *sourcev1.HelmRepository
+		secretRef *meta.LocalObjectReference
+		expected  string
+	}{
+		{
+			name:     "not an authentication failure",
+			repo:     withCondition("Ready", "IndexationFailed"),
+			expected: "",
+		},
+		{
+			name:      "ready condition with secret",
+			repo:      withCondition("Ready", sourcev1.AuthenticationFailedReason),
+			secretRef: &meta.LocalObjectReference{Name: "registry"},
+			expected:  "HelmRepository 'default/redpanda-repository' failed to authenticate against oci://registry.example.com/charts using registry secret 'default/registry': 401 Unauthorized",
+		},
+		{
+			name:     "fetch failed condition without secret",
+			repo:     withCondition("FetchFailed", sourcev1.AuthenticationFailedReason),
+			expected: "HelmRepository 'default/redpanda-repository' failed to authenticate against oci://registry.example.com/charts using no registry secret: 401 Unauthorized",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+			rp.Spec.ChartRef.RegistrySecretRef = tt.secretRef
+			assert.Equal(t, tt.expected, helmRepositoryAuthFailureMessage(rp, tt.repo))
+		})
+	}
+}
+
+func TestReconcileExistingHelmRepository(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		rp := testRedpanda()
+		rp.Spec.ChartRef.ExistingRepositoryName = "shared"
+		r, recorder := newTestRedpandaReconciler(t, rp)
+
+		rp, _, err := r.reconcileHelmRepository(context.Background(), rp)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "default/shared")
+		assert.Len(t, recorder.Events, 1)
+		assert.False(t, apimeta.IsStatusConditionTrue(rp.Status.Conditions, meta.ReadyCondition))
+	})
+
+	t.Run("existing is not modified", func(t *testing.T) {
+		rp := testRedpanda()
+		rp.Spec.ChartRef.ExistingRepositoryName = "shared"
+		rp.Spec.ChartRef.RepositoryURL = "https://charts.example.com"
+		shared := &sourcev1.HelmRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+			Spec:       sourcev1.HelmRepositorySpec{URL: "https://charts.redpanda.com/"},
+		}
+		r, recorder := newTestRedpandaReconciler(t, rp, shared)
+
+		rp, repo, err := r.reconcileHelmRepository(context.Background(), rp)
+		require.NoError(t, err)
+		assert.Equal(t, "shared", repo.Name)
+		assert.Equal(t, "shared", rp.Status.HelmRepository)
+		assert.Empty(t, recorder.Events)
+
+		var got sourcev1.HelmRepository
+		require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(shared), &got))
+		assert.Equal(t, "https://charts.redpanda.com/", got.Spec.URL)
+		assert.Empty(t, got.OwnerReferences)
+
+		var repos sourcev1.HelmRepositoryList
+		require.NoError(t, r.Client.List(context.Background(), &repos))
+		assert.Len(t, repos.Items, 1)
+	})
+}
+
+func testCACertificate(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "registry-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestValidateCABundleSecret(t *testing.T) {
+	ca := testCACertificate(t)
+
+	tests := []struct {
+		name        string
+		data        map[string][]byte
+		noSecret    bool
+		expectError string
+	}{
+		{name: "valid", data: map[string][]byte{caBundleKey: ca}},
+		{name: "bundle", data: map[string][]byte{caBundleKey: append(append([]byte{}, ca...), ca...)}},
+		{name: "secret missing", noSecret: true, expectError: "not found"},
+		{name: "key missing", data: map[string][]byte{"tls.crt": ca}, expectError: "no CA bundle"},
+		{name: "not PEM", data: map[string][]byte{caBundleKey: []byte("not a certificate")}, expectError: "no PEM encoded certificate"},
+		{name: "private key", data: map[string][]byte{caBundleKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")})}, expectError: "unexpected PEM block"},
+		{name: "corrupt certificate", data: map[string][]byte{caBundleKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("cert")})}, expectError: "invalid certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			if !tt.noSecret {
+				objs = append(objs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry-ca", Namespace: "default"}, Data: tt.data})
+			}
+			r, _ := newTestRedpandaReconciler(t, objs...)
+			rp := testRedpanda()
+			rp.Spec.ChartRef.CABundleSecretRef = &meta.LocalObjectReference{Name: "registry-ca"}
+
+			err := r.validateCABundleSecret(context.Background(), rp)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
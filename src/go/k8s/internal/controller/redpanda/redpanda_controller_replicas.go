@@ -0,0 +1,235 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// chartDefaultReplicas is the broker count of the chart when
+// statefulset.replicas is not set.
+const chartDefaultReplicas = 3
+
+// desiredReplicas returns the broker count requested by the chart values.
+func desiredReplicas(rp *v1alpha1.Redpanda) int32 {
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.Statefulset != nil && rp.Spec.ClusterSpec.Statefulset.Replicas != nil {
+		return int32(*rp.Spec.ClusterSpec.Statefulset.Replicas)
+	}
+	return chartDefaultReplicas
+}
+
+// replicasExternallyManaged reports whether the broker count of the given
+// Redpanda is managed outside of the operator, e.g. by an autoscaler, with the
+// replicas-externally-managed annotation set to "true".
+func replicasExternallyManaged(rp *v1alpha1.Redpanda) bool {
+	return rp.Annotations[v1alpha1.GroupVersion.Group+replicasExternallyManagedPath] == "true"
+}
+
+// currentReplicas returns the replicas of the StatefulSet of the given
+// Redpanda, nil if it does not exist yet.
+func (r *RedpandaReconciler) currentReplicas(ctx context.Context, rp *v1alpha1.Redpanda) (*int32, error) {
+	var sts appsv1.StatefulSet
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: internalServiceName(rp)}
+	if err := r.Client.Get(ctx, key, &sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get statefulset (%s): %w", key, err)
+	}
+	return sts.Spec.Replicas, nil
+}
+
+// externalReplicasValues replaces statefulset.replicas in the given chart
+// values with the current replicas of the StatefulSet, so that an upgrade of
+// the release does not reassert the broker count of the Redpanda over the one
+// set by the external source. The value is omitted until the StatefulSet
+// exists, the chart default then applies to the first install.
+func (r *RedpandaReconciler) externalReplicasValues(ctx context.Context, rp *v1alpha1.Redpanda, values map[string]interface{}) error {
+	replicas, err := r.currentReplicas(ctx, rp)
+	if err != nil {
+		return err
+	}
+
+	statefulset, _ := values["statefulset"].(map[string]interface{})
+	if replicas == nil {
+		if statefulset != nil {
+			delete(statefulset, "replicas")
+		}
+		return nil
+	}
+	if statefulset == nil {
+		statefulset = map[string]interface{}{}
+		values["statefulset"] = statefulset
+	}
+	statefulset["replicas"] = *replicas
+	return nil
+}
+
+// reconcileReplicasExternallyManaged maintains the ReplicasExternallyManaged
+// condition of the given Redpanda.
+func (r *RedpandaReconciler) reconcileReplicasExternallyManaged(rp *v1alpha1.Redpanda) {
+	if !replicasExternallyManaged(rp) {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ReplicasExternallyManagedCondition)
+		return
+	}
+
+	msg := "the broker count is managed outside of the operator, statefulset.replicas of the Redpanda is ignored and the StatefulSet keeps its current replicas"
+	if apimeta.FindStatusCondition(rp.Status.Conditions, ReplicasExternallyManagedCondition) == nil {
+		r.event(rp, v1alpha1.ReplicasExternallyManagedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ReplicasExternallyManagedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ReplicasExternallyManagedReason,
+		Message:            msg,
+	})
+}
+
+// maxReplicas returns the largest broker count the given Redpanda may
+// request. The max-replicas annotation overrides MaxReplicas, zero disables
+// the limit.
+func (r *RedpandaReconciler) maxReplicas(rp *v1alpha1.Redpanda) (int32, error) {
+	key := v1alpha1.GroupVersion.Group + maxReplicasPath
+	value, ok := rp.Annotations[key]
+	if !ok {
+		return r.MaxReplicas, nil
+	}
+	limit, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q, expected a non-negative integer", key, value)
+	}
+	return int32(limit), nil
+}
+
+// checkReplicaLimit reports whether the broker count requested by the given
+// Redpanda is within its maximum. Otherwise the ReplicaLimitExceeded
+// condition is set and the Redpanda is marked not ready, the HelmRelease
+// must then be left untouched so that the scale-up is not applied. An
+// invalid max-replicas annotation is rejected the same way. Externally
+// managed replicas are not limited, the operator does not apply them.
+func (r *RedpandaReconciler) checkReplicaLimit(rp *v1alpha1.Redpanda) bool {
+	var msg string
+	limit, err := r.maxReplicas(rp)
+	switch {
+	case replicasExternallyManaged(rp):
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ReplicaLimitExceededCondition)
+		return true
+	case err != nil:
+		msg = err.Error()
+	case limit > 0 && desiredReplicas(rp) > limit:
+		msg = fmt.Sprintf("%d brokers requested, exceeding the maximum of %d; raise the limit with the %s annotation if the scale-up is intended", desiredReplicas(rp), limit, v1alpha1.GroupVersion.Group+maxReplicasPath)
+	default:
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ReplicaLimitExceededCondition)
+		return true
+	}
+
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReplicaLimitExceededCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.ReplicaLimitExceededReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ReplicaLimitExceededCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ReplicaLimitExceededReason,
+		Message:            msg,
+	})
+	v1alpha1.RedpandaNotReady(rp, v1alpha1.ReplicaLimitExceededReason, msg)
+	return false
+}
+
+func (r *RedpandaReconciler) replicaMismatchGracePeriod() time.Duration {
+	if r.ReplicaMismatchGracePeriod > 0 {
+		return r.ReplicaMismatchGracePeriod
+	}
+	return defaultReplicaMismatchGracePeriod
+}
+
+// reconcileReplicaDrift records the desired and observed replicas of the
+// given Redpanda and maintains the ReplicaMismatch condition. A HelmRelease
+// is reported ready as soon as the StatefulSet is updated, so a scale
+// operation stuck on pods that never become ready only shows up here. A
+// mismatch is first recorded with an Unknown status, whose transition time
+// starts the grace period, and turns True once the grace period is over. The
+// returned duration is the time left until then.
+func (r *RedpandaReconciler) reconcileReplicaDrift(ctx context.Context, rp *v1alpha1.Redpanda) (time.Duration, error) {
+	var sts appsv1.StatefulSet
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: internalServiceName(rp)}
+	if err := r.Client.Get(ctx, key, &sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			// not created by the chart yet
+			apimeta.RemoveStatusCondition(rp.GetConditions(), ReplicaMismatchCondition)
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get statefulset (%s): %w", key, err)
+	}
+
+	rp.Status.DesiredReplicas = desiredReplicas(rp)
+	if replicasExternallyManaged(rp) {
+		rp.Status.DesiredReplicas = ptr.Deref(sts.Spec.Replicas, 1)
+	}
+	rp.Status.ObservedReplicas = sts.Status.ReadyReplicas
+
+	if rp.Status.DesiredReplicas == rp.Status.ObservedReplicas {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ReplicaMismatchCondition)
+		return 0, nil
+	}
+
+	msg := fmt.Sprintf("%d of %d desired brokers are ready", rp.Status.ObservedReplicas, rp.Status.DesiredReplicas)
+	grace := r.replicaMismatchGracePeriod()
+
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReplicaMismatchCondition)
+	if cond == nil {
+		apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+			Type:               ReplicaMismatchCondition,
+			Status:             metav1.ConditionUnknown,
+			ObservedGeneration: rp.Generation,
+			Reason:             v1alpha1.GracePeriodReason,
+			Message:            msg,
+		})
+		return grace, nil
+	}
+
+	if cond.Status == metav1.ConditionTrue {
+		cond.Message = msg
+		cond.ObservedGeneration = rp.Generation
+		return 0, nil
+	}
+
+	if remaining := grace - time.Since(cond.LastTransitionTime.Time); remaining > 0 {
+		cond.Message = msg
+		cond.ObservedGeneration = rp.Generation
+		return remaining, nil
+	}
+
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ReplicaMismatchCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ReplicaMismatchReason,
+		Message:            msg,
+	})
+	r.reasonEvent(rp, v1alpha1.ReplicaMismatchReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError,
+		fmt.Sprintf("StatefulSet '%s' replicas mismatch for more than %s: %s", key, grace, msg))
+	return 0, nil
+}
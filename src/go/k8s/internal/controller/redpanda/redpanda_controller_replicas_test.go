@@ -0,0 +1,238 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestDesiredReplicas(t *testing.T) {
+	rp := testRedpanda()
+	assert.Equal(t, int32(3), desiredReplicas(rp))
+
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{
+		Statefulset: &v1alpha1.Statefulset{Replicas: ptr.To(5)},
+	}
+	assert.Equal(t, int32(5), desiredReplicas(rp))
+}
+
+func TestCheckReplicaLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int32
+		annotation string
+		replicas   *int
+		ok         bool
+		message    string
+	}{
+		{name: "no limit", replicas: ptr.To(100), ok: true},
+		{name: "within limit", limit: 5, replicas: ptr.To(5), ok: true},
+		{name: "chart default within limit", limit: 3, ok: true},
+		{name: "exceeds limit", limit: 5, replicas: ptr.To(50), message: "50 brokers requested, exceeding the maximum of 5"},
+		{name: "annotation raises limit", limit: 5, annotation: "50", replicas: ptr.To(50), ok: true},
+		{name: "annotation lowers limit", annotation: "3", replicas: ptr.To(4), message: "exceeding the maximum of 3"},
+		{name: "invalid annotation", annotation: "many", replicas: ptr.To(3), message: "invalid cluster.redpanda.com/max-replicas annotation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, recorder := newTestRedpandaReconciler(t)
+			r.MaxReplicas = tt.limit
+			rp := testRedpanda()
+			if tt.annotation != "" {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + maxReplicasPath: tt.annotation}
+			}
+			if tt.replicas != nil {
+				rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Statefulset: &v1alpha1.Statefulset{Replicas: tt.replicas}}
+			}
+
+			assert.Equal(t, tt.ok, r.checkReplicaLimit(rp))
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReplicaLimitExceededCondition)
+			if tt.ok {
+				assert.Nil(t, cond)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, v1alpha1.ReplicaLimitExceededReason, cond.Reason)
+			assert.Contains(t, cond.Message, tt.message)
+			ready := apimeta.FindStatusCondition(rp.Status.Conditions, meta.ReadyCondition)
+			require.NotNil(t, ready)
+			assert.Equal(t, v1alpha1.ReplicaLimitExceededReason, ready.Reason)
+			assert.Len(t, recorder.Events, 1)
+
+			// the event is not repeated while the message is unchanged
+			assert.False(t, r.checkReplicaLimit(rp))
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
+
+func TestReconcileReplicaDrift(t *testing.T) {
+	testStatefulSet := func(ready int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: ready},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		sts           *appsv1.StatefulSet
+		condition     *metav1.Condition
+		wantStatus    metav1.ConditionStatus
+		wantRequeue   bool
+		wantEvent     bool
+		wantObserved  int32
+		wantCondition bool
+	}{
+		{
+			name: "statefulset not created yet",
+		},
+		{
+			name:         "replicas match",
+			sts:          testStatefulSet(3),
+			condition:    &metav1.Condition{Type: ReplicaMismatchCondition, Status: metav1.ConditionTrue, Reason: "ReplicaMismatch"},
+			wantObserved: 3,
+		},
+		{
+			name:          "mismatch starts the grace period",
+			sts:           testStatefulSet(2),
+			wantStatus:    metav1.ConditionUnknown,
+			wantRequeue:   true,
+			wantObserved:  2,
+			wantCondition: true,
+		},
+		{
+			name: "mismatch within the grace period",
+			sts:  testStatefulSet(2),
+			condition: &metav1.Condition{
+				Type:               ReplicaMismatchCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "GracePeriod",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+			wantStatus:    metav1.ConditionUnknown,
+			wantRequeue:   true,
+			wantObserved:  2,
+			wantCondition: true,
+		},
+		{
+			name: "mismatch past the grace period",
+			sts:  testStatefulSet(2),
+			condition: &metav1.Condition{
+				Type:               ReplicaMismatchCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "GracePeriod",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			wantStatus:    metav1.ConditionTrue,
+			wantEvent:     true,
+			wantObserved:  2,
+			wantCondition: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			if tt.condition != nil {
+				rp.Status.Conditions = []metav1.Condition{*tt.condition}
+			}
+			r, recorder := newTestRedpandaReconciler(t, rp)
+			if tt.sts != nil {
+				require.NoError(t, r.Client.Create(context.Background(), tt.sts))
+			}
+
+			requeueAfter, err := r.reconcileReplicaDrift(context.Background(), rp)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantRequeue, requeueAfter > 0)
+			assert.Equal(t, tt.wantObserved, rp.Status.ObservedReplicas)
+			assert.Equal(t, tt.wantEvent, len(recorder.Events) > 0)
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReplicaMismatchCondition)
+			if !tt.wantCondition {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, "2 of 3 desired brokers are ready", cond.Message)
+		})
+	}
+}
+
+func TestReplicasExternallyManaged(t *testing.T) {
+	tests := []struct {
+		name     string
+		managed  bool
+		sts      *appsv1.StatefulSet
+		expected string
+	}{
+		{name: "managed by the operator", expected: `{"statefulset":{"replicas":5}}`},
+		{name: "no statefulset yet", managed: true, expected: `{"statefulset":{}}`},
+		{
+			name:     "current replicas",
+			managed:  true,
+			sts:      &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}, Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(7))}},
+			expected: `{"statefulset":{"replicas":7}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{
+				Statefulset: &v1alpha1.Statefulset{Replicas: ptr.To(5)},
+			}
+			if tt.managed {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + replicasExternallyManagedPath: "true"}
+			}
+			r, recorder := newTestRedpandaReconciler(t)
+			r.MaxReplicas = 3
+			if tt.sts != nil {
+				require.NoError(t, r.Client.Create(context.Background(), tt.sts))
+			}
+
+			values, err := r.buildValues(context.Background(), rp)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(values.Raw))
+
+			r.reconcileReplicasExternallyManaged(rp)
+			assert.Equal(t, tt.managed, r.checkReplicaLimit(rp))
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ReplicasExternallyManagedCondition)
+			if !tt.managed {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, v1alpha1.ReplicasExternallyManagedReason, cond.Reason)
+			assert.Len(t, recorder.Events, 1)
+
+			// the event is only emitted when the condition is set
+			r.reconcileReplicasExternallyManaged(rp)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
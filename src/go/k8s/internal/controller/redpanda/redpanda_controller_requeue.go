@@ -0,0 +1,37 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// validateRequeueInterval rejects ChartRef.RequeueInterval values below
+// minRequeueInterval, which would hammer the API server.
+func validateRequeueInterval(rp *v1alpha1.Redpanda) error {
+	d := rp.Spec.ChartRef.RequeueInterval
+	if d == nil || d.Duration >= minRequeueInterval {
+		return nil
+	}
+	return fmt.Errorf("%s is shorter than the minimum of %s", d.Duration, minRequeueInterval)
+}
+
+// requeueInterval returns the interval the given Redpanda is requeued at
+// while its dependencies are not ready, ChartRef.RequeueInterval when valid
+// or RequeueHelmDeps.
+func (r *RedpandaReconciler) requeueInterval(rp *v1alpha1.Redpanda) time.Duration {
+	if validateRequeueInterval(rp) == nil && rp.Spec.ChartRef.RequeueInterval != nil {
+		return rp.Spec.ChartRef.RequeueInterval.Duration
+	}
+	return r.RequeueHelmDeps
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequeueInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		interval    *metav1.Duration
+		expected    time.Duration
+		expectError string
+	}{
+		{name: "default", expected: 10 * time.Second},
+		{name: "override", interval: &metav1.Duration{Duration: time.Minute}, expected: time.Minute},
+		{name: "minimum", interval: &metav1.Duration{Duration: 5 * time.Second}, expected: 5 * time.Second},
+		{name: "too short", interval: &metav1.Duration{Duration: time.Second}, expected: 10 * time.Second, expectError: "1s is shorter than the minimum of 5s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRedpandaReconciler(t)
+			r.RequeueHelmDeps = 10 * time.Second
+			rp := testRedpanda()
+			rp.Spec.ChartRef.RequeueInterval = tt.interval
+
+			err := validateRequeueInterval(rp)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, r.requeueInterval(rp))
+		})
+	}
+}
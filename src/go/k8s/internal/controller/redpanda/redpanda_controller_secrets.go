@@ -0,0 +1,62 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// requiredSecrets returns the names of the Secrets the HelmRelease of the
+// given Redpanda depends on, without duplicates.
+func requiredSecrets(rp *v1alpha1.Redpanda) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	for _, ref := range rp.Spec.ChartRef.WaitForSecrets {
+		add(ref.Name)
+	}
+	for _, overlay := range rp.Spec.ChartRef.ValuesOverlays {
+		if overlay.Kind == valuesOverlayKindSecret {
+			add(overlay.Name)
+		}
+	}
+	return names
+}
+
+// missingSecrets returns the names of the required Secrets that do not exist
+// yet.
+func (r *RedpandaReconciler) missingSecrets(ctx context.Context, rp *v1alpha1.Redpanda) ([]string, error) {
+	var missing []string
+	for _, name := range requiredSecrets(rp) {
+		var secret corev1.Secret
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: name}, &secret)
+		switch {
+		case apierrors.IsNotFound(err):
+			missing = append(missing, name)
+		case err != nil:
+			return nil, fmt.Errorf("get secret (%s/%s): %w", rp.Namespace, name, err)
+		}
+	}
+	return missing, nil
+}
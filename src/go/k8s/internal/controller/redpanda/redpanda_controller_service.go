@@ -0,0 +1,72 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// internalServiceName returns the name of the internal Service created by
+// the chart for the given Redpanda.
+func internalServiceName(rp *v1alpha1.Redpanda) string {
+	if rp.Spec.ClusterSpec != nil && rp.Spec.ClusterSpec.FullNameOverride != "" {
+		return rp.Spec.ClusterSpec.FullNameOverride
+	}
+	return rp.GetReleaseName()
+}
+
+// expectedInternalServiceSelector returns the selector the internal Service
+// must have to route traffic to the Redpanda brokers.
+func expectedInternalServiceSelector(rp *v1alpha1.Redpanda) map[string]string {
+	return map[string]string{
+		K8sInstanceLabelKey: rp.GetReleaseName(),
+		K8sNameLabelKey:     redpandaChartName(rp),
+	}
+}
+
+// repairInternalServiceSelector restores the selector of the internal Service
+// when it drifted from the expected instance and name labels, which would
+// otherwise silently stop routing traffic to the brokers.
+func (r *RedpandaReconciler) repairInternalServiceSelector(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	var svc corev1.Service
+	key := types.NamespacedName{Namespace: rp.Namespace, Name: internalServiceName(rp)}
+	if err := r.Client.Get(ctx, key, &svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			// not created by the chart yet
+			return nil
+		}
+		return fmt.Errorf("get internal service (%s): %w", key, err)
+	}
+
+	expected := expectedInternalServiceSelector(rp)
+	if maps.Equal(svc.Spec.Selector, expected) {
+		return nil
+	}
+
+	patch := client.MergeFrom(svc.DeepCopy())
+	previous := svc.Spec.Selector
+	svc.Spec.Selector = expected
+	if err := r.Client.Patch(ctx, &svc, patch); err != nil {
+		return fmt.Errorf("repairing internal service (%s) selector: %w", key, err)
+	}
+
+	r.event(rp, v1alpha1.ServiceSelectorRepairedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo,
+		fmt.Sprintf("repaired selector of internal Service '%s' from %v to %v", key, previous, expected))
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// Modes a Redpanda was last reconciled in.
+const (
+	reconcileModeManaged              = "managed"
+	reconcileModeUnmanaged            = "unmanaged"
+	reconcileModeDeleting             = "deleting"
+	reconcileModeTeardown             = "teardown"
+	reconcileModeMigrating            = "migrating"
+	reconcileModeNamespaceTerminating = "namespaceTerminating"
+)
+
+// ReconcileState is the reconciler's in-memory view of a single Redpanda, as
+// of its last reconcile.
+type ReconcileState struct {
+	Namespace             string        `json:"namespace"`
+	Name                  string        `json:"name"`
+	Mode                  string        `json:"mode"`
+	ValuesSHA             string        `json:"valuesSHA,omitempty"`
+	LastAttemptedRevision string        `json:"lastAttemptedRevision,omitempty"`
+	LastAppliedRevision   string        `json:"lastAppliedRevision,omitempty"`
+	HelmRepositoryReady   *bool         `json:"helmRepositoryReady,omitempty"`
+	HelmReleaseReady      *bool         `json:"helmReleaseReady,omitempty"`
+	LastReconcileStart    time.Time     `json:"lastReconcileStart"`
+	LastReconcileDuration time.Duration `json:"lastReconcileDuration"`
+	Requeue               bool          `json:"requeue,omitempty"`
+	RequeueAfter          time.Duration `json:"requeueAfter,omitempty"`
+	NextReconcile         *time.Time    `json:"nextReconcile,omitempty"`
+	Error                 string        `json:"error,omitempty"`
+}
+
+// reconcileStates holds the ReconcileState of every reconciled Redpanda. The
+// zero value is ready to use.
+type reconcileStates struct {
+	mu     sync.RWMutex
+	states map[types.NamespacedName]ReconcileState
+}
+
+// update applies fn to the state stored for key, creating it if needed.
+func (s *reconcileStates) update(key types.NamespacedName, fn func(*ReconcileState)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.states == nil {
+		s.states = map[types.NamespacedName]ReconcileState{}
+	}
+	state, ok := s.states[key]
+	if !ok {
+		state = ReconcileState{Namespace: key.Namespace, Name: key.Name}
+	}
+	fn(&state)
+	s.states[key] = state
+}
+
+func (s *reconcileStates) delete(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, key)
+}
+
+// list returns a copy of all states sorted by namespace and name.
+func (s *reconcileStates) list() []ReconcileState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make([]ReconcileState, 0, len(s.states))
+	for _, state := range s.states {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Namespace != states[j].Namespace {
+			return states[i].Namespace < states[j].Namespace
+		}
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
+// ReconcileStateHandler serves the in-memory reconcile state of every
+// Redpanda as JSON. It does not query the API server, so it keeps answering
+// while reconciles are stuck.
+func (r *RedpandaReconciler) ReconcileStateHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		states := r.states.list()
+		if ns := req.URL.Query().Get("namespace"); ns != "" {
+			filtered := states[:0]
+			for _, state := range states {
+				if state.Namespace == ns {
+					filtered = append(filtered, state)
+				}
+			}
+			states = filtered
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(states); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// recordReconcileMode records a reconcile that did not go through the
+// regular install or upgrade path.
+func (r *RedpandaReconciler) recordReconcileMode(key types.NamespacedName, mode string, start time.Time) {
+	r.states.update(key, func(s *ReconcileState) {
+		s.Mode = mode
+		s.LastReconcileStart = start
+		s.LastReconcileDuration = 0
+		s.Requeue = false
+		s.RequeueAfter = 0
+		s.NextReconcile = nil
+		s.Error = ""
+	})
+}
+
+// recordReconcileState records the outcome of a regular reconcile.
+func (r *RedpandaReconciler) recordReconcileState(rp *v1alpha1.Redpanda, start time.Time, result ctrl.Result, err error) {
+	r.states.update(client.ObjectKeyFromObject(rp), func(s *ReconcileState) {
+		s.Mode = reconcileModeManaged
+		if rp.Spec.Migration != nil && rp.Spec.Migration.Enabled {
+			s.Mode = reconcileModeMigrating
+		}
+		s.LastAttemptedRevision = rp.Status.LastAttemptedRevision
+		s.LastAppliedRevision = rp.Status.LastAppliedRevision
+		s.HelmRepositoryReady = rp.Status.HelmRepositoryReady
+		s.HelmReleaseReady = rp.Status.HelmReleaseReady
+		s.LastReconcileStart = start
+		s.LastReconcileDuration = time.Since(start)
+		s.Requeue = result.Requeue
+		s.RequeueAfter = result.RequeueAfter
+		s.NextReconcile = nil
+		if result.RequeueAfter > 0 {
+			next := start.Add(s.LastReconcileDuration + result.RequeueAfter)
+			s.NextReconcile = &next
+		}
+		s.Error = ""
+		if err != nil {
+			s.Error = err.Error()
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"fmt"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// isResumeRequested reports whether the resume annotation is set to "true".
+func isResumeRequested(rp *v1alpha1.Redpanda) bool {
+	return rp.Annotations[v1alpha1.GroupVersion.Group+resumePath] == "true"
+}
+
+// applySuspendOnCreate keeps a HelmRelease created suspended because of
+// ChartRef.SuspendOnCreate suspended in the template until the resume
+// annotation is set. It reports whether the HelmRelease must be updated to
+// resume it.
+func (r *RedpandaReconciler) applySuspendOnCreate(rp *v1alpha1.Redpanda, hr, hrTemplate *helmv2beta1.HelmRelease) bool {
+	if !rp.Spec.ChartRef.SuspendOnCreate || !hr.Spec.Suspend {
+		return false
+	}
+
+	if !isResumeRequested(rp) {
+		hrTemplate.Spec.Suspend = true
+		return false
+	}
+
+	r.event(rp, v1alpha1.HelmReleaseResumedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("resuming HelmRelease '%s/%s'", hr.Namespace, hr.Name))
+	return true
+}
@@ -0,0 +1,1234 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cmapiv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+func newTestRedpandaReconciler(t *testing.T, objs ...client.Object) (*RedpandaReconciler, *record.FakeRecorder) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, helmv2beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
+	require.NoError(t, storagev1.AddToScheme(scheme))
+	require.NoError(t, cmapiv1.AddToScheme(scheme))
+	require.NoError(t, vectorizedv1alpha1.AddToScheme(scheme))
+
+	recorder := record.NewFakeRecorder(10)
+	return &RedpandaReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Scheme:        scheme,
+		EventRecorder: recorder,
+	}, recorder
+}
+
+func testRedpanda() *v1alpha1.Redpanda {
+	return &v1alpha1.Redpanda{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redpanda",
+			Namespace: "default",
+		},
+	}
+}
+
+func testMigratingRedpanda() *v1alpha1.Redpanda {
+	rp := testRedpanda()
+	rp.Spec.Migration = &v1alpha1.Migration{Enabled: true}
+	return rp
+}
+
+func TestCheckMigrationConflict(t *testing.T) {
+	t.Run("already migrated", func(t *testing.T) {
+		rp := testMigratingRedpanda()
+		hr := &helmv2beta1.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rp.GetHelmReleaseName(),
+				Namespace: rp.Namespace,
+			},
+		}
+		r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+		conflict, err := r.checkMigrationConflict(context.Background(), rp)
+		require.NoError(t, err)
+		assert.True(t, conflict)
+
+		cond := apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, v1alpha1.HelmReleaseExistsReason, cond.Reason)
+
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "Warning")
+	})
+
+	t.Run("created by this operator during migration", func(t *testing.T) {
+		rp := testMigratingRedpanda()
+		rp.UID = "redpanda-uid"
+		hr := &helmv2beta1.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            rp.GetHelmReleaseName(),
+				Namespace:       rp.Namespace,
+				OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
+			},
+		}
+		r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+		for i := 0; i < 2; i++ {
+			conflict, err := r.checkMigrationConflict(context.Background(), rp)
+			require.NoError(t, err)
+			assert.False(t, conflict)
+		}
+		assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("conflict is reported once", func(t *testing.T) {
+		rp := testMigratingRedpanda()
+		hr := &helmv2beta1.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rp.GetHelmReleaseName(),
+				Namespace: rp.Namespace,
+			},
+		}
+		r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+		for i := 0; i < 3; i++ {
+			conflict, err := r.checkMigrationConflict(context.Background(), rp)
+			require.NoError(t, err)
+			assert.True(t, conflict)
+		}
+		assert.Len(t, recorder.Events, 1)
+	})
+
+	t.Run("conflict survives a new generation", func(t *testing.T) {
+		rp := testMigratingRedpanda()
+		rp.UID = "redpanda-uid"
+		rp.Generation = 1
+		hr := &helmv2beta1.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rp.GetHelmReleaseName(),
+				Namespace: rp.Namespace,
+			},
+		}
+		r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+		conflict, err := r.checkMigrationConflict(context.Background(), rp)
+		require.NoError(t, err)
+		assert.True(t, conflict)
+
+		// the spec changes and the reconcile re-asserts the ownership of
+		// the HelmRelease
+		rp.Generation = 2
+		rp = observeGeneration(rp)
+		assert.Equal(t, int64(2), rp.Status.ObservedGeneration)
+		assert.Equal(t, v1alpha1.ProgressingReason, apimeta.FindStatusCondition(rp.Status.Conditions, meta.ReadyCondition).Reason)
+		require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), hr))
+		hr.OwnerReferences = []metav1.OwnerReference{rp.OwnerShipRefObj()}
+		require.NoError(t, r.Client.Update(context.Background(), hr))
+
+		conflict, err = r.checkMigrationConflict(context.Background(), rp)
+		require.NoError(t, err)
+		assert.True(t, conflict)
+		cond := apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition)
+		require.NotNil(t, cond)
+		assert.Equal(t, v1alpha1.HelmReleaseExistsReason, cond.Reason)
+		assert.Len(t, recorder.Events, 1)
+	})
+
+	t.Run("self reference", func(t *testing.T) {
+		rp := testMigratingRedpanda()
+		rp.UID = "redpanda-uid"
+		console := &vectorizedv1alpha1.Console{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            rp.Name,
+				Namespace:       rp.Namespace,
+				OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
+			},
+		}
+		r, recorder := newTestRedpandaReconciler(t, rp, console)
+
+		for i := 0; i < 2; i++ {
+			conflict, err := r.checkMigrationConflict(context.Background(), rp)
+			require.NoError(t, err)
+			assert.True(t, conflict)
+		}
+		cond := apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition)
+		require.NotNil(t, cond)
+		assert.Equal(t, v1alpha1.MigrationSelfReferenceReason, cond.Reason)
+		assert.Contains(t, cond.Message, "spec.migration.consoleRef 'default/redpanda'")
+		assert.Len(t, recorder.Events, 1)
+
+		// fixed reference
+		rp.Spec.Migration.ConsoleRef.Name = "console"
+		conflict, err := r.checkMigrationConflict(context.Background(), rp)
+		require.NoError(t, err)
+		assert.False(t, conflict)
+		assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition))
+	})
+
+	t.Run("not migrated yet", func(t *testing.T) {
+		rp := testMigratingRedpanda()
+		apimeta.SetStatusCondition(&rp.Status.Conditions, metav1.Condition{
+			Type:   MigrationConflictCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "HelmReleaseExists",
+		})
+		r, recorder := newTestRedpandaReconciler(t, rp)
+
+		conflict, err := r.checkMigrationConflict(context.Background(), rp)
+		require.NoError(t, err)
+		assert.False(t, conflict)
+		assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, MigrationConflictCondition))
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+func TestGateChartUpgrade(t *testing.T) {
+	hrWithVersion := func(version string) *helmv2beta1.HelmRelease {
+		hr := &helmv2beta1.HelmRelease{}
+		hr.Spec.Chart.Spec.Version = version
+		return hr
+	}
+
+	tests := []struct {
+		name            string
+		approval        string
+		approved        string
+		expectedVersion string
+		expectPending   bool
+	}{
+		{name: "automatic", approval: "", expectedVersion: "5.7.2"},
+		{name: "required and not approved", approval: approvalRequired, expectedVersion: "5.7.1", expectPending: true},
+		{name: "required and approved other version", approval: approvalRequired, approved: "5.7.3", expectedVersion: "5.7.1", expectPending: true},
+		{name: "required and approved", approval: approvalRequired, approved: "5.7.2", expectedVersion: "5.7.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &v1alpha1.Redpanda{}
+			rp.Spec.ChartRef.Approval = tt.approval
+			if tt.approved != "" {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + approveUpgradePath: tt.approved}
+			}
+			r, _ := newTestRedpandaReconciler(t)
+
+			hrTemplate := hrWithVersion("5.7.2")
+			r.gateChartUpgrade(rp, hrWithVersion("5.7.1"), hrTemplate)
+
+			assert.Equal(t, tt.expectedVersion, hrTemplate.Spec.Chart.Spec.Version)
+			assert.Equal(t, tt.expectPending, apimeta.IsStatusConditionTrue(rp.Status.Conditions, PendingApprovalCondition))
+		})
+	}
+}
+
+func TestBlockChartDowngrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         string
+		applied         string
+		desired         string
+		allowed         string
+		expectedVersion string
+		expectBlocked   bool
+	}{
+		{name: "upgrade", current: "5.7.1", desired: "5.7.2", expectedVersion: "5.7.2"},
+		{name: "same version", current: "5.7.1", desired: "5.7.1", expectedVersion: "5.7.1"},
+		{name: "downgrade", current: "5.7.2", desired: "5.7.1", expectedVersion: "5.7.2", expectBlocked: true},
+		{name: "downgrade below applied", current: "5.7.1", applied: "5.7.2", desired: "5.7.1", expectedVersion: "5.7.2", expectBlocked: true},
+		{name: "downgrade allowed", current: "5.7.2", desired: "5.7.1", allowed: "true", expectedVersion: "5.7.1"},
+		{name: "downgrade not allowed", current: "5.7.2", desired: "5.7.1", allowed: "false", expectedVersion: "5.7.2", expectBlocked: true},
+		{name: "range", current: "5.7.2", desired: "5.x", expectedVersion: "5.x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &v1alpha1.Redpanda{}
+			if tt.allowed != "" {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + allowDowngradePath: tt.allowed}
+			}
+			r, recorder := newTestRedpandaReconciler(t)
+
+			hr := &helmv2beta1.HelmRelease{}
+			hr.Spec.Chart.Spec.Version = tt.current
+			hr.Status.LastAppliedRevision = tt.applied
+			newTemplate := func() *helmv2beta1.HelmRelease {
+				hrTemplate := &helmv2beta1.HelmRelease{}
+				hrTemplate.Spec.Chart.Spec.Version = tt.desired
+				return hrTemplate
+			}
+			hrTemplate := newTemplate()
+			r.blockChartDowngrade(rp, hr, hrTemplate)
+
+			assert.Equal(t, tt.expectedVersion, hrTemplate.Spec.Chart.Spec.Version)
+			assert.Equal(t, tt.expectBlocked, apimeta.IsStatusConditionTrue(rp.Status.Conditions, DowngradeBlockedCondition))
+			if !tt.expectBlocked {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, "Warning")
+
+			// the event is not repeated while the downgrade stays blocked
+			r.blockChartDowngrade(rp, hr, newTemplate())
+			assert.True(t, apimeta.IsStatusConditionTrue(rp.Status.Conditions, DowngradeBlockedCondition))
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestReconcileHelmReleaseOwnership(t *testing.T) {
+	newObjects := func() (*v1alpha1.Redpanda, *helmv2beta1.HelmRelease) {
+		rp := &v1alpha1.Redpanda{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Redpanda",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "redpanda",
+				Namespace: "default",
+				UID:       "redpanda-uid",
+			},
+		}
+		hr := &helmv2beta1.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            rp.GetHelmReleaseName(),
+				Namespace:       rp.Namespace,
+				OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()},
+			},
+		}
+		return rp, hr
+	}
+
+	t.Run("owner reference intact", func(t *testing.T) {
+		rp, hr := newObjects()
+		r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+		require.NoError(t, r.reconcileHelmReleaseOwnership(context.Background(), rp, hr))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("owner reference removed is re-asserted", func(t *testing.T) {
+		rp, hr := newObjects()
+		r, _ := newTestRedpandaReconciler(t, rp, hr)
+
+		hr.OwnerReferences = []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}}
+		require.NoError(t, r.Client.Update(context.Background(), hr))
+
+		require.NoError(t, r.reconcileHelmReleaseOwnership(context.Background(), rp, hr))
+
+		var got helmv2beta1.HelmRelease
+		require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), &got))
+		assert.Len(t, got.OwnerReferences, 2)
+		assert.Equal(t, rp.UID, got.OwnerReferences[0].UID)
+		assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, OwnershipLostCondition))
+	})
+
+	t.Run("owner reference removed and re-assert disabled", func(t *testing.T) {
+		rp, hr := newObjects()
+		rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + reassertOwnershipPath: "false"}
+		r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+		hr.OwnerReferences = nil
+		require.NoError(t, r.Client.Update(context.Background(), hr))
+
+		require.NoError(t, r.reconcileHelmReleaseOwnership(context.Background(), rp, hr))
+
+		var got helmv2beta1.HelmRelease
+		require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), &got))
+		assert.Empty(t, got.OwnerReferences)
+		assert.True(t, apimeta.IsStatusConditionTrue(rp.Status.Conditions, OwnershipLostCondition))
+		assert.Len(t, recorder.Events, 1)
+	})
+}
+
+func TestSendStructuredEvent(t *testing.T) {
+	received := make(chan StructuredEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event StructuredEvent
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&event))
+		received <- event
+	}))
+	defer server.Close()
+
+	require.NoError(t, sendStructuredEvent(server.URL, &StructuredEvent{
+		Kind:      "Redpanda",
+		Namespace: "default",
+		Name:      "redpanda",
+		Reason:    v1alpha1.HelmReleaseCreatedReason,
+		Severity:  v1alpha1.EventSeverityInfo,
+		Message:   "HelmRelease 'default/redpanda' created",
+	}))
+
+	event := <-received
+	assert.Equal(t, "redpanda", event.Name)
+	assert.Equal(t, v1alpha1.HelmReleaseCreatedReason, event.Reason)
+	assert.Equal(t, v1alpha1.EventSeverityInfo, event.Severity)
+	assert.Equal(t, "HelmRelease 'default/redpanda' created", event.Message)
+}
+
+func TestPostStructuredEvent(t *testing.T) {
+	received := make(chan StructuredEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event StructuredEvent
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&event))
+		received <- event
+	}))
+	defer server.Close()
+
+	r, _ := newTestRedpandaReconciler(t)
+	r.StructuredEventsAddr = server.URL
+
+	r.event(testRedpanda(), v1alpha1.HelmReleaseCreatedReason, "1.0.0", v1alpha1.EventSeverityInfo, "message")
+
+	select {
+	case event := <-received:
+		assert.Equal(t, v1alpha1.HelmReleaseCreatedReason, event.Reason)
+		assert.Equal(t, "1.0.0", event.Revision)
+	case <-time.After(10 * time.Second):
+		t.Fatal("structured event not posted")
+	}
+}
+
+func TestRepairInternalServiceSelector(t *testing.T) {
+	rp := &v1alpha1.Redpanda{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redpanda",
+			Namespace: "default",
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redpanda",
+			Namespace: "default",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "something-else"},
+		},
+	}
+	r, recorder := newTestRedpandaReconciler(t, rp, svc)
+
+	require.NoError(t, r.repairInternalServiceSelector(context.Background(), rp))
+
+	var got corev1.Service
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(svc), &got))
+	assert.Equal(t, expectedInternalServiceSelector(rp), got.Spec.Selector)
+	assert.Len(t, recorder.Events, 1)
+
+	// already matching, nothing to do
+	require.NoError(t, r.repairInternalServiceSelector(context.Background(), rp))
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestRepairHelmMetadata(t *testing.T) {
+	rp := testRedpanda()
+	objectMeta := func(labels, annotations map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Labels: labels, Annotations: annotations}
+	}
+	helmAnnotations := map[string]string{"meta.helm.sh/release-name": "redpanda", "meta.helm.sh/release-namespace": "default"}
+
+	svc := &corev1.Service{ObjectMeta: objectMeta(map[string]string{"team": "streaming"}, nil)}
+	sa := &corev1.ServiceAccount{ObjectMeta: objectMeta(map[string]string{"app.kubernetes.io/managed-by": helm}, helmAnnotations)}
+	pdb := &policyv1.PodDisruptionBudget{ObjectMeta: objectMeta(nil, map[string]string{"meta.helm.sh/release-name": "other"})}
+	sts := &appsv1.StatefulSet{ObjectMeta: objectMeta(nil, map[string]string{"meta.helm.sh/release-name": "redpanda"})}
+	r, recorder := newTestRedpandaReconciler(t, svc, sa, pdb, sts)
+
+	require.NoError(t, r.repairHelmMetadata(context.Background(), rp))
+	// the Service and the StatefulSet are repaired, the ServiceAccount is
+	// already complete and the PodDisruptionBudget belongs to another release
+	assert.Len(t, recorder.Events, 2)
+
+	tests := []struct {
+		obj         client.Object
+		labels      map[string]string
+		annotations map[string]string
+	}{
+		{obj: &corev1.Service{}, labels: map[string]string{"team": "streaming", "app.kubernetes.io/managed-by": helm}, annotations: helmAnnotations},
+		{obj: &corev1.ServiceAccount{}, labels: map[string]string{"app.kubernetes.io/managed-by": helm}, annotations: helmAnnotations},
+		{obj: &policyv1.PodDisruptionBudget{}, annotations: map[string]string{"meta.helm.sh/release-name": "other"}},
+		{obj: &appsv1.StatefulSet{}, labels: map[string]string{"app.kubernetes.io/managed-by": helm}, annotations: helmAnnotations},
+	}
+	for _, tt := range tests {
+		require.NoError(t, r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "redpanda"}, tt.obj))
+		assert.Equal(t, tt.labels, tt.obj.GetLabels(), "%T", tt.obj)
+		assert.Equal(t, tt.annotations, tt.obj.GetAnnotations(), "%T", tt.obj)
+	}
+
+	// already repaired, nothing to do
+	require.NoError(t, r.repairHelmMetadata(context.Background(), rp))
+	assert.Len(t, recorder.Events, 2)
+}
+
+func TestValidatePostRenderers(t *testing.T) {
+	strategicMerge := func(raw string) []helmv2beta1.PostRenderer {
+		return []helmv2beta1.PostRenderer{{
+			Kustomize: &helmv2beta1.Kustomize{
+				PatchesStrategicMerge: []apiextensionsv1.JSON{{Raw: []byte(raw)}},
+			},
+		}}
+	}
+
+	assert.NoError(t, validatePostRenderers(nil))
+	assert.NoError(t, validatePostRenderers([]helmv2beta1.PostRenderer{{}}))
+	assert.NoError(t, validatePostRenderers(strategicMerge(`{"apiVersion":"apps/v1","kind":"StatefulSet","metadata":{"name":"redpanda"}}`)))
+	assert.Error(t, validatePostRenderers(strategicMerge(`{"kind":"StatefulSet"}`)))
+	assert.Error(t, validatePostRenderers(strategicMerge(`["not","an","object"]`)))
+}
+
+func TestChartLabels(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name          string
+		clusterSpec   *v1alpha1.RedpandaClusterSpec
+		wantName      string
+		wantComponent string
+		wantConsole   string
+	}{
+		{
+			name:          "defaults",
+			wantName:      "redpanda",
+			wantComponent: "redpanda-statefulset",
+			wantConsole:   "console",
+		},
+		{
+			name:          "name override",
+			clusterSpec:   &v1alpha1.RedpandaClusterSpec{NameOverride: "broker"},
+			wantName:      "broker",
+			wantComponent: "broker-statefulset",
+			wantConsole:   "console",
+		},
+		{
+			name:          "fullname override does not change labels",
+			clusterSpec:   &v1alpha1.RedpandaClusterSpec{FullNameOverride: "my-cluster"},
+			wantName:      "redpanda",
+			wantComponent: "redpanda-statefulset",
+			wantConsole:   "console",
+		},
+		{
+			name: "long name override is truncated",
+			clusterSpec: &v1alpha1.RedpandaClusterSpec{
+				NameOverride: "a-very-long-name-override-that-exceeds-the-component-label-limit",
+			},
+			wantName:      "a-very-long-name-override-that-exceeds-the-component-label-limi",
+			wantComponent: "a-very-long-name-override-that-exceeds-the-componen-statefulset",
+			wantConsole:   "console",
+		},
+		{
+			name: "console name override",
+			clusterSpec: &v1alpha1.RedpandaClusterSpec{
+				Console: &v1alpha1.RedpandaConsole{NameOverride: ptr("ui")},
+			},
+			wantName:      "redpanda",
+			wantComponent: "redpanda-statefulset",
+			wantConsole:   "ui",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &v1alpha1.Redpanda{
+				ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+				Spec:       v1alpha1.RedpandaSpec{ClusterSpec: tt.clusterSpec},
+			}
+			assert.Equal(t, tt.wantName, redpandaChartName(rp))
+			assert.Equal(t, tt.wantComponent, redpandaComponentLabel(rp))
+			assert.Equal(t, tt.wantConsole, consoleChartName(rp))
+			assert.Equal(t, tt.wantName, expectedInternalServiceSelector(rp)[K8sNameLabelKey])
+		})
+	}
+}
+
+func TestRedpandaChangedPredicate(t *testing.T) {
+	old := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Generation: 1}}
+
+	tests := []struct {
+		name     string
+		update   func(rp *v1alpha1.Redpanda)
+		expected bool
+	}{
+		{
+			name: "status only",
+			update: func(rp *v1alpha1.Redpanda) {
+				rp.Status.LastReconcileTime = ptr.To(metav1.Now())
+			},
+		},
+		{
+			name:     "spec change",
+			update:   func(rp *v1alpha1.Redpanda) { rp.Generation++ },
+			expected: true,
+		},
+		{
+			name: "annotation change",
+			update: func(rp *v1alpha1.Redpanda) {
+				rp.Annotations = map[string]string{"cluster.redpanda.com/resume": "true"}
+			},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := old.DeepCopy()
+			tt.update(updated)
+			assert.Equal(t, tt.expected, redpandaChangedPredicate.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}))
+		})
+	}
+}
+
+func TestValidateDependsOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		noCrossNS   bool
+		dependsOn   []meta.NamespacedObjectReference
+		expectError bool
+	}{
+		{name: "empty"},
+		{name: "same namespace", noCrossNS: true, dependsOn: []meta.NamespacedObjectReference{{Name: "cert-manager"}, {Name: "storage", Namespace: "default"}}},
+		{name: "cross namespace allowed", dependsOn: []meta.NamespacedObjectReference{{Name: "cert-manager", Namespace: "cert-manager"}}},
+		{name: "cross namespace rejected", noCrossNS: true, dependsOn: []meta.NamespacedObjectReference{{Name: "cert-manager", Namespace: "cert-manager"}}, expectError: true},
+		{name: "missing name", dependsOn: []meta.NamespacedObjectReference{{Namespace: "default"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedpandaReconciler{NoCrossNamespaceRef: tt.noCrossNS}
+			rp := testRedpanda()
+			rp.Spec.ChartRef.DependsOn = tt.dependsOn
+
+			err := r.validateDependsOn(rp)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMissingSecrets(t *testing.T) {
+	rp := testRedpanda()
+	rp.Spec.ChartRef.WaitForSecrets = []meta.LocalObjectReference{{Name: "external"}, {Name: "present"}}
+	rp.Spec.ChartRef.ValuesOverlays = []v1alpha1.ValuesOverlay{
+		{Kind: "Secret", Name: "external"},
+		{Kind: "Secret", Name: "overlay"},
+		{Kind: "ConfigMap", Name: "config"},
+	}
+	present := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "present", Namespace: "default"}}
+	r, _ := newTestRedpandaReconciler(t, rp, present)
+
+	missing, err := r.missingSecrets(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"external", "overlay"}, missing)
+}
+
+func TestReconcileStateHandler(t *testing.T) {
+	r := &RedpandaReconciler{}
+	rp := testRedpanda()
+	rp.Status.LastAttemptedRevision = "5.7.1"
+
+	r.recordReconcileState(rp, time.Now(), ctrl.Result{RequeueAfter: time.Minute}, nil)
+	r.recordReconcileMode(types.NamespacedName{Namespace: "other", Name: "redpanda"}, reconcileModeUnmanaged, time.Now())
+
+	rec := httptest.NewRecorder()
+	r.ReconcileStateHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/redpanda/state?namespace=default", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var states []ReconcileState
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&states))
+	require.Len(t, states, 1)
+	assert.Equal(t, reconcileModeManaged, states[0].Mode)
+	assert.Equal(t, "5.7.1", states[0].LastAttemptedRevision)
+	assert.Equal(t, time.Minute, states[0].RequeueAfter)
+	assert.NotNil(t, states[0].NextReconcile)
+
+	r.states.delete(client.ObjectKeyFromObject(rp))
+	assert.Len(t, r.states.list(), 1)
+}
+
+func TestApplySuspendOnCreate(t *testing.T) {
+	tests := []struct {
+		name            string
+		suspendOnCreate bool
+		suspended       bool
+		resume          bool
+		expectResume    bool
+		expectSuspended bool
+	}{
+		{name: "disabled"},
+		{name: "disabled keeps template", suspended: true},
+		{name: "not suspended", suspendOnCreate: true},
+		{name: "waits for resume", suspendOnCreate: true, suspended: true, expectSuspended: true},
+		{name: "resumes", suspendOnCreate: true, suspended: true, resume: true, expectResume: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ChartRef.SuspendOnCreate = tt.suspendOnCreate
+			if tt.resume {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + resumePath: "true"}
+			}
+			hr := &helmv2beta1.HelmRelease{Spec: helmv2beta1.HelmReleaseSpec{Suspend: tt.suspended}}
+			hrTemplate := &helmv2beta1.HelmRelease{}
+			r, _ := newTestRedpandaReconciler(t)
+
+			assert.Equal(t, tt.expectResume, r.applySuspendOnCreate(rp, hr, hrTemplate))
+			assert.Equal(t, tt.expectSuspended, hrTemplate.Spec.Suspend)
+		})
+	}
+}
+
+func TestIsEventSuppressed(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		annotation *string
+		reason     string
+		now        time.Time
+		want       bool
+	}{
+		{name: "no annotation", reason: "ArtifactFailed", now: created},
+		{name: "within window", annotation: ptr.To("ArtifactFailed=10m"), reason: "ArtifactFailed", now: created.Add(5 * time.Minute), want: true},
+		{name: "after window", annotation: ptr.To("ArtifactFailed=10m"), reason: "ArtifactFailed", now: created.Add(11 * time.Minute)},
+		{name: "other reason", annotation: ptr.To("ArtifactFailed=10m"), reason: "ReconcileTimeout", now: created},
+		{name: "multiple reasons", annotation: ptr.To("ReconcileTimeout=1h, ArtifactFailed=10m"), reason: "ArtifactFailed", now: created, want: true},
+		{name: "malformed duration", annotation: ptr.To("ArtifactFailed=soon"), reason: "ArtifactFailed", now: created},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.CreationTimestamp = metav1.NewTime(created)
+			if tt.annotation != nil {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + suppressEventsPath: *tt.annotation}
+			}
+			assert.Equal(t, tt.want, isEventSuppressed(rp, tt.reason, tt.now))
+		})
+	}
+}
+
+func TestReasonEvent(t *testing.T) {
+	rp := testRedpanda()
+	rp.CreationTimestamp = metav1.Now()
+	rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + suppressEventsPath: "ArtifactFailed=10m"}
+	r, recorder := newTestRedpandaReconciler(t, rp)
+
+	r.reasonEvent(rp, "ArtifactFailed", "", v1alpha1.EventSeverityInfo, "HelmRelease 'default/redpanda' is not ready")
+	assert.Empty(t, recorder.Events)
+
+	r.reasonEvent(rp, "ReconcileTimeout", "", v1alpha1.EventSeverityError, "reconcile timed out after 1m0s")
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestEventPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies map[string]string
+		reason   string
+		severity string
+		expected string
+	}{
+		{name: "default info", reason: v1alpha1.HelmReleaseCreatedReason, severity: v1alpha1.EventSeverityInfo, expected: "Normal HelmReleaseCreated"},
+		{name: "default error", reason: v1alpha1.HelmReleaseFailedReason, severity: v1alpha1.EventSeverityError, expected: "Warning HelmReleaseFailed"},
+		{name: "default reason", reason: "ArtifactFailed", severity: v1alpha1.EventSeverityInfo, expected: "Normal ArtifactFailed"},
+		{name: "reason dropped", policies: map[string]string{"ArtifactFailed": EventPolicyDrop}, reason: "ArtifactFailed", severity: v1alpha1.EventSeverityInfo},
+		{name: "reason remapped", policies: map[string]string{"ReplicaMismatch": EventPolicyNormal}, reason: "ReplicaMismatch", severity: v1alpha1.EventSeverityError, expected: "Normal ReplicaMismatch"},
+		{name: "severity remapped", policies: map[string]string{v1alpha1.EventSeverityInfo: EventPolicyWarning}, reason: "ArtifactFailed", severity: v1alpha1.EventSeverityInfo, expected: "Warning ArtifactFailed"},
+		{
+			name:     "reason wins over severity",
+			policies: map[string]string{v1alpha1.EventSeverityInfo: EventPolicyDrop, "SourceFailover": EventPolicyNormal},
+			reason:   "SourceFailover",
+			severity: v1alpha1.EventSeverityInfo,
+			expected: "Normal SourceFailover",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			r, recorder := newTestRedpandaReconciler(t)
+			r.EventPolicies = tt.policies
+
+			r.reasonEvent(rp, tt.reason, "", tt.severity, "message")
+			if tt.expected == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.expected+" message", <-recorder.Events)
+		})
+	}
+
+	t.Run("severity policies apply to reasons without a policy", func(t *testing.T) {
+		r, recorder := newTestRedpandaReconciler(t)
+		r.EventPolicies = map[string]string{v1alpha1.EventSeverityInfo: EventPolicyDrop}
+
+		r.event(testRedpanda(), v1alpha1.HelmReleaseCreatedReason, "", v1alpha1.EventSeverityInfo, "message")
+		assert.Empty(t, recorder.Events)
+		r.event(testRedpanda(), v1alpha1.HelmReleaseFailedReason, "", v1alpha1.EventSeverityError, "message")
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning HelmReleaseFailed message", <-recorder.Events)
+	})
+}
+
+func TestParseEventPolicies(t *testing.T) {
+	policies, err := ParseEventPolicies(map[string]string{"ArtifactFailed": "Drop", "info": "Warning"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ArtifactFailed": EventPolicyDrop, "info": EventPolicyWarning}, policies)
+
+	_, err = ParseEventPolicies(map[string]string{"ArtifactFailed": "drop"})
+	assert.ErrorContains(t, err, `invalid event policy "drop" for reason "ArtifactFailed"`)
+
+	_, err = ParseEventPolicies(map[string]string{"": "Drop"})
+	assert.ErrorContains(t, err, "empty reason")
+}
+
+func TestPatchRedpandaStatus(t *testing.T) {
+	reconciled := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := testRedpanda()
+	stored.Status = v1alpha1.RedpandaStatus{
+		ObservedGeneration: 1,
+		HelmRelease:        "redpanda",
+		HelmReleaseReady:   ptr.To(true),
+		Conditions: []metav1.Condition{{
+			Type:               meta.ReadyCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "RedpandaClusterDeployed",
+			Message:            "Redpanda reconciliation succeeded",
+			LastTransitionTime: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}},
+		LastReconcileTime: ptr.To(metav1.NewTime(reconciled)),
+	}
+	stored.Status.Summary = statusSummary(stored)
+
+	tests := []struct {
+		name       string
+		refresh    time.Duration
+		mutate     func(rp *v1alpha1.Redpanda)
+		wantWrites int
+	}{
+		{
+			name: "steady state no-op reconcile",
+			mutate: func(rp *v1alpha1.Redpanda) {
+				v1alpha1.RedpandaReady(rp)
+				rp.Status.Summary = statusSummary(rp)
+				rp.Status.LastReconcileTime = ptr.To(metav1.NewTime(reconciled.Add(time.Minute)))
+			},
+		},
+		{
+			name: "steady state with a stale LastReconcileTime",
+			mutate: func(rp *v1alpha1.Redpanda) {
+				v1alpha1.RedpandaReady(rp)
+				rp.Status.Summary = statusSummary(rp)
+				rp.Status.LastReconcileTime = ptr.To(metav1.NewTime(reconciled.Add(defaultLastReconcileTimeRefresh)))
+			},
+			wantWrites: 1,
+		},
+		{
+			name:    "steady state within a longer refresh",
+			refresh: time.Hour,
+			mutate: func(rp *v1alpha1.Redpanda) {
+				v1alpha1.RedpandaReady(rp)
+				rp.Status.Summary = statusSummary(rp)
+				rp.Status.LastReconcileTime = ptr.To(metav1.NewTime(reconciled.Add(defaultLastReconcileTimeRefresh)))
+			},
+		},
+		{
+			name: "status changed",
+			mutate: func(rp *v1alpha1.Redpanda) {
+				rp.Status.HelmReleaseReady = ptr.To(false)
+				rp.Status.Summary = statusSummary(rp)
+			},
+			wantWrites: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+			writes := 0
+			r := &RedpandaReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(stored.DeepCopy()).
+					WithStatusSubresource(&v1alpha1.Redpanda{}).
+					WithInterceptorFuncs(interceptor.Funcs{
+						SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+							writes++
+							return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+						},
+					}).
+					Build(),
+				Scheme:                   scheme,
+				LastReconcileTimeRefresh: tt.refresh,
+			}
+
+			var rp v1alpha1.Redpanda
+			require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(stored), &rp))
+			tt.mutate(&rp)
+
+			require.NoError(t, r.patchRedpandaStatus(context.Background(), &rp))
+			assert.Equal(t, tt.wantWrites, writes)
+		})
+	}
+}
+
+func TestReconcileSteadyStateNoWrites(t *testing.T) {
+	rp := testRedpanda()
+	rp.UID = "rp-uid"
+	rp.Finalizers = []string{FinalizerKey}
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Console: &v1alpha1.RedpandaConsole{Enabled: ptr.To(false)}}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+		Status:     appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1, UpdatedReplicas: 1},
+	}
+
+	r, recorder := newTestRedpandaReconciler(t)
+	counting := false
+	writes := 0
+	count := func() {
+		if counting {
+			writes++
+		}
+	}
+	r.Client = fake.NewClientBuilder().
+		WithScheme(r.Scheme).
+		WithObjects(rp, sts).
+		WithStatusSubresource(&v1alpha1.Redpanda{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				count()
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				count()
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				count()
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				count()
+				return c.Delete(ctx, obj, opts...)
+			},
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				count()
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				count()
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rp)}
+	reconcile := func() {
+		t.Helper()
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		for len(recorder.Events) > 0 {
+			<-recorder.Events
+		}
+	}
+	ready := []metav1.Condition{{
+		Type:               meta.ReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             meta.SucceededReason,
+		LastTransitionTime: metav1.Now(),
+	}}
+
+	// creates the HelmRepository, then the HelmRelease once it is ready
+	reconcile()
+	repo := &sourcev1.HelmRepository{}
+	require.NoError(t, r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetHelmRepositoryName()}, repo))
+	repo.Status.Conditions = ready
+	require.NoError(t, r.Client.Update(ctx, repo))
+
+	reconcile()
+	hr := &helmv2beta1.HelmRelease{}
+	require.NoError(t, r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetHelmReleaseName()}, hr))
+	hr.Status.Conditions = ready
+	require.NoError(t, r.Client.Update(ctx, hr))
+
+	reconcile()
+	require.NoError(t, r.Client.Get(ctx, req.NamespacedName, rp))
+	require.True(t, apimeta.IsStatusConditionTrue(rp.Status.Conditions, meta.ReadyCondition))
+
+	// nothing changed since, neither the resources nor the status are written
+	counting = true
+	reconcile()
+	assert.Zero(t, writes)
+}
+
+func TestValidateServiceAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		sa          string
+		expectError bool
+	}{
+		{name: "empty"},
+		{name: "existing", sa: "tenant-a"},
+		{name: "missing", sa: "tenant-b", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ChartRef.ServiceAccountName = tt.sa
+			r, _ := newTestRedpandaReconciler(t, rp, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Namespace: rp.Namespace},
+			})
+
+			err := r.validateServiceAccount(context.Background(), rp)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "default/tenant-b")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHelmReleaseRequiresUpdateServiceAccount(t *testing.T) {
+	r, _ := newTestRedpandaReconciler(t)
+	hr := &helmv2beta1.HelmRelease{}
+	hrTemplate := hr.DeepCopy()
+	assert.False(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+
+	hrTemplate.Spec.ServiceAccountName = "tenant-a"
+	assert.True(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+}
+
+func TestHelmReleaseChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(hr *helmv2beta1.HelmRelease)
+		changes []string
+	}{
+		{name: "unchanged", mutate: func(*helmv2beta1.HelmRelease) {}},
+		{
+			name: "values",
+			mutate: func(hr *helmv2beta1.HelmRelease) {
+				hr.Spec.Values = &apiextensionsv1.JSON{Raw: []byte(`{"image":{"tag":"v24.1.1"}}`)}
+			},
+			changes: []string{"values"},
+		},
+		{
+			name: "chart version and source",
+			mutate: func(hr *helmv2beta1.HelmRelease) {
+				hr.Spec.Chart.Spec.Version = "5.8.0"
+				hr.Spec.Chart.Spec.SourceRef.Name = "mirror"
+			},
+			changes: []string{"chart.version", "chart.sourceRef"},
+		},
+		{
+			name: "several aspects",
+			mutate: func(hr *helmv2beta1.HelmRelease) {
+				hr.Spec.Interval = metav1.Duration{Duration: time.Minute}
+				hr.Spec.ServiceAccountName = "tenant-a"
+				hr.Spec.ReleaseName = "redpanda-prod"
+			},
+			changes: []string{"interval", "serviceAccountName", "releaseName"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hr := &helmv2beta1.HelmRelease{}
+			hr.Spec.Chart.Spec.Chart = "redpanda"
+			hr.Spec.Chart.Spec.Version = "5.7.0"
+			hrTemplate := hr.DeepCopy()
+			tt.mutate(hrTemplate)
+
+			assert.Equal(t, tt.changes, helmReleaseChanges(hr, hrTemplate))
+
+			r, _ := newTestRedpandaReconciler(t)
+			assert.Equal(t, len(tt.changes) > 0, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+		})
+	}
+}
+
+func TestValidateReleaseName(t *testing.T) {
+	tests := []struct {
+		name        string
+		releaseName string
+		expectError string
+	}{
+		{name: "default"},
+		{name: "valid", releaseName: "redpanda-prod"},
+		{name: "dotted", releaseName: "redpanda.prod"},
+		{name: "uppercase", releaseName: "Redpanda", expectError: "RFC 1123"},
+		{name: "trailing dash", releaseName: "redpanda-", expectError: "RFC 1123"},
+		{name: "too long", releaseName: strings.Repeat("a", 54), expectError: "longer than 53 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateReleaseName(tt.releaseName)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReleaseName(t *testing.T) {
+	rp := testRedpanda()
+	r, _ := newTestRedpandaReconciler(t)
+
+	hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Empty(t, hr.Spec.ReleaseName)
+	assert.Equal(t, "redpanda", rp.GetReleaseName())
+	assert.Equal(t, "redpanda", expectedInternalServiceSelector(rp)[K8sInstanceLabelKey])
+
+	rp.Spec.ChartRef.ReleaseName = "redpanda-prod"
+	hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, "redpanda", hrTemplate.Name)
+	assert.Equal(t, "redpanda-prod", hrTemplate.Spec.ReleaseName)
+	assert.Equal(t, "redpanda-prod", hrTemplate.GetReleaseName())
+	assert.Equal(t, "default", hrTemplate.GetReleaseNamespace())
+	assert.Equal(t, "redpanda-prod", internalServiceName(rp))
+	assert.Equal(t, "redpanda-prod", expectedInternalServiceSelector(rp)[K8sInstanceLabelKey])
+	assert.True(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+
+	rp.Spec.ChartRef.ReleaseName = "Redpanda"
+	_, err = r.createHelmReleaseFromTemplate(context.Background(), rp)
+	assert.ErrorContains(t, err, "invalid releaseName")
+}
+
+func TestReconcileHelmReleaseUninstalling(t *testing.T) {
+	hr := &helmv2beta1.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "redpanda",
+			Namespace:         "default",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"finalizers.fluxcd.io"},
+		},
+		Spec: helmv2beta1.HelmReleaseSpec{Interval: metav1.Duration{Duration: time.Hour}},
+	}
+	rp := testRedpanda()
+	rp.Status.HelmRelease = "redpanda"
+	r, recorder := newTestRedpandaReconciler(t, rp, hr)
+
+	rp, got, err := r.reconcileHelmRelease(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, got.Spec.Interval.Duration, "HelmRelease must not be updated")
+	assert.True(t, apimeta.IsStatusConditionTrue(rp.Status.Conditions, ReleaseUninstallingCondition))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "is being uninstalled")
+
+	// reported once
+	_, _, err = r.reconcileHelmRelease(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+
+	// created again once deleted
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), hr))
+	hr.Finalizers = nil
+	require.NoError(t, r.Client.Update(context.Background(), hr))
+	rp, _, err = r.reconcileHelmRelease(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Nil(t, apimeta.FindStatusCondition(rp.Status.Conditions, ReleaseUninstallingCondition))
+}
+
+func TestReconcileHelmReleaseAlreadyExists(t *testing.T) {
+	rp := testRedpanda()
+	rp.UID = "rp-uid"
+
+	tests := []struct {
+		name        string
+		owners      []metav1.OwnerReference
+		expectError string
+	}{
+		{name: "owned", owners: []metav1.OwnerReference{rp.OwnerShipRefObj()}},
+		{name: "not owned", owners: nil},
+		{name: "stale owner", owners: []metav1.OwnerReference{{Kind: "Redpanda", Name: "redpanda", UID: "old-uid"}}},
+		{
+			name:        "owned by another redpanda",
+			owners:      []metav1.OwnerReference{{Kind: "Redpanda", Name: "other", UID: "other-uid"}},
+			expectError: "owned by Redpanda 'default/other'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the HelmRelease was created by a previous reconcile that did
+			// not record it in the status
+			existing := &helmv2beta1.HelmRelease{
+				ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", OwnerReferences: tt.owners},
+				Spec:       helmv2beta1.HelmReleaseSpec{Interval: metav1.Duration{Duration: time.Hour}},
+			}
+			rp := rp.DeepCopy()
+			r, _ := newTestRedpandaReconciler(t, rp, existing)
+
+			rp, got, err := r.reconcileHelmRelease(context.Background(), rp)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				assert.Empty(t, rp.Status.HelmRelease)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "redpanda", rp.Status.HelmRelease)
+
+			// drift is evaluated in the same reconcile
+			assert.Equal(t, 30*time.Second, got.Spec.Interval.Duration)
+			var hr helmv2beta1.HelmRelease
+			require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(existing), &hr))
+			assert.Equal(t, 30*time.Second, hr.Spec.Interval.Duration)
+			assert.True(t, isOwnedBy(hr.OwnerReferences, rp.UID))
+		})
+	}
+}
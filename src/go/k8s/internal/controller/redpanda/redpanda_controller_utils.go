@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fluxcd/pkg/runtime/logger"
@@ -141,14 +143,38 @@ func newClient(credentialsFile string, tlsConfig *tls.Config) (*registry.Client,
 	return registry.NewClient(opts...)
 }
 
+// StorageBasePath returns the directory below root holding the chart
+// artifacts of this operator instance. It is scoped by the watched namespace,
+// or "cluster" for a cluster scoped operator, and by the instance name, so
+// that operators sharing a node neither serve nor garbage collect the
+// artifacts of each other.
+func StorageBasePath(root, namespace, instance string) string {
+	if namespace == "" {
+		namespace = "cluster"
+	}
+	return filepath.Join(root, "redpanda-operator", namespace, instance)
+}
+
+// StorageInstanceName returns a name unique to this operator instance, the
+// Pod name when running in Kubernetes.
+func StorageInstanceName() string {
+	if name := os.Getenv("HOSTNAME"); name != "" {
+		return name
+	}
+	if name, err := os.Hostname(); err == nil && name != "" {
+		return name
+	}
+	return strconv.Itoa(os.Getpid())
+}
+
 func MustInitStorage(path, storageAdvAddr string, artifactRetentionTTL time.Duration, artifactRetentionRecords int, l logr.Logger) controllers.Storage {
 	if path == "" {
 		p, _ := os.Getwd()
 		path = filepath.Join(p, "bin")
-		err := os.MkdirAll(path, 0o700)
-		if err != nil {
-			l.Error(err, "unable make directory with right permissions")
-		}
+	}
+	err := os.MkdirAll(path, 0o700)
+	if err != nil {
+		l.Error(err, "unable make directory with right permissions")
 	}
 
 	storage, err := controllers.NewStorage(path, storageAdvAddr, artifactRetentionTTL, artifactRetentionRecords)
@@ -160,6 +186,36 @@ func MustInitStorage(path, storageAdvAddr string, artifactRetentionTTL time.Dura
 	return storage
 }
 
+// RemoveStorage removes the artifacts stored below the base path of this
+// operator instance, leaving those of other instances untouched.
+func RemoveStorage(path string, l logr.Logger) {
+	if err := os.RemoveAll(path); err != nil {
+		l.Error(err, "unable to remove storage", "path", path)
+	}
+}
+
+// serviceAccountNamespaceFile holds the namespace of the pod in every
+// container with a mounted service account token.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// DetermineOperatorNamespace returns the namespace the operator runs in,
+// taken from the POD_NAMESPACE environment variable, the namespace of the
+// mounted service account or, outside of a cluster, the given fallback.
+func DetermineOperatorNamespace(fallback string) (string, error) {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns, nil
+	}
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		if ns := strings.TrimSpace(string(data)); ns != "" {
+			return ns, nil
+		}
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", fmt.Errorf("set POD_NAMESPACE or --namespace, the namespace of the operator cannot be read from %s", serviceAccountNamespaceFile)
+}
+
 func DetermineAdvStorageAddr(storageAddr string, l logr.Logger) string {
 	host, port, err := net.SplitHostPort(storageAddr)
 	if err != nil {
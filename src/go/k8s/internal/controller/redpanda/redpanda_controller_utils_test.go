@@ -0,0 +1,53 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageBasePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		instance  string
+		want      string
+	}{
+		{name: "namespaced", namespace: "team-a", instance: "operator-0", want: "/tmp/redpanda-operator/team-a/operator-0"},
+		{name: "cluster scoped", instance: "operator-0", want: "/tmp/redpanda-operator/cluster/operator-0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StorageBasePath("/tmp", tt.namespace, tt.instance))
+		})
+	}
+}
+
+func TestRemoveStorage(t *testing.T) {
+	root := t.TempDir()
+	own := StorageBasePath(root, "team-a", "operator-0")
+	other := StorageBasePath(root, "team-a", "operator-1")
+	for _, dir := range []string{own, other} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "helmchart"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "helmchart", "redpanda-5.7.1.tgz"), []byte("chart"), 0o600))
+	}
+
+	RemoveStorage(own, logr.Discard())
+
+	assert.NoDirExists(t, own)
+	assert.FileExists(t, filepath.Join(other, "helmchart", "redpanda-5.7.1.tgz"))
+}
@@ -0,0 +1,464 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/strvals"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+const (
+	valuesOverlayKindConfigMap = "ConfigMap"
+	valuesOverlayKindSecret    = "Secret"
+
+	// valuesOverlaysIndex indexes Redpandas by the names of the ConfigMaps
+	// and Secrets referenced in Spec.ChartRef.ValuesOverlays.
+	valuesOverlaysIndex = "spec.chartRef.valuesOverlays[].name"
+
+	// valuesFromIndex indexes Redpandas by the names of the ConfigMaps and
+	// Secrets referenced in Spec.ChartRef.ValuesFrom.
+	valuesFromIndex = "spec.chartRef.valuesFrom[].name"
+
+	// operatorValuesKey is the values key holding the settings of the
+	// operator passed to the chart, operator.namespace is the namespace the
+	// operator runs in.
+	operatorValuesKey = "operator"
+
+	// maxTargetPathLength is the longest TargetPath accepted by the helm
+	// controller.
+	maxTargetPathLength = 250
+
+	// unreconciledFieldsReason is the reason of the event listing the
+	// clusterSpec fields that do not take effect.
+	unreconciledFieldsReason = "UnreconciledFields"
+	// valuesDroppedReason is the reason of the event listing the clusterSpec
+	// fields dropped from the values of an older chart version.
+	valuesDroppedReason = "ValuesDropped"
+)
+
+// targetPathRegexp matches the dot notation understood by the helm
+// controller: non empty segments separated by dots, each optionally followed
+// by list indices, e.g. "config.cluster.superusers[0]". Dots within a
+// segment are escaped with a backslash.
+var targetPathRegexp = regexp.MustCompile(`^(?:[a-zA-Z0-9_\-/]|\\.)+(?:\[[0-9]{1,5}\])*(?:\.(?:[a-zA-Z0-9_\-/]|\\.)+(?:\[[0-9]{1,5}\])*)*$`)
+
+func valuesOverlayNames(obj client.Object) []string {
+	rp, ok := obj.(*v1alpha1.Redpanda)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(rp.Spec.ChartRef.ValuesOverlays))
+	for i := range rp.Spec.ChartRef.ValuesOverlays {
+		names = append(names, rp.Spec.ChartRef.ValuesOverlays[i].Name)
+	}
+	return names
+}
+
+// redpandasForValuesOverlay maps a ConfigMap or Secret, depending on kind, to
+// the Redpandas that reference it as a values overlay so that changes to the
+// overlay are rolled out.
+func (r *RedpandaReconciler) redpandasForValuesOverlay(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list v1alpha1.RedpandaList
+		if err := r.Client.List(ctx, &list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{valuesOverlaysIndex: obj.GetName()}); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "could not list redpandas referencing values overlay", "kind", kind, "name", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range list.Items {
+			for _, overlay := range list.Items[i].Spec.ChartRef.ValuesOverlays {
+				if overlay.Kind == kind && overlay.Name == obj.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+func valuesFromNames(obj client.Object) []string {
+	rp, ok := obj.(*v1alpha1.Redpanda)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(rp.Spec.ChartRef.ValuesFrom))
+	for i := range rp.Spec.ChartRef.ValuesFrom {
+		names = append(names, rp.Spec.ChartRef.ValuesFrom[i].Name)
+	}
+	return names
+}
+
+// redpandasForValuesFrom maps a ConfigMap or Secret, depending on kind, to
+// the Redpandas that reference it in valuesFrom. The helm controller does not
+// watch these references, the Redpandas reconcile to roll out the change.
+func (r *RedpandaReconciler) redpandasForValuesFrom(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list v1alpha1.RedpandaList
+		if err := r.Client.List(ctx, &list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{valuesFromIndex: obj.GetName()}); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "could not list redpandas referencing valuesFrom", "kind", kind, "name", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range list.Items {
+			for _, ref := range list.Items[i].Spec.ChartRef.ValuesFrom {
+				if ref.Kind == kind && ref.Name == obj.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+// buildValues returns the chart values for the given Redpanda: the inline
+// ClusterSpec, completed with the Console resources preserved by a migration,
+// with Spec.ChartRef.RawValues and every entry of Spec.ChartRef.ValuesOverlays
+// merged on top in order, followed by the values derived from operator
+// managed resources. Externally managed replicas are set to the current ones
+// of the StatefulSet.
+func (r *RedpandaReconciler) buildValues(ctx context.Context, rp *v1alpha1.Redpanda) (*apiextensionsv1.JSON, error) {
+	values, err := rp.ValuesJSON()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse clusterSpec to json: %w", err)
+	}
+
+	consoleValues, err := migratedConsoleValues(rp)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := r.defaultValues()
+	operatorValues := certManagerValues(rp)
+	if len(rp.Spec.ChartRef.ValuesOverlays) == 0 && defaults == nil && operatorValues == nil && consoleValues == nil && rp.Spec.ChartRef.RawValues == "" && !replicasExternallyManaged(rp) {
+		return values, nil
+	}
+
+	clusterSpecValues := map[string]interface{}{}
+	if err = json.Unmarshal(values.Raw, &clusterSpecValues); err != nil {
+		return nil, fmt.Errorf("could not unmarshal clusterSpec values: %w", err)
+	}
+	// an unset ClusterSpec is marshalled as null
+	merged := mergeValues(mergeValues(map[string]interface{}{}, defaults), clusterSpecValues)
+	merged = mergeValues(merged, consoleValues)
+
+	rawValues, err := parseRawValues(rp.Spec.ChartRef.RawValues)
+	if err != nil {
+		return nil, err
+	}
+	merged = mergeValues(merged, rawValues)
+
+	for i := range rp.Spec.ChartRef.ValuesOverlays {
+		overlay := &rp.Spec.ChartRef.ValuesOverlays[i]
+		data, err := r.getValuesOverlay(ctx, rp.Namespace, overlay)
+		if err != nil {
+			return nil, err
+		}
+
+		overlayValues := map[string]interface{}{}
+		if err = yaml.Unmarshal(data, &overlayValues); err != nil {
+			return nil, fmt.Errorf("could not parse values of %s '%s/%s' key %q: %w", overlay.Kind, rp.Namespace, overlay.Name, overlay.GetValuesKey(), err)
+		}
+
+		merged = mergeValues(merged, overlayValues)
+	}
+
+	merged = mergeValues(merged, operatorValues)
+
+	if replicasExternallyManaged(rp) {
+		if err = r.externalReplicasValues(ctx, rp, merged); err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal merged values: %w", err)
+	}
+
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+// defaultValues returns the values derived from the operator settings, all
+// other values take precedence over them.
+func (r *RedpandaReconciler) defaultValues() map[string]interface{} {
+	if r.ClusterDomain == "" && r.OperatorNamespace == "" {
+		return nil
+	}
+	values := map[string]interface{}{}
+	if r.ClusterDomain != "" {
+		values["clusterDomain"] = r.ClusterDomain
+	}
+	if r.OperatorNamespace != "" {
+		values[operatorValuesKey] = map[string]interface{}{"namespace": r.OperatorNamespace}
+	}
+	return values
+}
+
+// parseRawValues parses Spec.ChartRef.RawValues, which must be a YAML
+// mapping.
+func parseRawValues(rawValues string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(rawValues), &values); err != nil {
+		return nil, fmt.Errorf("could not parse rawValues, expected a YAML mapping: %w", err)
+	}
+	return values, nil
+}
+
+func (r *RedpandaReconciler) getValuesOverlay(ctx context.Context, namespace string, overlay *v1alpha1.ValuesOverlay) ([]byte, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: overlay.Name}
+	valuesKey := overlay.GetValuesKey()
+
+	switch overlay.Kind {
+	case valuesOverlayKindConfigMap:
+		var cm corev1.ConfigMap
+		if err := r.Client.Get(ctx, key, &cm); err != nil {
+			return nil, fmt.Errorf("could not get values overlay ConfigMap '%s': %w", key, err)
+		}
+		data, ok := cm.Data[valuesKey]
+		if !ok {
+			return nil, fmt.Errorf("values overlay ConfigMap '%s' has no key %q", key, valuesKey)
+		}
+		return []byte(data), nil
+	case valuesOverlayKindSecret:
+		var secret corev1.Secret
+		if err := r.Client.Get(ctx, key, &secret); err != nil {
+			return nil, fmt.Errorf("could not get values overlay Secret '%s': %w", key, err)
+		}
+		data, ok := secret.Data[valuesKey]
+		if !ok {
+			return nil, fmt.Errorf("values overlay Secret '%s' has no key %q", key, valuesKey)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported values overlay kind %q", overlay.Kind)
+	}
+}
+
+// validateValuesFrom rejects ValuesFrom references the helm controller would
+// fail to resolve because of their kind or TargetPath syntax.
+func validateValuesFrom(valuesFrom []helmv2beta1.ValuesReference) error {
+	for i, ref := range valuesFrom {
+		if ref.Kind != valuesOverlayKindConfigMap && ref.Kind != valuesOverlayKindSecret {
+			return fmt.Errorf("valuesFrom[%d]: unsupported kind %q", i, ref.Kind)
+		}
+		if ref.Name == "" {
+			return fmt.Errorf("valuesFrom[%d]: name is required", i)
+		}
+		if ref.TargetPath == "" {
+			continue
+		}
+		if len(ref.TargetPath) > maxTargetPathLength {
+			return fmt.Errorf("valuesFrom[%d]: targetPath is longer than %d characters", i, maxTargetPathLength)
+		}
+		if !targetPathRegexp.MatchString(ref.TargetPath) {
+			return fmt.Errorf("valuesFrom[%d]: invalid targetPath %q", i, ref.TargetPath)
+		}
+	}
+	return nil
+}
+
+// valuesFromSHA returns the SHA of the data referenced in
+// Spec.ChartRef.ValuesFrom, or an empty string if there are no references.
+// The HelmRelease spec does not change when the referenced data does, the
+// SHA tells when the release needs to be reconciled again. Like the helm
+// controller, missing optional references are ignored but a missing
+// ValuesKey, defaulting to 'values.yaml', is not.
+func (r *RedpandaReconciler) valuesFromSHA(ctx context.Context, rp *v1alpha1.Redpanda) (string, error) {
+	if len(rp.Spec.ChartRef.ValuesFrom) == 0 {
+		return "", nil
+	}
+
+	hasher := sha256.New()
+	for i := range rp.Spec.ChartRef.ValuesFrom {
+		ref := &rp.Spec.ChartRef.ValuesFrom[i]
+		data, err := r.getValuesFrom(ctx, rp.Namespace, i, ref)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hasher, "%s/%s/%s/%d:", ref.Kind, ref.Name, ref.GetValuesKey(), len(data))
+		hasher.Write(data)
+	}
+	return base64.URLEncoding.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// getValuesFrom returns the data of the i-th valuesFrom reference, nil if an
+// optional reference is missing.
+func (r *RedpandaReconciler) getValuesFrom(ctx context.Context, namespace string, i int, ref *helmv2beta1.ValuesReference) ([]byte, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+
+	var data []byte
+	var found bool
+	var err error
+	switch ref.Kind {
+	case valuesOverlayKindConfigMap:
+		var cm corev1.ConfigMap
+		if err = r.Client.Get(ctx, key, &cm); err == nil {
+			var value string
+			value, found = cm.Data[ref.GetValuesKey()]
+			data = []byte(value)
+		}
+	case valuesOverlayKindSecret:
+		var secret corev1.Secret
+		if err = r.Client.Get(ctx, key, &secret); err == nil {
+			data, found = secret.Data[ref.GetValuesKey()]
+		}
+	default:
+		return nil, fmt.Errorf("valuesFrom[%d]: unsupported kind %q", i, ref.Kind)
+	}
+
+	switch {
+	case apierrors.IsNotFound(err) && ref.Optional:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("valuesFrom[%d]: could not get %s '%s': %w", i, ref.Kind, key, err)
+	case !found:
+		return nil, fmt.Errorf("valuesFrom[%d]: %s '%s' has no key %q", i, ref.Kind, key, ref.GetValuesKey())
+	}
+	return data, nil
+}
+
+// releaseValues returns the values the helm controller installs the chart of
+// the given Redpanda with: the data referenced in Spec.ChartRef.ValuesFrom,
+// merged in order, with the values of buildValues, which the values SHA is
+// computed from, merged on top.
+func (r *RedpandaReconciler) releaseValues(ctx context.Context, rp *v1alpha1.Redpanda) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for i := range rp.Spec.ChartRef.ValuesFrom {
+		ref := &rp.Spec.ChartRef.ValuesFrom[i]
+		data, err := r.getValuesFrom(ctx, rp.Namespace, i, ref)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		if ref.TargetPath != "" {
+			if err = strvals.ParseInto(fmt.Sprintf("%s=%s", ref.TargetPath, data), result); err != nil {
+				return nil, fmt.Errorf("valuesFrom[%d]: could not set %q: %w", i, ref.TargetPath, err)
+			}
+			continue
+		}
+		values := map[string]interface{}{}
+		if err = yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("valuesFrom[%d]: could not parse values of %s '%s/%s' key %q: %w", i, ref.Kind, rp.Namespace, ref.Name, ref.GetValuesKey(), err)
+		}
+		result = mergeValues(result, values)
+	}
+
+	values, err := r.buildValues(ctx, rp)
+	if err != nil {
+		return nil, err
+	}
+	inline := map[string]interface{}{}
+	if err = json.Unmarshal(values.Raw, &inline); err != nil {
+		return nil, fmt.Errorf("could not unmarshal chart values: %w", err)
+	}
+	return mergeValues(result, inline), nil
+}
+
+// mergeValues deep merges src on top of dst and returns dst. Nested maps are
+// merged key by key; any other value, including arrays, in src replaces the
+// value in dst.
+func mergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[k] = mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// reportUnreconciledFields sets Status.UnreconciledFields to the clusterSpec
+// fields of the given Redpanda whose value differs in the chart values or
+// that the value schema of the chart version does not know, emitting events
+// enumerating them when they change.
+func (r *RedpandaReconciler) reportUnreconciledFields(rp *v1alpha1.Redpanda, values *apiextensionsv1.JSON) error {
+	clusterSpecJSON, dropped, err := rp.VersionedValuesJSON()
+	if err != nil {
+		return fmt.Errorf("could not parse clusterSpec to json: %w", err)
+	}
+	clusterSpecValues := map[string]interface{}{}
+	if err = json.Unmarshal(clusterSpecJSON.Raw, &clusterSpecValues); err != nil {
+		return fmt.Errorf("could not unmarshal clusterSpec values: %w", err)
+	}
+	chartValues := map[string]interface{}{}
+	if err = json.Unmarshal(values.Raw, &chartValues); err != nil {
+		return fmt.Errorf("could not unmarshal chart values: %w", err)
+	}
+
+	overridden := unreconciledFields("", clusterSpecValues, chartValues)
+	fields := append(append([]string{}, overridden...), dropped...)
+	sort.Strings(fields)
+	if len(fields) == 0 {
+		fields = nil
+	}
+	if !reflect.DeepEqual(fields, rp.Status.UnreconciledFields) {
+		if len(dropped) > 0 {
+			msg := fmt.Sprintf("clusterSpec fields are not known to chart version %s, they are dropped from the chart values: %s", rp.Spec.ChartRef.ChartVersion, strings.Join(dropped, ", "))
+			r.reasonEvent(rp, valuesDroppedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		}
+		if len(overridden) > 0 {
+			msg := fmt.Sprintf("clusterSpec fields do not take effect, they are overridden in the chart values: %s", strings.Join(overridden, ", "))
+			r.reasonEvent(rp, unreconciledFieldsReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+		}
+	}
+	rp.Status.UnreconciledFields = fields
+	return nil
+}
+
+// unreconciledFields returns the sorted dot notation paths, prefixed with
+// prefix, of the leaves of spec whose value differs in values or that are
+// missing from values.
+func unreconciledFields(prefix string, spec, values map[string]interface{}) []string {
+	var fields []string
+	for k, v := range spec {
+		path := prefix + k
+		specMap, specIsMap := v.(map[string]interface{})
+		valuesMap, valuesIsMap := values[k].(map[string]interface{})
+		if specIsMap && len(specMap) > 0 && valuesIsMap {
+			fields = append(fields, unreconciledFields(path+".", specMap, valuesMap)...)
+			continue
+		}
+		if value, ok := values[k]; !ok || !reflect.DeepEqual(v, value) {
+			fields = append(fields, path)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
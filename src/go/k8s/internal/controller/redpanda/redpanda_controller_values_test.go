@@ -0,0 +1,561 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestMergeValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      map[string]interface{}
+		src      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "maps are merged key by key",
+			dst:      map[string]interface{}{"storage": map[string]interface{}{"persistentVolume": map[string]interface{}{"size": "10Gi", "enabled": true}}},
+			src:      map[string]interface{}{"storage": map[string]interface{}{"persistentVolume": map[string]interface{}{"size": "20Gi"}}},
+			expected: map[string]interface{}{"storage": map[string]interface{}{"persistentVolume": map[string]interface{}{"size": "20Gi", "enabled": true}}},
+		},
+		{
+			name:     "arrays are replaced",
+			dst:      map[string]interface{}{"tolerations": []interface{}{"a", "b"}},
+			src:      map[string]interface{}{"tolerations": []interface{}{"c"}},
+			expected: map[string]interface{}{"tolerations": []interface{}{"c"}},
+		},
+		{
+			name:     "scalar replaces map",
+			dst:      map[string]interface{}{"tls": map[string]interface{}{"enabled": true}},
+			src:      map[string]interface{}{"tls": nil},
+			expected: map[string]interface{}{"tls": nil},
+		},
+		{
+			name:     "new keys are added",
+			dst:      map[string]interface{}{"a": 1},
+			src:      map[string]interface{}{"b": 2},
+			expected: map[string]interface{}{"a": 1, "b": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mergeValues(tt.dst, tt.src))
+		})
+	}
+}
+
+func TestRedpandasForValuesOverlay(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	withOverlays := func(name string, overlays ...v1alpha1.ValuesOverlay) *v1alpha1.Redpanda {
+		return &v1alpha1.Redpanda{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1alpha1.RedpandaSpec{ChartRef: v1alpha1.ChartRef{ValuesOverlays: overlays}},
+		}
+	}
+
+	r := &RedpandaReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&v1alpha1.Redpanda{}, valuesOverlaysIndex, valuesOverlayNames).
+			WithObjects(
+				withOverlays("uses-configmap", v1alpha1.ValuesOverlay{Kind: "ConfigMap", Name: "overrides"}),
+				withOverlays("uses-secret", v1alpha1.ValuesOverlay{Kind: "Secret", Name: "overrides"}),
+				withOverlays("unrelated", v1alpha1.ValuesOverlay{Kind: "ConfigMap", Name: "other"}),
+			).Build(),
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-configmap"}},
+	}, r.redpandasForValuesOverlay(valuesOverlayKindConfigMap)(context.Background(), cm))
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-secret"}},
+	}, r.redpandasForValuesOverlay(valuesOverlayKindSecret)(context.Background(), secret))
+
+	cm.Namespace = "other"
+	assert.Empty(t, r.redpandasForValuesOverlay(valuesOverlayKindConfigMap)(context.Background(), cm))
+}
+
+func TestValidateValuesFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		targetPath  string
+		kind        string
+		expectError string
+	}{
+		{name: "no target path"},
+		{name: "nested key", targetPath: "config.cluster.auto_create_topics_enabled"},
+		{name: "list index", targetPath: "auth.sasl.users[0].password"},
+		{name: "escaped dot", targetPath: `podAnnotations.prometheus\.io/scrape`},
+		{name: "leading dot", targetPath: ".config", expectError: "invalid targetPath"},
+		{name: "empty segment", targetPath: "config..cluster", expectError: "invalid targetPath"},
+		{name: "trailing dot", targetPath: "config.", expectError: "invalid targetPath"},
+		{name: "bare index", targetPath: "[0].name", expectError: "invalid targetPath"},
+		{name: "non numeric index", targetPath: "users[first]", expectError: "invalid targetPath"},
+		{name: "too long", targetPath: strings.Repeat("a", maxTargetPathLength+1), expectError: "longer than 250 characters"},
+		{name: "unsupported kind", kind: "Deployment", expectError: `unsupported kind "Deployment"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := tt.kind
+			if kind == "" {
+				kind = valuesOverlayKindSecret
+			}
+			err := validateValuesFrom([]helmv2beta1.ValuesReference{
+				{Kind: valuesOverlayKindConfigMap, Name: "values"},
+				{Kind: kind, Name: "override", ValuesKey: "value", TargetPath: tt.targetPath},
+			})
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, "valuesFrom[1]: "+tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValuesFromSHA(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "profiles", Namespace: "default"},
+		Data: map[string]string{
+			"values.yaml":      "{}",
+			"prod-values.yaml": "{}",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "license", Namespace: "default"},
+		Data:       map[string][]byte{"license": []byte("license")},
+	}
+
+	tests := []struct {
+		name        string
+		ref         helmv2beta1.ValuesReference
+		expectError string
+	}{
+		{name: "default key", ref: helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "profiles"}},
+		{name: "selected key", ref: helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "profiles", ValuesKey: "prod-values.yaml"}},
+		{name: "secret key", ref: helmv2beta1.ValuesReference{Kind: "Secret", Name: "license", ValuesKey: "license"}},
+		{
+			name:        "missing key",
+			ref:         helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "profiles", ValuesKey: "dev-values.yaml"},
+			expectError: `valuesFrom[0]: ConfigMap 'default/profiles' has no key "dev-values.yaml"`,
+		},
+		{
+			name:        "missing secret key",
+			ref:         helmv2beta1.ValuesReference{Kind: "Secret", Name: "license"},
+			expectError: `valuesFrom[0]: Secret 'default/license' has no key "values.yaml"`,
+		},
+		{
+			name:        "missing reference",
+			ref:         helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "other"},
+			expectError: "valuesFrom[0]: could not get ConfigMap 'default/other'",
+		},
+		{name: "missing optional reference", ref: helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "other", Optional: true}},
+		{
+			name:        "missing key of optional reference",
+			ref:         helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "profiles", ValuesKey: "dev-values.yaml", Optional: true},
+			expectError: `has no key "dev-values.yaml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ChartRef.ValuesFrom = []helmv2beta1.ValuesReference{tt.ref}
+			r, _ := newTestRedpandaReconciler(t, cm.DeepCopy(), secret.DeepCopy())
+
+			sha, err := r.valuesFromSHA(context.Background(), rp)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, sha)
+		})
+	}
+}
+
+func TestRedpandasForValuesFrom(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	withValuesFrom := func(name string, refs ...helmv2beta1.ValuesReference) *v1alpha1.Redpanda {
+		rp := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		rp.Spec.ChartRef.ValuesFrom = refs
+		return rp
+	}
+	r := &RedpandaReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&v1alpha1.Redpanda{}, valuesFromIndex, valuesFromNames).
+			WithObjects(
+				withValuesFrom("uses-configmap", helmv2beta1.ValuesReference{Kind: "ConfigMap", Name: "values"}),
+				withValuesFrom("uses-secret", helmv2beta1.ValuesReference{Kind: "Secret", Name: "values"}),
+				withValuesFrom("unrelated", helmv2beta1.ValuesReference{Kind: "Secret", Name: "other"}),
+			).Build(),
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: "default"}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-secret"}},
+	}, r.redpandasForValuesFrom(valuesOverlayKindSecret)(context.Background(), secret))
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: "default"}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "uses-configmap"}},
+	}, r.redpandasForValuesFrom(valuesOverlayKindConfigMap)(context.Background(), cm))
+
+	secret.Namespace = "other"
+	assert.Empty(t, r.redpandasForValuesFrom(valuesOverlayKindSecret)(context.Background(), secret))
+}
+
+func TestValuesFromSecretChangeTriggersHelmReleaseUpdate(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "license", Namespace: "default"},
+		Data:       map[string][]byte{"license": []byte("license")},
+	}
+	rp := testRedpanda()
+	rp.Spec.ChartRef.ValuesFrom = []helmv2beta1.ValuesReference{
+		{Kind: valuesOverlayKindSecret, Name: "license", ValuesKey: "license", TargetPath: "enterprise.license"},
+	}
+	r, _ := newTestRedpandaReconciler(t, secret)
+
+	hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.NotEmpty(t, hr.Annotations[v1alpha1.GroupVersion.Group+valuesFromSHAPath])
+
+	hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Empty(t, helmReleaseChanges(hr, hrTemplate))
+
+	secret.Data["license"] = []byte("renewed")
+	require.NoError(t, r.Client.Update(context.Background(), secret))
+
+	hrTemplate, err = r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, hr.Spec, hrTemplate.Spec)
+	assert.Equal(t, []string{"valuesFrom data"}, helmReleaseChanges(hr, hrTemplate))
+	assert.True(t, helmReleaseMateriallyChanged(hr, hrTemplate))
+}
+
+func TestValuesFromTriggerHelmReleaseUpdate(t *testing.T) {
+	rp := testRedpanda()
+	r, _ := newTestRedpandaReconciler(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "license", Namespace: "default"},
+		Data:       map[string][]byte{"license": []byte("license")},
+	})
+
+	hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+
+	rp.Spec.ChartRef.ValuesFrom = []helmv2beta1.ValuesReference{
+		{Kind: valuesOverlayKindSecret, Name: "license", ValuesKey: "license", TargetPath: "enterprise.license"},
+	}
+	hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, rp.Spec.ChartRef.ValuesFrom, hrTemplate.Spec.ValuesFrom)
+	assert.True(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+
+	rp.Spec.ChartRef.ValuesFrom[0].TargetPath = "enterprise..license"
+	_, err = r.createHelmReleaseFromTemplate(context.Background(), rp)
+	assert.ErrorContains(t, err, "invalid valuesFrom")
+}
+
+func TestBuildValuesRawValues(t *testing.T) {
+	overlay := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "overlay", Namespace: "default"},
+		Data:       map[string]string{"values.yaml": "tuning:\n  tune_aio_events: false\n"},
+	}
+
+	tests := []struct {
+		name        string
+		rawValues   string
+		overlay     bool
+		expected    map[string]interface{}
+		expectError string
+	}{
+		{
+			name:     "unset",
+			expected: map[string]interface{}{"nameOverride": "panda"},
+		},
+		{
+			name:      "merged on top of clusterSpec",
+			rawValues: "nameOverride: redpanda\ntuning:\n  tune_aio_events: true\n",
+			expected: map[string]interface{}{
+				"nameOverride": "redpanda",
+				"tuning":       map[string]interface{}{"tune_aio_events": true},
+			},
+		},
+		{
+			name:      "overlays take precedence",
+			rawValues: "tuning:\n  tune_aio_events: true\n  tune_clocksource: true\n",
+			overlay:   true,
+			expected: map[string]interface{}{
+				"nameOverride": "panda",
+				"tuning":       map[string]interface{}{"tune_aio_events": false, "tune_clocksource": true},
+			},
+		},
+		{
+			name:        "not a mapping",
+			rawValues:   "- nameOverride\n",
+			expectError: "could not parse rawValues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{NameOverride: "panda"}
+			rp.Spec.ChartRef.RawValues = tt.rawValues
+			if tt.overlay {
+				rp.Spec.ChartRef.ValuesOverlays = []v1alpha1.ValuesOverlay{{Kind: valuesOverlayKindConfigMap, Name: "overlay"}}
+			}
+			r, _ := newTestRedpandaReconciler(t, overlay)
+
+			values, err := r.buildValues(context.Background(), rp)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+
+			got := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(values.Raw, &got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRawValuesChangeValuesSHA(t *testing.T) {
+	rp := testRedpanda()
+	r, _ := newTestRedpandaReconciler(t)
+	key := v1alpha1.GroupVersion.Group + valuesSHAPath
+
+	hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+
+	rp.Spec.ChartRef.RawValues = "tuning:\n  tune_aio_events: true\n"
+	hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.NotEqual(t, hr.Annotations[key], hrTemplate.Annotations[key])
+	assert.True(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+}
+
+func TestReportUnreconciledFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawValues string
+		expected  []string
+	}{
+		{name: "all applied"},
+		{name: "same value", rawValues: "nameOverride: panda\n"},
+		{name: "scalar overridden", rawValues: "nameOverride: bear\n", expected: []string{"nameOverride"}},
+		{
+			name:      "nested overridden",
+			rawValues: "nameOverride: bear\ntuning:\n  tune_aio_events: false\n  tune_clocksource: true\n",
+			expected:  []string{"nameOverride", "tuning.tune_aio_events"},
+		},
+		{name: "mapping replaced", rawValues: "tuning: null\n", expected: []string{"tuning"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{NameOverride: "panda", Tuning: &v1alpha1.Tuning{TuneAioEvents: ptr.To(true)}}
+			rp.Spec.ChartRef.RawValues = tt.rawValues
+			r, recorder := newTestRedpandaReconciler(t)
+
+			values, err := r.buildValues(context.Background(), rp)
+			require.NoError(t, err)
+			require.NoError(t, r.reportUnreconciledFields(rp, values))
+			assert.Equal(t, tt.expected, rp.Status.UnreconciledFields)
+
+			// the event is only emitted when the fields change
+			require.NoError(t, r.reportUnreconciledFields(rp, values))
+			var events []string
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			if len(tt.expected) == 0 {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			assert.Contains(t, events[0], unreconciledFieldsReason)
+			assert.Contains(t, events[0], strings.Join(tt.expected, ", "))
+		})
+	}
+}
+
+func TestReportUnreconciledFieldsDroppedValues(t *testing.T) {
+	rp := testRedpanda()
+	rp.Spec.ChartRef.ChartVersion = "4.0.54"
+	rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{NameOverride: "panda", Connectors: &v1alpha1.RedpandaConnectors{Enabled: ptr.To(true)}}
+	r, recorder := newTestRedpandaReconciler(t)
+
+	values, err := r.buildValues(context.Background(), rp)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nameOverride":"panda"}`, string(values.Raw))
+
+	require.NoError(t, r.reportUnreconciledFields(rp, values))
+	assert.Equal(t, []string{"connectors"}, rp.Status.UnreconciledFields)
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, valuesDroppedReason)
+	assert.Contains(t, event, "chart version 4.0.54")
+}
+
+func TestBuildValuesClusterDomain(t *testing.T) {
+	tests := []struct {
+		name          string
+		clusterDomain string
+		clusterSpec   *v1alpha1.RedpandaClusterSpec
+		expected      map[string]interface{}
+	}{
+		{name: "chart default"},
+		{
+			name:          "operator setting",
+			clusterDomain: "example.internal",
+			expected:      map[string]interface{}{"clusterDomain": "example.internal"},
+		},
+		{
+			name:          "set by the Redpanda",
+			clusterDomain: "example.internal",
+			clusterSpec:   &v1alpha1.RedpandaClusterSpec{ClusterDomain: "other.internal"},
+			expected:      map[string]interface{}{"clusterDomain": "other.internal"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ClusterSpec = tt.clusterSpec
+			r, _ := newTestRedpandaReconciler(t)
+			r.ClusterDomain = tt.clusterDomain
+
+			values, err := r.buildValues(context.Background(), rp)
+			require.NoError(t, err)
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(values.Raw, &got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	t.Run("changes the values SHA", func(t *testing.T) {
+		rp := testRedpanda()
+		r, _ := newTestRedpandaReconciler(t)
+		key := v1alpha1.GroupVersion.Group + valuesSHAPath
+
+		hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+		require.NoError(t, err)
+
+		r.ClusterDomain = "example.internal"
+		hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+		require.NoError(t, err)
+		assert.NotEqual(t, hr.Annotations[key], hrTemplate.Annotations[key])
+	})
+}
+
+func TestBuildValuesOperatorNamespace(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterSpec *v1alpha1.RedpandaClusterSpec
+		rawValues   string
+		expected    map[string]interface{}
+	}{
+		{
+			name:     "operator setting",
+			expected: map[string]interface{}{"operator": map[string]interface{}{"namespace": "redpanda-system"}},
+		},
+		{
+			name:        "merged with the cluster spec",
+			clusterSpec: &v1alpha1.RedpandaClusterSpec{FullNameOverride: "panda"},
+			expected: map[string]interface{}{
+				"fullNameOverride": "panda",
+				"operator":         map[string]interface{}{"namespace": "redpanda-system"},
+			},
+		},
+		{
+			name:      "overridden by rawValues",
+			rawValues: "operator:\n  namespace: other\n",
+			expected:  map[string]interface{}{"operator": map[string]interface{}{"namespace": "other"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Spec.ClusterSpec = tt.clusterSpec
+			rp.Spec.ChartRef.RawValues = tt.rawValues
+			r, _ := newTestRedpandaReconciler(t)
+			r.OperatorNamespace = "redpanda-system"
+
+			values, err := r.buildValues(context.Background(), rp)
+			require.NoError(t, err)
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(values.Raw, &got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	t.Run("changes the values SHA", func(t *testing.T) {
+		rp := testRedpanda()
+		r, _ := newTestRedpandaReconciler(t)
+		key := v1alpha1.GroupVersion.Group + valuesSHAPath
+
+		hr, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+		require.NoError(t, err)
+
+		r.OperatorNamespace = "redpanda-system"
+		hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+		require.NoError(t, err)
+		assert.NotEqual(t, hr.Annotations[key], hrTemplate.Annotations[key])
+	})
+}
+
+func TestDetermineOperatorNamespace(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "redpanda-system")
+	ns, err := DetermineOperatorNamespace("fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "redpanda-system", ns)
+
+	if _, err = os.Stat(serviceAccountNamespaceFile); err == nil {
+		t.Skip("running in a pod, the service account namespace takes precedence over the fallback")
+	}
+	t.Setenv("POD_NAMESPACE", "")
+	ns, err = DetermineOperatorNamespace("fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", ns)
+
+	_, err = DetermineOperatorNamespace("")
+	assert.ErrorContains(t, err, "POD_NAMESPACE")
+}
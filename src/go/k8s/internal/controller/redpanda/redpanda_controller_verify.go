@@ -0,0 +1,110 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// validateChartVerification checks that the chart of the given Redpanda can
+// be verified as configured by Spec.ChartRef.Verify. The source controller
+// only verifies charts of OCI repositories and silently skips others, so any
+// other repository is rejected rather than installing an unverified chart.
+func (r *RedpandaReconciler) validateChartVerification(ctx context.Context, rp *v1alpha1.Redpanda) error {
+	verify := rp.Spec.ChartRef.Verify
+	if verify == nil {
+		return nil
+	}
+
+	switch {
+	case rp.Spec.ChartRef.LocalChartPath != "":
+		return errors.New("charts of localChartPath cannot be verified")
+	case rp.Spec.ChartRef.ExistingRepositoryName != "":
+		var repo sourcev1.HelmRepository
+		key := types.NamespacedName{Namespace: rp.Namespace, Name: rp.Spec.ChartRef.ExistingRepositoryName}
+		if err := r.Client.Get(ctx, key, &repo); err != nil {
+			return fmt.Errorf("get HelmRepository '%s': %w", key, err)
+		}
+		if normalizedRepositoryType(&repo) != sourcev1.HelmRepositoryTypeOCI {
+			return fmt.Errorf("HelmRepository '%s' is not an OCI repository", key)
+		}
+	default:
+		urls := append([]string{rp.Spec.ChartRef.GetRepositoryURL()}, rp.Spec.ChartRef.FallbackRepositoryURLs...)
+		for _, url := range urls {
+			if helmRepositoryType(url) != sourcev1.HelmRepositoryTypeOCI {
+				return fmt.Errorf("repository %q is not an OCI repository", url)
+			}
+		}
+	}
+
+	if verify.SecretRef != nil {
+		var secret corev1.Secret
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rp.Namespace, Name: verify.SecretRef.Name}, &secret); err != nil {
+			return fmt.Errorf("verification secret '%s/%s': %w", rp.Namespace, verify.SecretRef.Name, err)
+		}
+	}
+	return nil
+}
+
+// reconcileChartVerification reflects the signature verification of the
+// HelmChart of the given HelmRelease in the ChartUnverified condition. It
+// returns false while the verification fails, the source controller then
+// holds back the chart, so it is neither installed nor upgraded.
+func (r *RedpandaReconciler) reconcileChartVerification(ctx context.Context, rp *v1alpha1.Redpanda, hr *helmv2beta1.HelmRelease) (bool, error) {
+	if rp.Spec.ChartRef.Verify == nil {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), ChartUnverifiedCondition)
+		return true, nil
+	}
+
+	var chart sourcev1.HelmChart
+	key := types.NamespacedName{Namespace: hr.Spec.Chart.GetNamespace(hr.Namespace), Name: hr.GetHelmChartName()}
+	if err := r.Client.Get(ctx, key, &chart); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the helm controller has not created the HelmChart yet
+			return true, nil
+		}
+		return false, fmt.Errorf("get HelmChart '%s': %w", key, err)
+	}
+
+	if cond := apimeta.FindStatusCondition(chart.Status.Conditions, sourcev1.SourceVerifiedCondition); cond != nil && cond.Status == metav1.ConditionFalse {
+		r.setChartUnverified(rp, fmt.Sprintf("HelmChart '%s' failed to verify the chart signature: %s", key, cond.Message))
+		return false, nil
+	}
+	apimeta.RemoveStatusCondition(rp.GetConditions(), ChartUnverifiedCondition)
+	return true, nil
+}
+
+// setChartUnverified sets the ChartUnverified condition, emitting an event
+// when its message changes.
+func (r *RedpandaReconciler) setChartUnverified(rp *v1alpha1.Redpanda, msg string) {
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, ChartUnverifiedCondition)
+	if cond == nil || cond.Message != msg {
+		r.event(rp, v1alpha1.ChartUnverifiedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityError, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               ChartUnverifiedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rp.Generation,
+		Reason:             v1alpha1.ChartUnverifiedReason,
+		Message:            msg,
+	})
+}
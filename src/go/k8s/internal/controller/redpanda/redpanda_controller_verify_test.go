@@ -0,0 +1,150 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestValidateChartVerification(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cosign-pub", Namespace: "default"}}
+	ociRepo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "oci", Namespace: "default"},
+		Spec:       sourcev1.HelmRepositorySpec{Type: sourcev1.HelmRepositoryTypeOCI},
+	}
+	httpRepo := &sourcev1.HelmRepository{ObjectMeta: metav1.ObjectMeta{Name: "http", Namespace: "default"}}
+
+	tests := []struct {
+		name     string
+		unset    bool
+		chartRef v1alpha1.ChartRef
+		err      string
+	}{
+		{name: "not set", unset: true},
+		{name: "oci", chartRef: v1alpha1.ChartRef{RepositoryURL: "oci://registry/charts"}},
+		{name: "default repository", err: `repository "https://charts.redpanda.com/" is not an OCI repository`},
+		{
+			name:     "http fallback",
+			chartRef: v1alpha1.ChartRef{RepositoryURL: "oci://registry/charts", FallbackRepositoryURLs: []string{"https://mirror/charts"}},
+			err:      `repository "https://mirror/charts" is not an OCI repository`,
+		},
+		{name: "local chart", chartRef: v1alpha1.ChartRef{LocalChartPath: "redpanda"}, err: "localChartPath"},
+		{name: "existing oci repository", chartRef: v1alpha1.ChartRef{ExistingRepositoryName: "oci"}},
+		{name: "existing http repository", chartRef: v1alpha1.ChartRef{ExistingRepositoryName: "http"}, err: "is not an OCI repository"},
+		{
+			name:     "missing secret",
+			chartRef: v1alpha1.ChartRef{RepositoryURL: "oci://registry/charts", Verify: &helmv2beta1.HelmChartTemplateVerification{Provider: "cosign", SecretRef: &meta.LocalObjectReference{Name: "missing"}}},
+			err:      "verification secret 'default/missing'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRedpandaReconciler(t, secret, ociRepo, httpRepo)
+			rp := testRedpanda()
+			rp.Spec.ChartRef = tt.chartRef
+			if !tt.unset && rp.Spec.ChartRef.Verify == nil {
+				rp.Spec.ChartRef.Verify = &helmv2beta1.HelmChartTemplateVerification{Provider: "cosign", SecretRef: &meta.LocalObjectReference{Name: "cosign-pub"}}
+			}
+
+			err := r.validateChartVerification(context.Background(), rp)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReconcileChartVerification(t *testing.T) {
+	hr := &helmv2beta1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+	chart := func(status metav1.ConditionStatus) *sourcev1.HelmChart {
+		return &sourcev1.HelmChart{
+			ObjectMeta: metav1.ObjectMeta{Name: hr.GetHelmChartName(), Namespace: "default"},
+			Status: sourcev1.HelmChartStatus{Conditions: []metav1.Condition{{
+				Type:    sourcev1.SourceVerifiedCondition,
+				Status:  status,
+				Reason:  sourcev1.VerificationError,
+				Message: "no matching signatures",
+			}}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		unset    bool
+		objs     []client.Object
+		verified bool
+	}{
+		{name: "not set", unset: true, objs: []client.Object{chart(metav1.ConditionFalse)}, verified: true},
+		{name: "no HelmChart yet", verified: true},
+		{name: "verified", objs: []client.Object{chart(metav1.ConditionTrue)}, verified: true},
+		{name: "verification failed", objs: []client.Object{chart(metav1.ConditionFalse)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, recorder := newTestRedpandaReconciler(t, tt.objs...)
+			rp := testRedpanda()
+			if !tt.unset {
+				rp.Spec.ChartRef.Verify = &helmv2beta1.HelmChartTemplateVerification{Provider: "cosign"}
+			}
+
+			verified, err := r.reconcileChartVerification(context.Background(), rp, hr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.verified, verified)
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, ChartUnverifiedCondition)
+			if tt.verified {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, v1alpha1.ChartUnverifiedReason, cond.Reason)
+			assert.Contains(t, cond.Message, "no matching signatures")
+			assert.Len(t, recorder.Events, 1)
+
+			// the event is not repeated while the message is unchanged
+			_, err = r.reconcileChartVerification(context.Background(), rp, hr)
+			require.NoError(t, err)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
+
+func TestHelmReleaseRequiresUpdateVerify(t *testing.T) {
+	r, _ := newTestRedpandaReconciler(t)
+	rp := testRedpanda()
+	rp.Spec.ChartRef.Verify = &helmv2beta1.HelmChartTemplateVerification{Provider: "cosign", SecretRef: &meta.LocalObjectReference{Name: "cosign-pub"}}
+
+	hrTemplate, err := r.createHelmReleaseFromTemplate(context.Background(), rp)
+	require.NoError(t, err)
+	assert.Equal(t, rp.Spec.ChartRef.Verify, hrTemplate.Spec.Chart.Spec.Verify)
+
+	hr := hrTemplate.DeepCopy()
+	assert.False(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+
+	hr.Spec.Chart.Spec.Verify = nil
+	assert.True(t, r.helmReleaseRequiresUpdate(context.Background(), hr, hrTemplate))
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// datadirVolumeName is the name of the volume claim template of the broker
+// data directory in the chart.
+const datadirVolumeName = "datadir"
+
+// desiredVolumeSize returns the storage.persistentVolume.size requested by
+// the given Redpanda, nil if it is not set.
+func desiredVolumeSize(rp *v1alpha1.Redpanda) (*resource.Quantity, error) {
+	spec := rp.Spec.ClusterSpec
+	if spec == nil || spec.Storage == nil || spec.Storage.PersistentVolume == nil || spec.Storage.PersistentVolume.Size == nil {
+		return nil, nil
+	}
+	size, err := resource.ParseQuantity(*spec.Storage.PersistentVolume.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage.persistentVolume.size %q: %w", *spec.Storage.PersistentVolume.Size, err)
+	}
+	return &size, nil
+}
+
+// brokerPVCs returns the data directory PVCs of the brokers of the given
+// Redpanda, including those of brokers that were scaled down.
+func (r *RedpandaReconciler) brokerPVCs(ctx context.Context, rp *v1alpha1.Redpanda) ([]corev1.PersistentVolumeClaim, error) {
+	var list corev1.PersistentVolumeClaimList
+	if err := r.Client.List(ctx, &list, client.InNamespace(rp.Namespace)); err != nil {
+		return nil, fmt.Errorf("list persistentvolumeclaims: %w", err)
+	}
+
+	prefix := fmt.Sprintf("%s-%s-", datadirVolumeName, internalServiceName(rp))
+	var pvcs []corev1.PersistentVolumeClaim
+	for i := range list.Items {
+		ordinal, ok := strings.CutPrefix(list.Items[i].Name, prefix)
+		if !ok {
+			continue
+		}
+		if _, err := strconv.Atoi(ordinal); err != nil {
+			continue
+		}
+		pvcs = append(pvcs, list.Items[i])
+	}
+	return pvcs, nil
+}
+
+// allowsVolumeExpansion reports whether the storage class with the given
+// name allows volume expansion.
+func (r *RedpandaReconciler) allowsVolumeExpansion(ctx context.Context, name string) (bool, error) {
+	if name == "" {
+		return false, nil
+	}
+	var sc storagev1.StorageClass
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, &sc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get storageclass %s: %w", name, err)
+	}
+	return ptr.Deref(sc.AllowVolumeExpansion, false), nil
+}
+
+// reconcileVolumeExpansion resizes the broker PVCs of the given Redpanda to
+// storage.persistentVolume.size when it grows and the Redpanda has the
+// expand-volumes annotation set to "true". Only PVCs of storage classes with
+// allowVolumeExpansion are resized. The VolumeExpansion condition reports the
+// progress until the capacity of every PVC reached the requested size.
+//
+// Volumes cannot shrink: a size smaller than the one of a PVC, like an
+// invalid size, is rejected with the VolumeExpansion condition and the
+// Redpanda is marked not ready, false is then returned and the HelmRelease
+// must be left untouched.
+func (r *RedpandaReconciler) reconcileVolumeExpansion(ctx context.Context, rp *v1alpha1.Redpanda) (bool, error) {
+	if rp.Annotations[v1alpha1.GroupVersion.Group+expandVolumesPath] != "true" {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), VolumeExpansionCondition)
+		return true, nil
+	}
+
+	desired, err := desiredVolumeSize(rp)
+	if err != nil {
+		r.setVolumeExpansion(rp, metav1.ConditionFalse, v1alpha1.InvalidVolumeSizeReason, err.Error())
+		v1alpha1.RedpandaNotReady(rp, v1alpha1.InvalidVolumeSizeReason, err.Error())
+		return false, nil
+	}
+	if desired == nil {
+		apimeta.RemoveStatusCondition(rp.GetConditions(), VolumeExpansionCondition)
+		return true, nil
+	}
+
+	pvcs, err := r.brokerPVCs(ctx, rp)
+	if err != nil {
+		return false, err
+	}
+
+	var larger, unsupported []string
+	for i := range pvcs {
+		requested := pvcs[i].Spec.Resources.Requests[corev1.ResourceStorage]
+		if requested.Cmp(*desired) > 0 {
+			larger = append(larger, fmt.Sprintf("%s (%s)", pvcs[i].Name, requested.String()))
+		}
+	}
+	if len(larger) > 0 {
+		msg := fmt.Sprintf("storage.persistentVolume.size %s is smaller than PVCs %s, volumes cannot shrink", desired.String(), strings.Join(larger, ", "))
+		r.setVolumeExpansion(rp, metav1.ConditionFalse, v1alpha1.VolumeShrinkRejectedReason, msg)
+		v1alpha1.RedpandaNotReady(rp, v1alpha1.VolumeShrinkRejectedReason, msg)
+		return false, nil
+	}
+
+	resized := 0
+	for i := range pvcs {
+		pvc := &pvcs[i]
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if requested.Cmp(*desired) < 0 {
+			expandable, err := r.allowsVolumeExpansion(ctx, ptr.Deref(pvc.Spec.StorageClassName, ""))
+			if err != nil {
+				return false, err
+			}
+			if !expandable {
+				unsupported = append(unsupported, pvc.Name)
+				continue
+			}
+
+			patch := client.MergeFrom(pvc.DeepCopy())
+			if pvc.Spec.Resources.Requests == nil {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *desired
+			if err = r.Client.Patch(ctx, pvc, patch); err != nil {
+				return false, fmt.Errorf("resize persistentvolumeclaim '%s/%s': %w", pvc.Namespace, pvc.Name, err)
+			}
+			r.event(rp, v1alpha1.VolumeResizeRequestedReason, rp.Status.LastAttemptedRevision, v1alpha1.EventSeverityInfo, fmt.Sprintf("PVC '%s/%s' resize from %s to %s requested", pvc.Namespace, pvc.Name, requested.String(), desired.String()))
+			continue
+		}
+
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(*desired) >= 0 {
+			resized++
+		}
+	}
+
+	switch {
+	case len(unsupported) > 0:
+		msg := fmt.Sprintf("cannot resize PVCs %s to %s, their storage class does not allow volume expansion", strings.Join(unsupported, ", "), desired.String())
+		r.setVolumeExpansion(rp, metav1.ConditionFalse, v1alpha1.VolumeExpansionNotSupportedReason, msg)
+	case resized < len(pvcs):
+		msg := fmt.Sprintf("%d of %d PVCs resized to %s", resized, len(pvcs), desired.String())
+		r.setVolumeExpansion(rp, metav1.ConditionTrue, v1alpha1.VolumeExpansionInProgressReason, msg)
+	default:
+		apimeta.RemoveStatusCondition(rp.GetConditions(), VolumeExpansionCondition)
+	}
+	return true, nil
+}
+
+// setVolumeExpansion sets the VolumeExpansion condition, emitting an event
+// when its message changes. Failures are emitted as errors.
+func (r *RedpandaReconciler) setVolumeExpansion(rp *v1alpha1.Redpanda, status metav1.ConditionStatus, reason, msg string) {
+	cond := apimeta.FindStatusCondition(rp.Status.Conditions, VolumeExpansionCondition)
+	if cond == nil || cond.Message != msg {
+		severity := v1alpha1.EventSeverityError
+		if reason == v1alpha1.VolumeExpansionInProgressReason {
+			severity = v1alpha1.EventSeverityInfo
+		}
+		r.event(rp, reason, rp.Status.LastAttemptedRevision, severity, msg)
+	}
+	apimeta.SetStatusCondition(rp.GetConditions(), metav1.Condition{
+		Type:               VolumeExpansionCondition,
+		Status:             status,
+		ObservedGeneration: rp.Generation,
+		Reason:             reason,
+		Message:            msg,
+	})
+}
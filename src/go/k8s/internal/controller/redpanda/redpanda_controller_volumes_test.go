@@ -0,0 +1,145 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestReconcileVolumeExpansion(t *testing.T) {
+	pvc := func(name, class, requested, capacity string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(class),
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(requested)},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			},
+		}
+	}
+	classes := []client.Object{
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "expandable"}, AllowVolumeExpansion: ptr.To(true)},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fixed"}},
+	}
+
+	tests := []struct {
+		name            string
+		disabled        bool
+		size            *string
+		pvcs            []client.Object
+		expectOK        bool
+		expectReason    string
+		expectRequested map[string]string
+	}{
+		{name: "disabled", disabled: true, size: ptr.To("20Gi"), pvcs: []client.Object{pvc("datadir-redpanda-0", "expandable", "10Gi", "10Gi")}, expectOK: true, expectRequested: map[string]string{"datadir-redpanda-0": "10Gi"}},
+		{name: "size not set", pvcs: []client.Object{pvc("datadir-redpanda-0", "expandable", "10Gi", "10Gi")}, expectOK: true},
+		{name: "invalid size", size: ptr.To("lots"), expectReason: v1alpha1.InvalidVolumeSizeReason},
+		{
+			name:            "shrink",
+			size:            ptr.To("5Gi"),
+			pvcs:            []client.Object{pvc("datadir-redpanda-0", "expandable", "10Gi", "10Gi")},
+			expectReason:    v1alpha1.VolumeShrinkRejectedReason,
+			expectRequested: map[string]string{"datadir-redpanda-0": "10Gi"},
+		},
+		{
+			name: "expand",
+			size: ptr.To("20Gi"),
+			pvcs: []client.Object{
+				pvc("datadir-redpanda-0", "expandable", "10Gi", "10Gi"),
+				pvc("datadir-redpanda-1", "expandable", "10Gi", "10Gi"),
+				pvc("datadir-other-0", "expandable", "10Gi", "10Gi"),
+				pvc("datadir-redpanda-backup", "expandable", "10Gi", "10Gi"),
+			},
+			expectOK:     true,
+			expectReason: v1alpha1.VolumeExpansionInProgressReason,
+			expectRequested: map[string]string{
+				"datadir-redpanda-0":      "20Gi",
+				"datadir-redpanda-1":      "20Gi",
+				"datadir-other-0":         "10Gi",
+				"datadir-redpanda-backup": "10Gi",
+			},
+		},
+		{
+			name:            "expansion pending",
+			size:            ptr.To("20Gi"),
+			pvcs:            []client.Object{pvc("datadir-redpanda-0", "expandable", "20Gi", "10Gi")},
+			expectOK:        true,
+			expectReason:    v1alpha1.VolumeExpansionInProgressReason,
+			expectRequested: map[string]string{"datadir-redpanda-0": "20Gi"},
+		},
+		{
+			name:            "expanded",
+			size:            ptr.To("20Gi"),
+			pvcs:            []client.Object{pvc("datadir-redpanda-0", "expandable", "20Gi", "20Gi")},
+			expectOK:        true,
+			expectRequested: map[string]string{"datadir-redpanda-0": "20Gi"},
+		},
+		{
+			name:            "expansion not allowed",
+			size:            ptr.To("20Gi"),
+			pvcs:            []client.Object{pvc("datadir-redpanda-0", "fixed", "10Gi", "10Gi")},
+			expectOK:        true,
+			expectReason:    v1alpha1.VolumeExpansionNotSupportedReason,
+			expectRequested: map[string]string{"datadir-redpanda-0": "10Gi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			if !tt.disabled {
+				rp.Annotations = map[string]string{v1alpha1.GroupVersion.Group + expandVolumesPath: "true"}
+			}
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{
+				Storage: &v1alpha1.Storage{PersistentVolume: &v1alpha1.PersistentVolume{Size: tt.size}},
+			}
+			r, _ := newTestRedpandaReconciler(t, append(tt.pvcs, classes...)...)
+
+			ok, err := r.reconcileVolumeExpansion(context.Background(), rp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectOK, ok)
+
+			cond := apimeta.FindStatusCondition(rp.Status.Conditions, VolumeExpansionCondition)
+			if tt.expectReason == "" {
+				assert.Nil(t, cond)
+			} else {
+				require.NotNil(t, cond)
+				assert.Equal(t, tt.expectReason, cond.Reason)
+			}
+			if !tt.expectOK {
+				assert.Equal(t, tt.expectReason, apimeta.FindStatusCondition(rp.Status.Conditions, meta.ReadyCondition).Reason)
+			}
+
+			for name, requested := range tt.expectRequested {
+				var got corev1.PersistentVolumeClaim
+				require.NoError(t, r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, &got))
+				assert.True(t, resource.MustParse(requested).Equal(got.Spec.Resources.Requests[corev1.ResourceStorage]), "%s requests %s", name, requested)
+			}
+		})
+	}
+}
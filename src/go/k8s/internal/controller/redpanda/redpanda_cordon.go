@@ -0,0 +1,98 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-helpers/storage/volume"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeCordonedReason is the reason of the events emitted when an action is
+// skipped because a Node is cordoned for maintenance.
+const NodeCordonedReason = "NodeCordoned"
+
+// cordonedBrokerNodes returns the cordoned Nodes the broker Pods of the given
+// StatefulSet run on. Missing Nodes are not reported, they are failures
+// rather than maintenance.
+func cordonedBrokerNodes(ctx context.Context, c client.Client, sts *appsv1.StatefulSet) ([]string, error) {
+	nodeNames, err := brokerNodeNames(ctx, c, sts)
+	if err != nil {
+		return nil, err
+	}
+
+	var cordoned []string
+	for _, name := range nodeNames {
+		var node corev1.Node
+		if err = c.Get(ctx, types.NamespacedName{Name: name}, &node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("could not get Node %q: %w", name, err)
+		}
+		if node.Spec.Unschedulable {
+			cordoned = append(cordoned, name)
+		}
+	}
+	return cordoned, nil
+}
+
+// brokerNodeNames returns the sorted names of the Nodes the broker Pods of the
+// given StatefulSet run on. Pending Pods, e.g. evicted by a drain, are
+// attributed to the Node their PVCs are bound to.
+func brokerNodeNames(ctx context.Context, c client.Client, sts *appsv1.StatefulSet) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of StatefulSet '%s/%s': %w", sts.Namespace, sts.Name, err)
+	}
+	var pods corev1.PodList
+	if err = c.List(ctx, &pods, client.InNamespace(sts.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("could not list pods: %w", err)
+	}
+
+	nodeNames := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != "" {
+			nodeNames[pod.Spec.NodeName] = true
+			continue
+		}
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim == nil {
+				continue
+			}
+			var pvc corev1.PersistentVolumeClaim
+			if err = c.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: vol.PersistentVolumeClaim.ClaimName}, &pvc); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("could not get PVC '%s/%s': %w", pod.Namespace, vol.PersistentVolumeClaim.ClaimName, err)
+			}
+			if node := pvc.Annotations[volume.AnnSelectedNode]; node != "" {
+				nodeNames[node] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(nodeNames))
+	for name := range nodeNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-helpers/storage/volume"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func cordonTestStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{K8sInstanceLabelKey: "redpanda"}},
+		},
+	}
+}
+
+func cordonTestPod(name, nodeName, claim string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{K8sInstanceLabelKey: "redpanda"}},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+	if claim != "" {
+		pod.Spec.Volumes = []corev1.Volume{{
+			Name:         "datadir",
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+		}}
+	}
+	return pod
+}
+
+func cordonTestNode(name string, cordoned bool) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Unschedulable: cordoned},
+	}
+}
+
+func TestCordonedBrokerNodes(t *testing.T) {
+	pendingPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "datadir-redpanda-1",
+			Namespace:   "default",
+			Annotations: map[string]string{volume.AnnSelectedNode: "node-b"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		objs     []client.Object
+		expected []string
+	}{
+		{
+			name: "no cordoned node",
+			objs: []client.Object{cordonTestPod("redpanda-0", "node-a", ""), cordonTestNode("node-a", false)},
+		},
+		{
+			name:     "running on cordoned node",
+			objs:     []client.Object{cordonTestPod("redpanda-0", "node-a", ""), cordonTestNode("node-a", true)},
+			expected: []string{"node-a"},
+		},
+		{
+			name: "pending on cordoned node",
+			objs: []client.Object{
+				cordonTestPod("redpanda-0", "node-a", ""), cordonTestNode("node-a", false),
+				cordonTestPod("redpanda-1", "", "datadir-redpanda-1"), pendingPVC, cordonTestNode("node-b", true),
+			},
+			expected: []string{"node-b"},
+		},
+		{
+			name: "missing node",
+			objs: []client.Object{cordonTestPod("redpanda-0", "node-a", "")},
+		},
+		{
+			name: "other pods ignored",
+			objs: []client.Object{
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-a"}},
+				cordonTestNode("node-a", true),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newTestRedpandaReconciler(t, tt.objs...)
+
+			nodes, err := cordonedBrokerNodes(context.Background(), r.Client, cordonTestStatefulSet())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, nodes)
+		})
+	}
+}
+
+func TestPauseForCordonedNodes(t *testing.T) {
+	tests := []struct {
+		name               string
+		actOnCordonedNodes bool
+		expected           bool
+	}{
+		{name: "paused", expected: true},
+		{name: "act on cordoned nodes", actOnCordonedNodes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestRedpandaReconciler(t, cordonTestPod("redpanda-0", "node-a", ""), cordonTestNode("node-a", true))
+			recorder := record.NewFakeRecorder(10)
+			r := &DecommissionReconciler{Client: c.Client, ActOnCordonedNodes: tt.actOnCordonedNodes, EventRecorder: recorder}
+
+			paused, err := r.pauseForCordonedNodes(context.Background(), cordonTestStatefulSet())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, paused)
+			if !tt.expected {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, "Normal NodeCordoned decommission paused, brokers of StatefulSet 'default/redpanda' run on cordoned nodes: node-a", <-recorder.Events)
+		})
+	}
+}
+
+func TestPauseForCordonedNode(t *testing.T) {
+	tests := []struct {
+		name               string
+		cordoned           bool
+		actOnCordonedNodes bool
+		registered         bool
+		deletedAgo         time.Duration
+		paused             bool
+		requeue            bool
+		event              bool
+	}{
+		{name: "not cordoned"},
+		{name: "cordoned and act on cordoned nodes", cordoned: true, actOnCordonedNodes: true},
+		{name: "cordoned", cordoned: true, paused: true, requeue: true, event: true},
+		{name: "cordoned and registered again", cordoned: true, registered: true, paused: true},
+		{name: "cordoned past the grace period", cordoned: true, deletedAgo: 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			if tt.registered {
+				objs = append(objs, cordonTestNode("node-a", false))
+			}
+			c, recorder := newTestRedpandaReconciler(t, objs...)
+			r := &RedpandaNodePVCReconciler{Client: c.Client, ActOnCordonedNodes: tt.actOnCordonedNodes, EventRecorder: recorder}
+
+			// the deletion is always let through
+			assert.True(t, r.recordCordonedNode(event.DeleteEvent{Object: cordonTestNode("node-a", tt.cordoned)}))
+			if deletedAt, ok := r.cordonedDeletions["node-a"]; ok {
+				r.cordonedDeletions["node-a"] = deletedAt.Add(-tt.deletedAgo)
+			}
+
+			result, paused, err := r.pauseForCordonedNode(context.Background(), "node-a")
+			require.NoError(t, err)
+			assert.Equal(t, tt.paused, paused)
+			assert.Equal(t, tt.requeue, result.Requeue)
+			if !tt.requeue {
+				assert.NotContains(t, r.cordonedDeletions, "node-a")
+			}
+			if !tt.event {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, NodeCordonedReason)
+		})
+	}
+}
+
+func TestIsInNodeSelectorScope(t *testing.T) {
+	labeledNode := func(name, pool string) *corev1.Node {
+		node := cordonTestNode(name, false)
+		node.Labels = map[string]string{"pool": pool}
+		return node
+	}
+
+	tests := []struct {
+		name         string
+		selector     string
+		templatePool string
+		objs         []client.Object
+		expected     bool
+	}{
+		{
+			name:     "no selector",
+			objs:     []client.Object{cordonTestPod("redpanda-0", "node-a", ""), labeledNode("node-a", "other")},
+			expected: true,
+		},
+		{
+			name:     "all nodes match",
+			selector: "pool=redpanda",
+			objs: []client.Object{
+				cordonTestPod("redpanda-0", "node-a", ""), labeledNode("node-a", "redpanda"),
+				cordonTestPod("redpanda-1", "node-b", ""), labeledNode("node-b", "redpanda"),
+			},
+			expected: true,
+		},
+		{
+			name:     "one node does not match",
+			selector: "pool=redpanda",
+			objs: []client.Object{
+				cordonTestPod("redpanda-0", "node-a", ""), labeledNode("node-a", "redpanda"),
+				cordonTestPod("redpanda-1", "node-b", ""), labeledNode("node-b", "other"),
+			},
+		},
+		{
+			name:     "missing node skipped",
+			selector: "pool=redpanda",
+			objs: []client.Object{
+				cordonTestPod("redpanda-0", "node-a", ""), labeledNode("node-a", "redpanda"),
+				cordonTestPod("redpanda-1", "node-b", ""),
+			},
+			expected: true,
+		},
+		{
+			name:         "no broker scheduled, template matches",
+			selector:     "pool=redpanda",
+			templatePool: "redpanda",
+			expected:     true,
+		},
+		{
+			name:         "no broker scheduled, template does not match",
+			selector:     "pool=redpanda",
+			templatePool: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestRedpandaReconciler(t, tt.objs...)
+			selector, err := labels.Parse(tt.selector)
+			require.NoError(t, err)
+			r := &DecommissionReconciler{Client: c.Client, NodeSelector: selector}
+
+			sts := cordonTestStatefulSet()
+			if tt.templatePool != "" {
+				sts.Spec.Template.Spec.NodeSelector = map[string]string{"pool": tt.templatePool}
+			}
+			inScope, err := r.isInNodeSelectorScope(context.Background(), sts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, inScope)
+		})
+	}
+}
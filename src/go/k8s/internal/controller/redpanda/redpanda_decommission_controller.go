@@ -17,19 +17,30 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	adminutils "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/admin"
 )
 
 // +kubebuilder:rbac:groups=cluster.redpanda.com,namespace=default,resources=redpandas,verbs=get;list;watch;
@@ -37,6 +48,7 @@ import (
 // +kubebuilder:rbac:groups=core,namespace=default,resources=persistentvolumeclaims,verbs=get;list;update;patch;delete;watch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;update;patch;watch
 // +kubebuilder:rbac:groups=apps,namespace=default,resources=statefulsets/status,verbs=update;patch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
 const (
 	DecommissionCondition = "DecommissionPhase"
@@ -55,12 +67,136 @@ var ConditionUnknown = appsv1.StatefulSetCondition{
 type DecommissionReconciler struct {
 	client.Client
 	OperatorMode bool
+
+	// DecommissionWaitInterval is the time to wait between polls of the
+	// decommission status while brokers are decommissioned, either after a
+	// downscale or through DecommissionBrokersAnnotation.
+	DecommissionWaitInterval time.Duration
+
+	// MaxConcurrentReconciles is the maximum number of StatefulSets reconciled
+	// in parallel. Defaults to 1.
+	MaxConcurrentReconciles int
+	// MaxInFlightDecommissions caps the number of decommissions actively
+	// worked on at the same time across all clusters. Zero means no cap.
+	MaxInFlightDecommissions int
+	// SafeMode turns the deletion of the PVCs of decommissioned brokers into
+	// a dry run, unless the StatefulSet has the allow-destructive-actions
+	// annotation.
+	SafeMode bool
+	// ActOnCordonedNodes lets decommissions proceed while broker Pods run on
+	// cordoned Nodes. By default they are paused until the Nodes are
+	// uncordoned, so that brokers down for maintenance are not decommissioned.
+	ActOnCordonedNodes bool
+	// NodeSelector scopes the controller to the brokers of a node pool, only
+	// StatefulSets whose brokers all run on Nodes matching it are
+	// decommissioned. Nil considers every StatefulSet.
+	NodeSelector labels.Selector
+	// EventRecorder reports decommissions paused by cordoned Nodes.
+	EventRecorder record.EventRecorder
+
+	// inFlight holds the StatefulSets with a decommission in progress, from
+	// the reconcile that starts it until it completes or fails.
+	inFlightMu sync.Mutex
+	inFlight   map[types.NamespacedName]struct{}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DecommissionReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&appsv1.StatefulSet{}).WithEventFilter(UpdateEventFilter).Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.StatefulSet{}).
+		WithEventFilter(UpdateEventFilter).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+
+	if r.OperatorMode {
+		// DecommissionBrokersAnnotation may be set on the Redpanda instead of
+		// the StatefulSet, so annotation changes there need to be picked up too.
+		b = b.Watches(
+			&v1alpha1.Redpanda{},
+			handler.EnqueueRequestsFromMapFunc(r.reconcileStatefulSetsForRedpanda),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{}),
+		)
+	}
+
+	return b.Complete(r)
+}
+
+func (r *DecommissionReconciler) reconcileStatefulSetsForRedpanda(ctx context.Context, rp client.Object) []reconcile.Request {
+	if _, ok := rp.GetAnnotations()[DecommissionBrokersAnnotation]; !ok {
+		return nil
+	}
+
+	releaseName := rp.GetName()
+	if redpanda, ok := rp.(*v1alpha1.Redpanda); ok {
+		releaseName = redpanda.GetReleaseName()
+	}
+
+	stsList := &appsv1.StatefulSetList{}
+	if err := r.Client.List(ctx, stsList, client.InNamespace(rp.GetNamespace()), client.MatchingLabels{K8sInstanceLabelKey: releaseName}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "could not list statefulsets for redpanda", "redpanda", rp.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(stsList.Items))
+	for i := range stsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&stsList.Items[i])})
+	}
+	return requests
+}
+
+// acquireDecommissionSlot tries to take one of the MaxInFlightDecommissions
+// slots for the given StatefulSet without blocking. A StatefulSet that holds
+// a slot already keeps it. When no slot is available the throttle metric is
+// incremented and false is returned.
+func (r *DecommissionReconciler) acquireDecommissionSlot(sts *appsv1.StatefulSet) bool {
+	if r.MaxInFlightDecommissions <= 0 {
+		return true
+	}
+
+	key := client.ObjectKeyFromObject(sts)
+
+	r.inFlightMu.Lock()
+	defer r.inFlightMu.Unlock()
+
+	if _, ok := r.inFlight[key]; ok {
+		return true
+	}
+	if len(r.inFlight) >= r.MaxInFlightDecommissions {
+		decommissionThrottled.WithLabelValues(key.String()).Inc()
+		return false
+	}
+	if r.inFlight == nil {
+		r.inFlight = make(map[types.NamespacedName]struct{})
+	}
+	r.inFlight[key] = struct{}{}
+	return true
+}
+
+// releaseDecommissionSlot frees the slot held by the given StatefulSet, if any.
+func (r *DecommissionReconciler) releaseDecommissionSlot(key types.NamespacedName) {
+	r.inFlightMu.Lock()
+	defer r.inFlightMu.Unlock()
+	delete(r.inFlight, key)
+}
+
+// trackDecommission releases the slot of the StatefulSet once the decommission
+// completed. The slot is kept while the decommission asks to be requeued or
+// failed, so that a retried decommission cannot be overtaken by another one.
+func (r *DecommissionReconciler) trackDecommission(sts *appsv1.StatefulSet, result ctrl.Result, err error) {
+	if err != nil || result.Requeue || result.RequeueAfter > 0 {
+		return
+	}
+	r.releaseDecommissionSlot(client.ObjectKeyFromObject(sts))
+}
+
+func (r *DecommissionReconciler) throttledResult() ctrl.Result {
+	return ctrl.Result{RequeueAfter: r.decommissionWaitInterval()}
+}
+
+func (r *DecommissionReconciler) decommissionWaitInterval() time.Duration {
+	if r.DecommissionWaitInterval <= 0 {
+		return defaultDecommissionWaitInterval
+	}
+	return r.DecommissionWaitInterval
 }
 
 func (r *DecommissionReconciler) Reconcile(c context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -72,25 +208,54 @@ func (r *DecommissionReconciler) Reconcile(c context.Context, req ctrl.Request)
 
 	sts := &appsv1.StatefulSet{}
 	if err := r.Client.Get(ctx, req.NamespacedName, sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.releaseDecommissionSlot(req.NamespacedName)
+		}
 		return ctrl.Result{}, fmt.Errorf("could not retrieve the statefulset: %w", err)
 	}
 
 	// Examine if the object is under deletion
 	if !sts.ObjectMeta.DeletionTimestamp.IsZero() {
 		log.Info(fmt.Sprintf("the statefulset %q is being deleted", req.NamespacedName))
+		r.releaseDecommissionSlot(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
+	inScope, err := r.isInNodeSelectorScope(ctx, sts)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !inScope {
+		Debugf(log, "brokers of statefulset %q do not all run on nodes matching the node selector %q, skipping", req.NamespacedName, r.NodeSelector.String())
+		r.releaseDecommissionSlot(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	if brokers := r.explicitDecommissionRequest(ctx, sts); brokers != "" {
+		if !r.acquireDecommissionSlot(sts) {
+			log.Info("maximum number of in-flight decommissions reached, will requeue")
+			return r.throttledResult(), nil
+		}
+		result, completed, err := r.reconcileExplicitDecommission(ctx, sts, brokers)
+		r.trackDecommission(sts, result, err)
+		// once every listed broker is gone the automatic downscale
+		// detection takes over again, even if the annotation is left behind
+		if err != nil || !completed {
+			return result, err
+		}
+	}
+
 	decomCondition, _ := getConditionOfTypeAndListWithout(DecommissionCondition, sts.Status.Conditions)
 	if decomCondition == nil {
 		decomCondition = &ConditionUnknown
 	}
 
-	var err error
 	var result ctrl.Result
 
 	switch decomCondition.Status {
 	case corev1.ConditionUnknown:
+		// the condition was reset, so no decommission is running anymore
+		r.releaseDecommissionSlot(req.NamespacedName)
 		// we have been notified, check to see if we need to decommission
 		result, err = r.verifyIfNeedDecommission(ctx, sts)
 	case corev1.ConditionFalse:
@@ -116,8 +281,14 @@ func (r *DecommissionReconciler) Reconcile(c context.Context, req ctrl.Request)
 		result = ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}
 	case corev1.ConditionTrue:
 		// condition updated to true, so we proceed to decommission
+		if !r.acquireDecommissionSlot(sts) {
+			log.Info("maximum number of in-flight decommissions reached, will requeue")
+			result = r.throttledResult()
+			break
+		}
 		log.Info("decommission started")
 		result, err = r.reconcileDecommission(ctx, sts)
+		r.trackDecommission(sts, result, err)
 	}
 
 	// Log reconciliation duration
@@ -170,7 +341,7 @@ func (r *DecommissionReconciler) verifyIfNeedDecommission(ctx context.Context, s
 
 		for i := range redpandaList.Items {
 			item := redpandaList.Items[i]
-			redpandaNameList = append(redpandaNameList, item.Name)
+			redpandaNameList = append(redpandaNameList, item.GetReleaseName())
 		}
 	} else {
 		releaseName, ok := os.LookupEnv(EnvHelmReleaseNameKey)
@@ -270,8 +441,10 @@ func (r *DecommissionReconciler) verifyIfNeedDecommission(ctx context.Context, s
 // 7. We are in steady state, proceed if we have more or the same number of downed nodes then are in allNodes registered minus requested
 // 8. For all the downed nodes, we get decommission-status, since we have waited for steady state we should be OK to do so
 // 9. Any failures captured will force us to requeue and try again.
-// 10. Attempt to delete the pvc and retain volumes if possible.
-// 11. Finally, reset condition state to unknown if we have been successful so far.
+// 10. Until every downed node reports its decommission as finished, we record the partitions left to move and
+// poll again after DecommissionWaitInterval.
+// 11. Attempt to delete the pvc and retain volumes if possible.
+// 12. Finally, reset condition state to unknown if we have been successful so far.
 //
 //nolint:funlen // length looks good
 func (r *DecommissionReconciler) reconcileDecommission(ctx context.Context, sts *appsv1.StatefulSet) (ctrl.Result, error) {
@@ -328,8 +501,12 @@ func (r *DecommissionReconciler) reconcileDecommission(ctx context.Context, sts
 		return ctrl.Result{}, nil
 	}
 
+	if paused, err := r.pauseForCordonedNodes(ctx, sts); err != nil || paused {
+		return ctrl.Result{RequeueAfter: r.decommissionWaitInterval()}, err
+	}
+
 	var errList error
-	// nolint:nestif // this is ok
+	var progress decommissionProgress
 	if len(health.AllNodes) > int(requestedReplicas) {
 		// we are in decommission mode
 
@@ -345,46 +522,29 @@ func (r *DecommissionReconciler) reconcileDecommission(ctx context.Context, sts
 		if len(health.NodesDown) >= (len(health.AllNodes) - int(requestedReplicas)) {
 			// TODO guard against intermittent situations where a node is coming up after it being brought down
 			// how do we get a signal of this, it would be easy if we can compare previous situation
-			for i := range health.NodesDown {
-				item := health.NodesDown[i]
-
-				// Now we check the decommission status before continuing
-				doDecommission := false
-				status, decommStatusError := adminAPI.DecommissionBrokerStatus(ctx, item)
-				if decommStatusError != nil {
-					Infof(log, "error found for decommission status: %s", decommStatusError.Error())
-					// nolint:gocritic // no need for a switch, this is ok
-					if strings.Contains(decommStatusError.Error(), "is not decommissioning") {
-						doDecommission = true
-					} else if strings.Contains(decommStatusError.Error(), "does not exists") {
-						Infof(log, "nodeID %d does not exist, skipping: %s", item, decommStatusError.Error())
-					} else {
-						errList = errors.Join(errList, fmt.Errorf("could get decommission status of broker: %w", decommStatusError))
-					}
-				}
-				Debugf(log, "decommission status: %v", status)
-
-				if doDecommission {
-					Infof(log, "all checks pass, attempting to decommission: %d", item)
-					// we want a clear signal to avoid 400s here, the suspicion here is an invalid transitional state
-					decomErr := adminAPI.DecommissionBroker(ctx, item)
-					if decomErr != nil && !strings.Contains(decomErr.Error(), "failed: Not Found") && !strings.Contains(decomErr.Error(), "failed: Bad Request") {
-						errList = errors.Join(errList, fmt.Errorf("could not decommission broker: %w", decomErr))
-					}
-				}
-			}
+			progress, errList = decommissionBrokers(ctx, adminAPI, health.NodesDown)
 		}
 	}
 
-	// now we check pvcs
-	if err = r.reconcilePVCs(log.WithName("DecommissionReconciler.reconcilePVCs"), ctx, sts, valuesMap); err != nil {
-		errList = errors.Join(errList, fmt.Errorf("could not reconcile pvcs: %w", err))
-	}
-
 	if errList != nil {
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, fmt.Errorf("found errors %w", errList)
 	}
 
+	// the brokers are only gone once their partitions have moved, poll the
+	// decommission status until then instead of assuming a fixed wait is enough
+	if !progress.finished() {
+		Infof(log, "waiting for partition movement: %s", progress)
+		if err = r.setDecommissionProgress(ctx, sts, progress); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: r.decommissionWaitInterval()}, nil
+	}
+
+	// now we check pvcs
+	if err = r.reconcilePVCs(log.WithName("DecommissionReconciler.reconcilePVCs"), ctx, sts, valuesMap); err != nil {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, fmt.Errorf("could not reconcile pvcs: %w", err)
+	}
+
 	// now we need to
 	patch := client.MergeFrom(sts.DeepCopy())
 	// create condition here
@@ -407,6 +567,98 @@ func (r *DecommissionReconciler) reconcileDecommission(ctx context.Context, sts
 	return ctrl.Result{}, nil
 }
 
+// decommissionProgress tracks the brokers whose decommission has not
+// finished yet.
+type decommissionProgress struct {
+	// Started holds the brokers whose decommission was started by this
+	// reconcile.
+	Started []int
+	// RemainingPartitions holds the number of partitions left to move away
+	// from each broker that is decommissioning.
+	RemainingPartitions map[int]int
+}
+
+func (p decommissionProgress) finished() bool {
+	return len(p.Started) == 0 && len(p.RemainingPartitions) == 0
+}
+
+func (p decommissionProgress) String() string {
+	parts := make([]string, 0, len(p.Started)+len(p.RemainingPartitions))
+	for _, id := range p.Started {
+		parts = append(parts, fmt.Sprintf("broker %d: decommission started", id))
+	}
+	ids := make([]int, 0, len(p.RemainingPartitions))
+	for id := range p.RemainingPartitions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("broker %d: %d partitions remaining", id, p.RemainingPartitions[id]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// decommissionBrokers starts the decommission of the given brokers where
+// needed and reports the ones that have not finished moving their partitions.
+// Brokers that are no longer registered are considered decommissioned.
+func decommissionBrokers(ctx context.Context, adminAPI adminutils.AdminAPIClient, ids []int) (decommissionProgress, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("decommissionBrokers")
+
+	progress := decommissionProgress{RemainingPartitions: map[int]int{}}
+	var errList error
+	for _, id := range ids {
+		status, err := adminAPI.DecommissionBrokerStatus(ctx, id)
+		if err != nil {
+			Infof(log, "error found for decommission status: %s", err.Error())
+			switch {
+			case strings.Contains(err.Error(), "is not decommissioning"):
+				Infof(log, "all checks pass, attempting to decommission: %d", id)
+				// we want a clear signal to avoid 400s here, the suspicion here is an invalid transitional state
+				decomErr := adminAPI.DecommissionBroker(ctx, id)
+				if decomErr != nil && !strings.Contains(decomErr.Error(), "failed: Not Found") && !strings.Contains(decomErr.Error(), "failed: Bad Request") {
+					errList = errors.Join(errList, fmt.Errorf("could not decommission broker: %w", decomErr))
+					continue
+				}
+				progress.Started = append(progress.Started, id)
+			case strings.Contains(err.Error(), "does not exists"):
+				Infof(log, "nodeID %d does not exist, skipping: %s", id, err.Error())
+			default:
+				errList = errors.Join(errList, fmt.Errorf("could get decommission status of broker: %w", err))
+			}
+			continue
+		}
+		Debugf(log, "decommission status: %v", status)
+
+		if !status.Finished {
+			progress.RemainingPartitions[id] = len(status.Partitions)
+		}
+	}
+	return progress, errList
+}
+
+// setDecommissionProgress records the progress in the message of the active
+// decommission condition.
+func (r *DecommissionReconciler) setDecommissionProgress(ctx context.Context, sts *appsv1.StatefulSet, progress decommissionProgress) error {
+	msg := fmt.Sprintf("%s %s", DecomConditionTrueReasonMsg, progress)
+
+	patch := client.MergeFrom(sts.DeepCopy())
+	updated := false
+	for i := range sts.Status.Conditions {
+		c := &sts.Status.Conditions[i]
+		if c.Type == DecommissionCondition && c.Status == corev1.ConditionTrue && c.Message != msg {
+			c.Message = msg
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := r.Client.Status().Patch(ctx, sts, patch); err != nil {
+		return fmt.Errorf("unable to update sts status %q with decommission progress: %w", sts.Name, err)
+	}
+	return nil
+}
+
 func (r *DecommissionReconciler) reconcilePVCs(log logr.Logger, ctx context.Context, sts *appsv1.StatefulSet, valuesMap map[string]interface{}) error {
 	Infof(log, "reconciling: %s/%s", sts.Namespace, sts.Name)
 
@@ -492,15 +744,16 @@ func (r *DecommissionReconciler) reconcilePVCs(log logr.Logger, ctx context.Cont
 
 	Infof(log, "pvc name list, binding processed: %+v", pvcsBound)
 
-	if pvcErrorList := r.tryToDeletePVC(log, ctx, ptr.Deref(sts.Spec.Replicas, 0), pvcsBound, pvcList); pvcErrorList != nil {
+	if pvcErrorList := r.tryToDeletePVC(log, ctx, sts, pvcsBound, pvcList); pvcErrorList != nil {
 		return fmt.Errorf("errors found: %w", pvcErrorList)
 	}
 
 	return nil
 }
 
-func (r *DecommissionReconciler) tryToDeletePVC(log logr.Logger, ctx context.Context, replicas int32, isBoundList map[string]bool, pvcList *corev1.PersistentVolumeClaimList) error {
+func (r *DecommissionReconciler) tryToDeletePVC(log logr.Logger, ctx context.Context, sts *appsv1.StatefulSet, isBoundList map[string]bool, pvcList *corev1.PersistentVolumeClaimList) error {
 	var pvcErrorList error
+	replicas := ptr.Deref(sts.Spec.Replicas, 0)
 
 	// here we sort the list of items, should be ordered by ordinal, and we remove the last first so we sort first then remove
 	// only the first n matching the number of replicas
@@ -527,6 +780,10 @@ func (r *DecommissionReconciler) tryToDeletePVC(log logr.Logger, ctx context.Con
 			continue
 		}
 
+		if !destructiveActionAllowed(log, r.SafeMode, sts, fmt.Sprintf("delete PVC %s", item.Name)) {
+			continue
+		}
+
 		// we are being deleted, before moving forward, try to update PV to avoid data loss
 		bestTrySetRetainPV(r.Client, log, ctx, item.Spec.VolumeName, item.Namespace)
 
@@ -541,6 +798,59 @@ func (r *DecommissionReconciler) tryToDeletePVC(log logr.Logger, ctx context.Con
 	return pvcErrorList
 }
 
+// pauseForCordonedNodes reports whether the decommission of the given
+// StatefulSet must wait because some of its brokers run on cordoned Nodes,
+// their brokers being down for maintenance cannot be told apart from the
+// brokers removed by a downscale. An informational event is emitted.
+func (r *DecommissionReconciler) pauseForCordonedNodes(ctx context.Context, sts *appsv1.StatefulSet) (bool, error) {
+	if r.ActOnCordonedNodes {
+		return false, nil
+	}
+
+	nodes, err := cordonedBrokerNodes(ctx, r.Client, sts)
+	if err != nil || len(nodes) == 0 {
+		return false, err
+	}
+
+	msg := fmt.Sprintf("decommission paused, brokers of StatefulSet '%s/%s' run on cordoned nodes: %s", sts.Namespace, sts.Name, strings.Join(nodes, ", "))
+	ctrl.LoggerFrom(ctx).WithName("DecommissionReconciler.pauseForCordonedNodes").Info(msg)
+	if r.EventRecorder != nil {
+		r.EventRecorder.Event(sts, corev1.EventTypeNormal, NodeCordonedReason, msg)
+	}
+	return true, nil
+}
+
+// isInNodeSelectorScope reports whether all brokers of the given StatefulSet
+// run on Nodes matching NodeSelector. Without brokers, e.g. while they are
+// all rescheduled, the nodeSelector of the Pod template decides.
+func (r *DecommissionReconciler) isInNodeSelectorScope(ctx context.Context, sts *appsv1.StatefulSet) (bool, error) {
+	if r.NodeSelector == nil || r.NodeSelector.Empty() {
+		return true, nil
+	}
+
+	nodeNames, err := brokerNodeNames(ctx, r.Client, sts)
+	if err != nil {
+		return false, err
+	}
+	if len(nodeNames) == 0 {
+		return r.NodeSelector.Matches(labels.Set(sts.Spec.Template.Spec.NodeSelector)), nil
+	}
+	for _, name := range nodeNames {
+		var node corev1.Node
+		if err = r.Client.Get(ctx, types.NamespacedName{Name: name}, &node); err != nil {
+			if apierrors.IsNotFound(err) {
+				// a deleted Node is handled by the node PVC controller
+				continue
+			}
+			return false, fmt.Errorf("could not get Node %q: %w", name, err)
+		}
+		if !r.NodeSelector.Matches(labels.Set(node.Labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func isNameInList(name string, keys []string) bool {
 	for i := range keys {
 		if name == keys[i] {
@@ -550,7 +860,7 @@ func isNameInList(name string, keys []string) bool {
 	return false
 }
 
-func buildAdminAPI(releaseName, namespace string, replicas int32, values map[string]interface{}) (*admin.AdminAPI, error) {
+func buildAdminAPI(releaseName, namespace string, replicas int32, values map[string]interface{}) (adminutils.AdminAPIClient, error) {
 	tlsEnabled, ok, err := unstructured.NestedBool(values, "tls", "enabled")
 	if !ok || err != nil {
 		// probably not a correct helm release, bail
@@ -579,7 +889,11 @@ func buildAdminAPI(releaseName, namespace string, replicas int32, values map[str
 	}
 
 	// TODO we do not tls, but we may need sasl items here.
-	return admin.NewAdminAPI(urls, admin.BasicCredentials{}, tlsConfig)
+	adminAPI, err := admin.NewAdminAPI(urls, admin.BasicCredentials{}, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return adminutils.NewInstrumentedAdminAPI(adminAPI, namespace+"/"+releaseName), nil
 }
 
 func createBrokerURLs(release, namespace string, replicas int32, values map[string]interface{}) ([]string, error) {
@@ -615,7 +929,7 @@ func createBrokerURLs(release, namespace string, replicas int32, values map[stri
 	return brokerList, nil
 }
 
-func watchClusterHealth(ctx context.Context, adminAPI *admin.AdminAPI) (*admin.ClusterHealthOverview, error) {
+func watchClusterHealth(ctx context.Context, adminAPI adminutils.AdminAPIClient) (*admin.ClusterHealthOverview, error) {
 	start := time.Now()
 	stop := start.Add(60 * time.Second)
 
@@ -0,0 +1,136 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	adminutils "github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/admin"
+)
+
+func TestDecommissionSlots(t *testing.T) {
+	sts := func(name string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	first, second := sts("first"), sts("second")
+
+	r := &DecommissionReconciler{MaxInFlightDecommissions: 1}
+
+	assert.True(t, r.acquireDecommissionSlot(first))
+	// still in progress, the slot is kept across reconciles
+	r.trackDecommission(first, ctrl.Result{RequeueAfter: 10 * time.Second}, nil)
+	assert.True(t, r.acquireDecommissionSlot(first))
+	assert.False(t, r.acquireDecommissionSlot(second))
+
+	// a failed decommission keeps its slot until it is retried
+	r.trackDecommission(first, ctrl.Result{RequeueAfter: 30 * time.Second}, errors.New("admin api unavailable"))
+	assert.False(t, r.acquireDecommissionSlot(second))
+	assert.True(t, r.acquireDecommissionSlot(first))
+
+	// a completed one gives it up
+	r.trackDecommission(first, ctrl.Result{}, nil)
+	assert.True(t, r.acquireDecommissionSlot(second))
+	assert.False(t, r.acquireDecommissionSlot(first))
+
+	// deleted statefulsets are released as well
+	r.releaseDecommissionSlot(client.ObjectKeyFromObject(second))
+	assert.True(t, r.acquireDecommissionSlot(first))
+}
+
+func TestDecommissionSlotsUnlimited(t *testing.T) {
+	r := &DecommissionReconciler{}
+	for _, name := range []string{"a", "b", "c"} {
+		assert.True(t, r.acquireDecommissionSlot(&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name}}))
+	}
+}
+
+// decommissionStatusAdminAPI serves decommission statuses from a map, where a
+// missing entry means the broker is not decommissioning yet.
+type decommissionStatusAdminAPI struct {
+	adminutils.AdminAPIClient
+	statuses map[int]admin.DecommissionStatusResponse
+	started  []int
+}
+
+func (a *decommissionStatusAdminAPI) DecommissionBrokerStatus(_ context.Context, id int) (admin.DecommissionStatusResponse, error) {
+	if id < 0 {
+		return admin.DecommissionStatusResponse{}, fmt.Errorf("node %d does not exists", id)
+	}
+	status, ok := a.statuses[id]
+	if !ok {
+		return admin.DecommissionStatusResponse{}, fmt.Errorf("node %d is not decommissioning", id)
+	}
+	return status, nil
+}
+
+func (a *decommissionStatusAdminAPI) DecommissionBroker(_ context.Context, id int) error {
+	a.started = append(a.started, id)
+	return nil
+}
+
+func TestDecommissionBrokers(t *testing.T) {
+	adminAPI := &decommissionStatusAdminAPI{statuses: map[int]admin.DecommissionStatusResponse{
+		3: {Finished: true},
+		4: {Partitions: make([]admin.DecommissionPartitions, 12)},
+	}}
+
+	progress, err := decommissionBrokers(context.Background(), adminAPI, []int{3, 4, 5, -1})
+	require.NoError(t, err)
+	assert.False(t, progress.finished())
+	assert.Equal(t, []int{5}, adminAPI.started)
+	assert.Equal(t, "broker 5: decommission started, broker 4: 12 partitions remaining", progress.String())
+
+	adminAPI.statuses[4] = admin.DecommissionStatusResponse{Finished: true}
+	adminAPI.statuses[5] = admin.DecommissionStatusResponse{Finished: true}
+	progress, err = decommissionBrokers(context.Background(), adminAPI, []int{3, 4, 5})
+	require.NoError(t, err)
+	assert.True(t, progress.finished())
+}
+
+func TestSetDecommissionProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"},
+		Status: appsv1.StatefulSetStatus{Conditions: []appsv1.StatefulSetCondition{{
+			Type:    DecommissionCondition,
+			Status:  corev1.ConditionTrue,
+			Message: DecomConditionTrueReasonMsg,
+		}}},
+	}
+	r := &DecommissionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(sts).WithStatusSubresource(sts).Build(),
+	}
+
+	progress := decommissionProgress{RemainingPartitions: map[int]int{4: 7}}
+	require.NoError(t, r.setDecommissionProgress(context.Background(), sts, progress))
+
+	var stored appsv1.StatefulSet
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(sts), &stored))
+	require.Len(t, stored.Status.Conditions, 1)
+	assert.Equal(t, corev1.ConditionTrue, stored.Status.Conditions[0].Status)
+	assert.Equal(t, DecomConditionTrueReasonMsg+" broker 4: 7 partitions remaining", stored.Status.Conditions[0].Message)
+}
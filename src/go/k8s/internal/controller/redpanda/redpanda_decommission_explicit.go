@@ -0,0 +1,309 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+const (
+	// DecommissionBrokersAnnotation holds a comma separated list of broker node IDs
+	// that should be decommissioned, one at a time, regardless of the automatic
+	// downscale detection.
+	DecommissionBrokersAnnotation = "cluster.redpanda.com/decommission-brokers"
+
+	ExplicitDecommissionCondition = "ExplicitDecommission"
+
+	defaultDecommissionWaitInterval = 10 * time.Second
+)
+
+// explicitDecommissionProgress tracks where each requested broker is in the
+// decommission process.
+type explicitDecommissionProgress struct {
+	Completed  []int
+	InProgress []int
+	Pending    []int
+}
+
+func (p explicitDecommissionProgress) String() string {
+	return fmt.Sprintf("completed: %v, in progress: %v, pending: %v", p.Completed, p.InProgress, p.Pending)
+}
+
+// parseBrokerIDs parses the value of the DecommissionBrokersAnnotation. Duplicates
+// are removed and the result is sorted so brokers are processed in a stable order.
+func parseBrokerIDs(value string) ([]int, error) {
+	seen := make(map[int]struct{})
+	ids := make([]int, 0)
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		id, err := strconv.Atoi(s)
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("invalid broker id %q in %q annotation", s, DecommissionBrokersAnnotation)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// unregisteredBrokers returns the given broker ids that are not registered
+// in the cluster.
+func unregisteredBrokers(ids, allNodes []int) []int {
+	registered := make(map[int]struct{}, len(allNodes))
+	for _, n := range allNodes {
+		registered[n] = struct{}{}
+	}
+
+	var unknown []int
+	for _, id := range ids {
+		if _, ok := registered[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	return unknown
+}
+
+// validateExplicitDecommission makes sure that removing the requested brokers
+// keeps enough brokers to satisfy the replication factor. Brokers that are no
+// longer registered were decommissioned already and do not count.
+func validateExplicitDecommission(ids, allNodes []int, replicationFactor int) error {
+	toRemove := len(ids) - len(unregisteredBrokers(ids, allNodes))
+	if remaining := len(allNodes) - toRemove; remaining < replicationFactor {
+		return fmt.Errorf("decommissioning brokers %v would leave %d brokers, which is less than the replication factor %d", ids, remaining, replicationFactor)
+	}
+	return nil
+}
+
+// replicationFactorFromValues returns the default_topic_replications of the
+// chart values, 1 if unset. Topics created with a higher replication factor
+// are not taken into account, the cluster is not queried for them.
+func replicationFactorFromValues(values map[string]interface{}) int {
+	rf, ok, err := unstructured.NestedFloat64(values, "config", "cluster", "default_topic_replications")
+	if !ok || err != nil || rf < 1 {
+		return 1
+	}
+	return int(rf)
+}
+
+// reconcileExplicitDecommission decommissions the brokers listed in the
+// DecommissionBrokersAnnotation one at a time:
+// 1. Parse the requested ids and validate them against the registered brokers,
+// a new request listing brokers that are not registered is rejected
+// 2. Brokers of an accepted request that are no longer registered are considered completed
+// 3. The first remaining broker is decommissioned, if it is not already decommissioning
+// 4. We requeue every DecommissionWaitInterval until the broker is gone, then move on
+// Progress is recorded in the ExplicitDecommission condition of the statefulset,
+// a request the condition records as completed is not reconciled again.
+// The returned bool reports whether every requested broker is decommissioned.
+func (r *DecommissionReconciler) reconcileExplicitDecommission(ctx context.Context, sts *appsv1.StatefulSet, annotation string) (ctrl.Result, bool, error) {
+	log := ctrl.LoggerFrom(ctx).WithName("DecommissionReconciler.reconcileExplicitDecommission")
+
+	ids, err := parseBrokerIDs(annotation)
+	if err != nil {
+		return ctrl.Result{}, false, r.setExplicitDecommissionCondition(ctx, sts, corev1.ConditionFalse, "InvalidBrokerList", err.Error())
+	}
+	if len(ids) == 0 {
+		return ctrl.Result{}, true, nil
+	}
+	if explicitDecommissionCompleted(sts, ids) {
+		Debugf(log, "explicit decommission of %s/%s already completed: %s", sts.Namespace, sts.Name, annotation)
+		return ctrl.Result{}, true, nil
+	}
+	Infof(log, "explicit decommission requested for %s/%s: %s", sts.Namespace, sts.Name, annotation)
+
+	releaseName, ok := sts.Labels[K8sInstanceLabelKey]
+	if !ok {
+		log.Info("could not find instance label to retrieve releaseName")
+		return ctrl.Result{}, true, nil
+	}
+
+	valuesMap, err := getHelmValues(log, releaseName, sts.Namespace)
+	if err != nil {
+		return ctrl.Result{}, false, fmt.Errorf("could not retrieve values, probably not a valid managed helm release: %w", err)
+	}
+
+	adminAPI, err := buildAdminAPI(releaseName, sts.Namespace, ptr.Deref(sts.Spec.Replicas, 0), valuesMap)
+	if err != nil {
+		return ctrl.Result{}, false, fmt.Errorf("could not reconcile, error creating adminAPI: %w", err)
+	}
+
+	health, err := adminAPI.GetHealthOverview(ctx)
+	if err != nil {
+		return ctrl.Result{}, false, fmt.Errorf("could not make request to admin-api: %w", err)
+	}
+
+	// brokers of an accepted request leave the cluster as they are
+	// decommissioned, only a new request must list registered brokers
+	if !explicitDecommissionAccepted(sts, ids) {
+		if unknown := unregisteredBrokers(ids, health.AllNodes); len(unknown) > 0 {
+			return ctrl.Result{}, false, r.setExplicitDecommissionCondition(ctx, sts, corev1.ConditionFalse, "InvalidBrokerList", fmt.Sprintf("brokers %v are not registered in the cluster", unknown))
+		}
+	}
+
+	if err = validateExplicitDecommission(ids, health.AllNodes, replicationFactorFromValues(valuesMap)); err != nil {
+		return ctrl.Result{}, false, r.setExplicitDecommissionCondition(ctx, sts, corev1.ConditionFalse, "ReplicationFactorViolation", err.Error())
+	}
+
+	registered := make(map[int]struct{}, len(health.AllNodes))
+	for _, n := range health.AllNodes {
+		registered[n] = struct{}{}
+	}
+
+	var progress explicitDecommissionProgress
+	for _, id := range ids {
+		if _, ok := registered[id]; !ok {
+			progress.Completed = append(progress.Completed, id)
+			continue
+		}
+		if len(progress.InProgress) > 0 {
+			progress.Pending = append(progress.Pending, id)
+			continue
+		}
+		progress.InProgress = append(progress.InProgress, id)
+
+		if _, statusErr := adminAPI.DecommissionBrokerStatus(ctx, id); statusErr != nil {
+			if !strings.Contains(statusErr.Error(), "is not decommissioning") {
+				return ctrl.Result{}, false, fmt.Errorf("could get decommission status of broker %d: %w", id, statusErr)
+			}
+			Infof(log, "starting decommission of broker %d", id)
+			if decomErr := adminAPI.DecommissionBroker(ctx, id); decomErr != nil {
+				return ctrl.Result{}, false, fmt.Errorf("could not decommission broker %d: %w", id, decomErr)
+			}
+		}
+	}
+
+	if len(progress.InProgress) == 0 {
+		return ctrl.Result{}, true, r.setExplicitDecommissionCondition(ctx, sts, corev1.ConditionTrue, "DecommissionCompleted", progress.String())
+	}
+
+	if err = r.setExplicitDecommissionCondition(ctx, sts, corev1.ConditionUnknown, "DecommissionInProgress", progress.String()); err != nil {
+		return ctrl.Result{}, false, err
+	}
+	return ctrl.Result{RequeueAfter: r.decommissionWaitInterval()}, false, nil
+}
+
+// explicitDecommissionCompleted reports whether the ExplicitDecommission
+// condition of the statefulset records that every given broker is
+// decommissioned.
+func explicitDecommissionCompleted(sts *appsv1.StatefulSet, ids []int) bool {
+	cond, _ := getConditionOfTypeAndListWithout(ExplicitDecommissionCondition, sts.Status.Conditions)
+	return cond != nil &&
+		cond.Status == corev1.ConditionTrue &&
+		cond.Reason == "DecommissionCompleted" &&
+		cond.Message == explicitDecommissionProgress{Completed: ids}.String()
+}
+
+// explicitDecommissionAccepted reports whether the ExplicitDecommission
+// condition of the statefulset records the given brokers as a request in
+// progress, which was validated while all of them were registered.
+func explicitDecommissionAccepted(sts *appsv1.StatefulSet, ids []int) bool {
+	cond, _ := getConditionOfTypeAndListWithout(ExplicitDecommissionCondition, sts.Status.Conditions)
+	if cond == nil || cond.Reason != "DecommissionInProgress" {
+		return false
+	}
+	// brokers are decommissioned one at a time in order
+	for i := range ids {
+		if cond.Message == (explicitDecommissionProgress{Completed: ids[:i], InProgress: ids[i : i+1], Pending: ids[i+1:]}).String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DecommissionReconciler) setExplicitDecommissionCondition(ctx context.Context, sts *appsv1.StatefulSet, status corev1.ConditionStatus, reason, message string) error {
+	oldCondition, conditions := getConditionOfTypeAndListWithout(ExplicitDecommissionCondition, sts.Status.Conditions)
+	if oldCondition != nil && oldCondition.Status == status && oldCondition.Reason == reason && oldCondition.Message == message {
+		return nil
+	}
+
+	patch := client.MergeFrom(sts.DeepCopy())
+	sts.Status.Conditions = append(conditions, appsv1.StatefulSetCondition{
+		Type:               ExplicitDecommissionCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := r.Client.Status().Patch(ctx, sts, patch); err != nil {
+		return fmt.Errorf("unable to update sts status %q with condition: %w", sts.Name, err)
+	}
+	return nil
+}
+
+// explicitDecommissionRequest returns the explicit broker list for the statefulset,
+// either from the statefulset itself or from the owning Redpanda resource.
+func (r *DecommissionReconciler) explicitDecommissionRequest(ctx context.Context, sts *appsv1.StatefulSet) string {
+	if v, ok := sts.Annotations[DecommissionBrokersAnnotation]; ok {
+		return v
+	}
+	if !r.OperatorMode {
+		return ""
+	}
+	rp, err := r.redpandaForStatefulSet(ctx, sts)
+	if err != nil || rp == nil {
+		return ""
+	}
+	return rp.Annotations[DecommissionBrokersAnnotation]
+}
+
+// redpandaForStatefulSet returns the Redpanda whose Helm release deployed the
+// statefulset, or nil. It is found by the name of the HelmRelease, from the
+// label the helm-controller sets on the resources of a release, or else by
+// the Helm release name, which may differ from the name of the Redpanda.
+func (r *DecommissionReconciler) redpandaForStatefulSet(ctx context.Context, sts *appsv1.StatefulSet) (*v1alpha1.Redpanda, error) {
+	if hrName, ok := sts.Labels[helmv2beta1.GroupVersion.Group+"/name"]; ok {
+		rp := &v1alpha1.Redpanda{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: sts.Namespace, Name: hrName}, rp)
+		if err == nil && rp.GetHelmReleaseName() == hrName {
+			return rp, nil
+		}
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+
+	releaseName, ok := sts.Labels[K8sInstanceLabelKey]
+	if !ok {
+		return nil, nil
+	}
+	var rps v1alpha1.RedpandaList
+	if err := r.Client.List(ctx, &rps, client.InNamespace(sts.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range rps.Items {
+		if rps.Items[i].GetReleaseName() == releaseName {
+			return &rps.Items[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestParseBrokerIDs(t *testing.T) {
+	ids, err := parseBrokerIDs(" 4, 3,4,,")
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, ids)
+
+	_, err = parseBrokerIDs("1,two")
+	assert.Error(t, err)
+
+	_, err = parseBrokerIDs("-1")
+	assert.Error(t, err)
+}
+
+func TestValidateExplicitDecommission(t *testing.T) {
+	tcs := []struct {
+		name     string
+		ids      []int
+		allNodes []int
+		rf       int
+		wantErr  bool
+	}{
+		{"keeps enough brokers", []int{4}, []int{0, 1, 2, 3, 4}, 3, false},
+		{"already removed brokers do not count", []int{3, 4}, []int{0, 1, 2, 3}, 3, false},
+		{"violates replication factor", []int{2, 3, 4}, []int{0, 1, 2, 3, 4}, 3, true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateExplicitDecommission(tc.ids, tc.allNodes, tc.rf)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUnregisteredBrokers(t *testing.T) {
+	assert.Empty(t, unregisteredBrokers([]int{3, 4}, []int{0, 1, 2, 3, 4}))
+	assert.Equal(t, []int{42}, unregisteredBrokers([]int{3, 42}, []int{0, 1, 2, 3, 4}))
+}
+
+func TestExplicitDecommissionAccepted(t *testing.T) {
+	tcs := []struct {
+		name     string
+		reason   string
+		progress explicitDecommissionProgress
+		ids      []int
+		expected bool
+	}{
+		{"first broker in progress", "DecommissionInProgress", explicitDecommissionProgress{InProgress: []int{3}, Pending: []int{4}}, []int{3, 4}, true},
+		{"last broker in progress", "DecommissionInProgress", explicitDecommissionProgress{Completed: []int{3}, InProgress: []int{4}}, []int{3, 4}, true},
+		{"other request", "DecommissionInProgress", explicitDecommissionProgress{InProgress: []int{3}}, []int{3, 4}, false},
+		{"rejected request", "InvalidBrokerList", explicitDecommissionProgress{InProgress: []int{3}, Pending: []int{4}}, []int{3, 4}, false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{}
+			sts.Status.Conditions = []appsv1.StatefulSetCondition{{
+				Type:    ExplicitDecommissionCondition,
+				Status:  corev1.ConditionUnknown,
+				Reason:  tc.reason,
+				Message: tc.progress.String(),
+			}}
+			assert.Equal(t, tc.expected, explicitDecommissionAccepted(sts, tc.ids))
+		})
+	}
+
+	assert.False(t, explicitDecommissionAccepted(&appsv1.StatefulSet{}, []int{3}))
+}
+
+func TestReplicationFactorFromValues(t *testing.T) {
+	assert.Equal(t, 1, replicationFactorFromValues(map[string]interface{}{}))
+	assert.Equal(t, 3, replicationFactorFromValues(map[string]interface{}{
+		"config": map[string]interface{}{
+			"cluster": map[string]interface{}{"default_topic_replications": float64(3)},
+		},
+	}))
+}
+
+func TestReconcileStatefulSetsForRedpanda(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	sts := func(namespace, instance string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
+			Name:      instance,
+			Namespace: namespace,
+			Labels:    map[string]string{K8sInstanceLabelKey: instance},
+		}}
+	}
+
+	r := &DecommissionReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			sts("default", "redpanda"),
+			sts("default", "other"),
+			sts("other", "redpanda"),
+		).Build(),
+		OperatorMode: true,
+	}
+
+	rp := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+	assert.Empty(t, r.reconcileStatefulSetsForRedpanda(context.Background(), rp))
+
+	rp.Annotations = map[string]string{DecommissionBrokersAnnotation: "3"}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "redpanda"}},
+	}, r.reconcileStatefulSetsForRedpanda(context.Background(), rp))
+}
+
+func TestExplicitDecommissionRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	rp := &v1alpha1.Redpanda{ObjectMeta: metav1.ObjectMeta{
+		Name:        "cluster-a",
+		Namespace:   "default",
+		Annotations: map[string]string{DecommissionBrokersAnnotation: "4"},
+	}}
+	rp.Spec.ChartRef.ReleaseName = "redpanda-prod"
+
+	r := &DecommissionReconciler{
+		Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(rp).Build(),
+		OperatorMode: true,
+	}
+
+	tcs := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+	}{
+		{"by HelmRelease", map[string]string{helmv2beta1.GroupVersion.Group + "/name": "cluster-a", K8sInstanceLabelKey: "redpanda-prod"}, "4"},
+		{"by release name", map[string]string{K8sInstanceLabelKey: "redpanda-prod"}, "4"},
+		{"Redpanda name is not the release name", map[string]string{K8sInstanceLabelKey: "cluster-a"}, ""},
+		{"other release", map[string]string{K8sInstanceLabelKey: "other"}, ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-prod", Namespace: "default", Labels: tc.labels}}
+			assert.Equal(t, tc.expected, r.explicitDecommissionRequest(context.Background(), sts))
+		})
+	}
+}
+
+func TestExplicitDecommissionCompleted(t *testing.T) {
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+	sts.Status.Conditions = []appsv1.StatefulSetCondition{{
+		Type:    ExplicitDecommissionCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  "DecommissionCompleted",
+		Message: explicitDecommissionProgress{Completed: []int{3, 4}}.String(),
+	}}
+
+	assert.True(t, explicitDecommissionCompleted(sts, []int{3, 4}))
+	assert.False(t, explicitDecommissionCompleted(sts, []int{3, 4, 5}))
+
+	// a completed request is not reconciled again, the Admin API is not used
+	r := &DecommissionReconciler{}
+	result, completed, err := r.reconcileExplicitDecommission(context.Background(), sts, "4,3")
+	require.NoError(t, err)
+	assert.True(t, completed)
+	assert.Zero(t, result)
+
+	sts.Status.Conditions[0].Status = corev1.ConditionUnknown
+	assert.False(t, explicitDecommissionCompleted(sts, []int{3, 4}))
+}
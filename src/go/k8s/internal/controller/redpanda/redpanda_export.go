@@ -0,0 +1,177 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// exportedMetadataFields are the metadata fields set by the API server or
+// bound to the objects of the exported cluster, they are removed so that the
+// export can be applied to recreate the objects.
+var exportedMetadataFields = []string{"resourceVersion", "uid", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds", "managedFields", "ownerReferences", "selfLink"}
+
+// ExportManagementState writes the Redpanda with the given key, its
+// HelmRepositories, its HelmRelease, which holds the rendered values, and
+// the StatefulSets, Deployments, Services, ConfigMaps and
+// PodDisruptionBudgets of its release to w as a multi-document YAML stream.
+// Status, server-set metadata, owner references and Service cluster IPs are
+// removed so that the stream can be applied to recreate the management
+// state. Secrets are not exported. Objects that do not exist are skipped.
+func ExportManagementState(ctx context.Context, c client.Reader, scheme *runtime.Scheme, key types.NamespacedName, w io.Writer) error {
+	var rp v1alpha1.Redpanda
+	if err := c.Get(ctx, key, &rp); err != nil {
+		return fmt.Errorf("get redpanda '%s': %w", key, err)
+	}
+
+	objs := []client.Object{&rp}
+
+	repoNames := []string{rp.GetHelmRepositoryName()}
+	for i := range rp.Spec.ChartRef.FallbackRepositoryURLs {
+		repoNames = append(repoNames, rp.GetFallbackHelmRepositoryName(i))
+	}
+	for _, name := range repoNames {
+		repo := &sourcev1.HelmRepository{}
+		found, err := getOptional(ctx, c, types.NamespacedName{Namespace: rp.Namespace, Name: name}, repo)
+		if err != nil {
+			return err
+		}
+		if found {
+			objs = append(objs, repo)
+		}
+	}
+
+	hr := &helmv2beta1.HelmRelease{}
+	found, err := getOptional(ctx, c, types.NamespacedName{Namespace: rp.Namespace, Name: rp.GetHelmReleaseName()}, hr)
+	if err != nil {
+		return err
+	}
+	if found {
+		objs = append(objs, hr)
+	}
+
+	chartObjs, err := releaseObjects(ctx, c, &rp)
+	if err != nil {
+		return err
+	}
+	objs = append(objs, chartObjs...)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	for _, obj := range objs {
+		content, err := exportedObject(scheme, obj)
+		if err != nil {
+			return err
+		}
+		if err = enc.Encode(content); err != nil {
+			return fmt.Errorf("write %s '%s/%s': %w", content["kind"], obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+	return enc.Close()
+}
+
+// getOptional gets the object with the given key into obj and reports
+// whether it exists.
+func getOptional(ctx context.Context, c client.Reader, key types.NamespacedName, obj client.Object) (bool, error) {
+	if err := c.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get %T '%s': %w", obj, key, err)
+	}
+	return true, nil
+}
+
+// releaseObjects returns the key chart resources of the release of the given
+// Redpanda.
+func releaseObjects(ctx context.Context, c client.Reader, rp *v1alpha1.Redpanda) ([]client.Object, error) {
+	opts := []client.ListOption{client.InNamespace(rp.Namespace), client.MatchingLabels{K8sInstanceLabelKey: rp.GetReleaseName()}}
+
+	var objs []client.Object
+	var stss appsv1.StatefulSetList
+	if err := c.List(ctx, &stss, opts...); err != nil {
+		return nil, fmt.Errorf("list statefulsets of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range stss.Items {
+		objs = append(objs, &stss.Items[i])
+	}
+	var deploys appsv1.DeploymentList
+	if err := c.List(ctx, &deploys, opts...); err != nil {
+		return nil, fmt.Errorf("list deployments of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range deploys.Items {
+		objs = append(objs, &deploys.Items[i])
+	}
+	var svcs corev1.ServiceList
+	if err := c.List(ctx, &svcs, opts...); err != nil {
+		return nil, fmt.Errorf("list services of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range svcs.Items {
+		objs = append(objs, &svcs.Items[i])
+	}
+	var cms corev1.ConfigMapList
+	if err := c.List(ctx, &cms, opts...); err != nil {
+		return nil, fmt.Errorf("list configmaps of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range cms.Items {
+		objs = append(objs, &cms.Items[i])
+	}
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := c.List(ctx, &pdbs, opts...); err != nil {
+		return nil, fmt.Errorf("list poddisruptionbudgets of release %s: %w", rp.GetReleaseName(), err)
+	}
+	for i := range pdbs.Items {
+		objs = append(objs, &pdbs.Items[i])
+	}
+	return objs, nil
+}
+
+// exportedObject returns the content of obj, including its apiVersion and
+// kind, without its status and the fields listed in exportedMetadataFields.
+func exportedObject(scheme *runtime.Scheme, obj client.Object) (map[string]interface{}, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s '%s/%s': %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range exportedMetadataFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	if gvk.Kind == "Service" {
+		// cluster IPs are allocated by the API server
+		unstructured.RemoveNestedField(u.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(u.Object, "spec", "clusterIPs")
+	}
+	return u.Object, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestExportManagementState(t *testing.T) {
+	rp := testRedpanda()
+	rp.UID = "rp-uid"
+	rp.Status.HelmRelease = "redpanda"
+	releaseLabels := map[string]string{K8sInstanceLabelKey: "redpanda"}
+
+	hr := &helmv2beta1.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", OwnerReferences: []metav1.OwnerReference{rp.OwnerShipRefObj()}},
+		Spec:       helmv2beta1.HelmReleaseSpec{Values: &apiextensionsv1.JSON{Raw: []byte(`{"nameOverride":"panda"}`)}},
+	}
+	repo := &sourcev1.HelmRepository{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-repository", Namespace: "default"}}
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Labels: releaseLabels}}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Labels: releaseLabels},
+		Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.1", ClusterIPs: []string{"10.0.0.1"}},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Labels: releaseLabels}}
+	otherRelease := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{K8sInstanceLabelKey: "other"}}}
+
+	r, _ := newTestRedpandaReconciler(t, rp, hr, repo, sts, svc, secret, otherRelease)
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportManagementState(context.Background(), r.Client, r.Scheme, types.NamespacedName{Namespace: "default", Name: "redpanda"}, &buf))
+
+	var docs []map[string]interface{}
+	dec := yaml.NewDecoder(&buf)
+	for {
+		doc := map[string]interface{}{}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+
+	var kinds []string
+	for _, doc := range docs {
+		kinds = append(kinds, doc["kind"].(string))
+		metadata := doc["metadata"].(map[string]interface{})
+		assert.Equal(t, "default", metadata["namespace"])
+		assert.NotContains(t, metadata, "resourceVersion")
+		assert.NotContains(t, metadata, "uid")
+		assert.NotContains(t, metadata, "ownerReferences")
+		assert.NotContains(t, doc, "status")
+	}
+	assert.Equal(t, []string{"Redpanda", "HelmRepository", "HelmRelease", "StatefulSet", "Service"}, kinds)
+
+	assert.Equal(t, map[string]interface{}{"nameOverride": "panda"}, docs[2]["spec"].(map[string]interface{})["values"])
+	assert.NotContains(t, docs[4]["spec"], "clusterIP")
+	assert.NotContains(t, docs[4]["spec"], "clusterIPs")
+}
+
+func TestExportManagementStateMissingRedpanda(t *testing.T) {
+	r, _ := newTestRedpandaReconciler(t)
+
+	var buf bytes.Buffer
+	err := ExportManagementState(context.Background(), r.Client, r.Scheme, types.NamespacedName{Namespace: "default", Name: "redpanda"}, &buf)
+	assert.ErrorContains(t, err, "get redpanda 'default/redpanda'")
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// HelmRepositorySweeper periodically deletes the HelmRepositories created for
+// Redpanda resources that no longer exist, e.g. because a Redpanda was
+// force deleted by removing its finalizer while the operator was down.
+// HelmRepositories still referenced by another Redpanda are kept, as are
+// those not created by the operator.
+type HelmRepositorySweeper struct {
+	client.Client
+	// Interval is the time between two sweeps.
+	Interval time.Duration
+	// Namespace restricts the sweep to a single namespace. Empty means all
+	// namespaces.
+	Namespace string
+	// SafeMode turns the deletions into a dry run, unless the HelmRepository
+	// has the allow-destructive-actions annotation.
+	SafeMode bool
+}
+
+// Start runs the sweep every Interval until the context is done.
+func (s *HelmRepositorySweeper) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("HelmRepositorySweeper")
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.sweep(ctx); err != nil {
+				log.Error(err, "sweeping orphaned HelmRepositories")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes only the leader sweep.
+func (s *HelmRepositorySweeper) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *HelmRepositorySweeper) sweep(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("HelmRepositorySweeper.sweep")
+
+	var opts []client.ListOption
+	if s.Namespace != "" {
+		opts = append(opts, client.InNamespace(s.Namespace))
+	}
+
+	// list the HelmRepositories first, so that a Redpanda created in between
+	// is found below
+	var repos sourcev1.HelmRepositoryList
+	if err := s.Client.List(ctx, &repos, opts...); err != nil {
+		return fmt.Errorf("listing HelmRepositories: %w", err)
+	}
+
+	var rps v1alpha1.RedpandaList
+	if err := s.Client.List(ctx, &rps, opts...); err != nil {
+		return fmt.Errorf("listing Redpandas: %w", err)
+	}
+	existing := map[types.UID]bool{}
+	referenced := map[types.NamespacedName]bool{}
+	for i := range rps.Items {
+		existing[rps.Items[i].UID] = true
+		referenced[types.NamespacedName{Namespace: rps.Items[i].Namespace, Name: rps.Items[i].GetHelmRepositoryName()}] = true
+	}
+
+	for i := range repos.Items {
+		repo := &repos.Items[i]
+		owners := redpandaOwners(repo.OwnerReferences)
+		if len(owners) == 0 {
+			continue
+		}
+
+		orphaned := true
+		for _, owner := range owners {
+			if existing[owner.UID] {
+				orphaned = false
+				break
+			}
+		}
+		if !orphaned {
+			continue
+		}
+
+		key := client.ObjectKeyFromObject(repo)
+		if referenced[key] {
+			Debugf(log, "keeping HelmRepository '%s' of deleted Redpanda, still used by another Redpanda", key)
+			continue
+		}
+
+		action := fmt.Sprintf("delete HelmRepository '%s' of deleted Redpanda '%s'", key, owners[0].Name)
+		if !destructiveActionAllowed(log, s.SafeMode, repo, action) {
+			continue
+		}
+		if err := s.Client.Delete(ctx, repo, client.Preconditions{UID: &repo.UID}); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting orphaned HelmRepository '%s': %w", key, err)
+		}
+		log.Info(fmt.Sprintf("deleted HelmRepository '%s' of deleted Redpanda '%s'", key, owners[0].Name))
+	}
+	return nil
+}
+
+// redpandaOwners returns the owner references pointing at a Redpanda.
+func redpandaOwners(refs []metav1.OwnerReference) []metav1.OwnerReference {
+	var owners []metav1.OwnerReference
+	for _, ref := range refs {
+		if ref.Kind == "Redpanda" && strings.HasPrefix(ref.APIVersion, v1alpha1.GroupVersion.Group+"/") {
+			owners = append(owners, ref)
+		}
+	}
+	return owners
+}
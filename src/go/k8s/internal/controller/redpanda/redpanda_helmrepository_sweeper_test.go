@@ -0,0 +1,84 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+func TestHelmRepositorySweeper(t *testing.T) {
+	owner := func(name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: v1alpha1.GroupVersion.String(), Kind: "Redpanda", Name: name, UID: uid}}
+	}
+	repo := func(name string, owners []metav1.OwnerReference) *sourcev1.HelmRepository {
+		return &sourcev1.HelmRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", OwnerReferences: owners},
+		}
+	}
+
+	live := testRedpanda()
+	live.UID = "live"
+	live.Spec.ChartRef.HelmRepositoryName = "live-repository"
+
+	sharing := testRedpanda()
+	sharing.Name = "sharing"
+	sharing.UID = "sharing"
+	sharing.Spec.ChartRef.HelmRepositoryName = "shared-repository"
+
+	r, _ := newTestRedpandaReconciler(t,
+		live,
+		sharing,
+		repo("live-repository", owner("redpanda", "live")),
+		repo("orphaned-repository", owner("deleted", "deleted")),
+		repo("shared-repository", owner("deleted", "deleted")),
+		repo("gitops-repository", nil),
+	)
+
+	s := &HelmRepositorySweeper{Client: r.Client, Namespace: "default"}
+	require.NoError(t, s.sweep(context.Background()))
+
+	var repos sourcev1.HelmRepositoryList
+	require.NoError(t, r.Client.List(context.Background(), &repos, client.InNamespace("default")))
+	var names []string
+	for i := range repos.Items {
+		names = append(names, repos.Items[i].Name)
+	}
+	assert.ElementsMatch(t, []string{"live-repository", "shared-repository", "gitops-repository"}, names)
+}
+
+func TestHelmRepositorySweeperSafeMode(t *testing.T) {
+	owners := []metav1.OwnerReference{{APIVersion: v1alpha1.GroupVersion.String(), Kind: "Redpanda", Name: "deleted", UID: "deleted"}}
+	blocked := &sourcev1.HelmRepository{ObjectMeta: metav1.ObjectMeta{Name: "blocked", Namespace: "default", OwnerReferences: owners}}
+	allowed := &sourcev1.HelmRepository{ObjectMeta: metav1.ObjectMeta{
+		Name:            "allowed",
+		Namespace:       "default",
+		OwnerReferences: owners,
+		Annotations:     map[string]string{v1alpha1.GroupVersion.Group + allowDestructiveActionsPath: "true"},
+	}}
+	r, _ := newTestRedpandaReconciler(t, blocked, allowed)
+
+	s := &HelmRepositorySweeper{Client: r.Client, Namespace: "default", SafeMode: true}
+	require.NoError(t, s.sweep(context.Background()))
+
+	require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(blocked), &sourcev1.HelmRepository{}))
+	err := r.Client.Get(context.Background(), client.ObjectKeyFromObject(allowed), &sourcev1.HelmRepository{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
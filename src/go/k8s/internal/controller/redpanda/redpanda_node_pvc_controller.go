@@ -14,13 +14,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/component-helpers/storage/volume"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
 )
@@ -30,16 +36,114 @@ import (
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
+// defaultCordonedNodeGracePeriod is the default
+// RedpandaNodePVCReconciler.CordonedNodeGracePeriod.
+const defaultCordonedNodeGracePeriod = time.Hour
+
 // RedpandaNodePVCReconciler watches node objects, and sets annotation to PVC to mark them for deletion
 type RedpandaNodePVCReconciler struct {
 	client.Client
 	OperatorMode bool
+	// ActOnCordonedNodes makes the PVCs of a Node deleted while cordoned be
+	// deleted right away. By default the Node is assumed to be under
+	// maintenance, their deletion waits until the Node registers again, the
+	// PVCs are then kept, or CordonedNodeGracePeriod has passed.
+	ActOnCordonedNodes bool
+	// CordonedNodeGracePeriod is the time the PVCs of a Node deleted while
+	// cordoned are kept for. Defaults to one hour.
+	CordonedNodeGracePeriod time.Duration
+	// SafeMode turns the deletion of the PVCs of deleted Nodes into a dry
+	// run, unless the PVC has the allow-destructive-actions annotation.
+	SafeMode bool
+	// EventRecorder reports the PVC deletions paused for cordoned Nodes and
+	// the ones blocked by safe mode.
+	EventRecorder record.EventRecorder
+
+	mu sync.Mutex
+	// cordonedDeletions holds the time the Nodes deleted while cordoned were
+	// deleted at, by name. Only the deleted object tells whether the Node was
+	// cordoned.
+	cordonedDeletions map[string]time.Time
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RedpandaNodePVCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Node{}).WithEventFilter(DeleteEventFilter).Complete(r)
+		For(&corev1.Node{}).
+		WithEventFilter(DeleteEventFilter).
+		WithEventFilter(predicate.Funcs{DeleteFunc: r.recordCordonedNode}).
+		Complete(r)
+}
+
+// recordCordonedNode records the deletion of a cordoned Node unless
+// ActOnCordonedNodes is set, see pauseForCordonedNode. The event is always
+// let through.
+func (r *RedpandaNodePVCReconciler) recordCordonedNode(e event.DeleteEvent) bool {
+	node, ok := e.Object.(*corev1.Node)
+	if !ok || r.ActOnCordonedNodes || !node.Spec.Unschedulable {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cordonedDeletions == nil {
+		r.cordonedDeletions = map[string]time.Time{}
+	}
+	if _, ok := r.cordonedDeletions[node.Name]; !ok {
+		r.cordonedDeletions[node.Name] = time.Now()
+	}
+	return true
+}
+
+func (r *RedpandaNodePVCReconciler) forgetCordonedNode(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cordonedDeletions, name)
+}
+
+func (r *RedpandaNodePVCReconciler) cordonedNodeGracePeriod() time.Duration {
+	if r.CordonedNodeGracePeriod > 0 {
+		return r.CordonedNodeGracePeriod
+	}
+	return defaultCordonedNodeGracePeriod
+}
+
+// pauseForCordonedNode reports whether the deletion of the PVCs of the given
+// Node must wait because the Node was cordoned when it was deleted. It is
+// retried with backoff, emitting a reminder each time, until the Node
+// registers again, its PVCs are then kept, or the cordoned node grace period
+// has passed, the Node is then assumed to be removed for good.
+func (r *RedpandaNodePVCReconciler) pauseForCordonedNode(ctx context.Context, name string) (ctrl.Result, bool, error) {
+	r.mu.Lock()
+	deletedAt, ok := r.cordonedDeletions[name]
+	r.mu.Unlock()
+	if !ok {
+		return ctrl.Result{}, false, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithName("RedpandaNodePVCReconciler.pauseForCordonedNode")
+	node := &corev1.Node{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, node); err == nil {
+		Infof(log, "Node %q registered again, keeping the PVCs of its brokers", name)
+		r.forgetCordonedNode(name)
+		return ctrl.Result{}, true, nil
+	} else if !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, true, fmt.Errorf("could not GET Node %q: %w", name, err)
+	}
+
+	if time.Since(deletedAt) >= r.cordonedNodeGracePeriod() {
+		Infof(log, "Node %q deleted while cordoned did not register again within %s, deleting the PVCs of its brokers", name, r.cordonedNodeGracePeriod())
+		r.forgetCordonedNode(name)
+		return ctrl.Result{}, false, nil
+	}
+
+	msg := fmt.Sprintf("Node %q was deleted while cordoned, keeping the PVCs of its brokers until it registers again or %s have passed", name, r.cordonedNodeGracePeriod())
+	log.Info(msg)
+	if r.EventRecorder != nil {
+		node.Name = name
+		r.EventRecorder.Event(node, corev1.EventTypeNormal, NodeCordonedReason, msg)
+	}
+	return ctrl.Result{Requeue: true}, true, nil
 }
 
 func (r *RedpandaNodePVCReconciler) Reconcile(c context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -51,7 +155,10 @@ func (r *RedpandaNodePVCReconciler) Reconcile(c context.Context, req ctrl.Reques
 
 	Infof(log, "Node %q was found to be deleted, checking for existing PVCs", req.Name)
 
-	result, err := r.reconcile(ctx, req)
+	result, paused, err := r.pauseForCordonedNode(ctx, req.Name)
+	if !paused {
+		result, err = r.reconcile(ctx, req)
+	}
 
 	// Log reconciliation duration
 	durationMsg := fmt.Sprintf("reconciliation finished in %s", time.Since(start).String())
@@ -167,6 +274,14 @@ func (r *RedpandaNodePVCReconciler) reconcile(ctx context.Context, req ctrl.Requ
 			}
 		}
 
+		action := fmt.Sprintf("delete PVC %s of deleted Node %s", pvc.Name, req.Name)
+		if !destructiveActionAllowed(log, r.SafeMode, &pvc, action) {
+			if r.EventRecorder != nil {
+				r.EventRecorder.Event(&pvc, corev1.EventTypeNormal, v1alpha1.DestructiveActionBlockedReason, safeModeMessage(action))
+			}
+			continue
+		}
+
 		// we are being deleted, before moving forward, try to update PV to avoid data loss
 		// this is by best effort, if we cannot, then we move on,
 		pvName := pvc.Spec.VolumeName
@@ -0,0 +1,41 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// errDestructiveActionBlocked is returned when safe mode prevented a
+// destructive action.
+var errDestructiveActionBlocked = errors.New("destructive action blocked by safe mode")
+
+// destructiveActionAllowed reports whether a destructive action may be
+// performed on behalf of the given object: always outside of safe mode,
+// otherwise only when the object has the allow-destructive-actions
+// annotation set to "true". Blocked actions are logged as a dry run.
+func destructiveActionAllowed(log logr.Logger, safeMode bool, obj client.Object, action string) bool {
+	if !safeMode || obj.GetAnnotations()[v1alpha1.GroupVersion.Group+allowDestructiveActionsPath] == "true" {
+		return true
+	}
+	log.Info("safe mode: dry run, not performed", "action", action, "object", client.ObjectKeyFromObject(obj))
+	return false
+}
+
+// safeModeMessage describes a destructive action blocked by safe mode.
+func safeModeMessage(action string) string {
+	return fmt.Sprintf("safe mode: would %s, set the %s annotation to \"true\" to allow it", action, v1alpha1.GroupVersion.Group+allowDestructiveActionsPath)
+}
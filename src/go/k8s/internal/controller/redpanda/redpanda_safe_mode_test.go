@@ -0,0 +1,226 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package redpanda
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-helpers/storage/volume"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+var allowDestructiveActions = map[string]string{v1alpha1.GroupVersion.Group + allowDestructiveActionsPath: "true"}
+
+func TestDestructiveActionAllowed(t *testing.T) {
+	tests := []struct {
+		name        string
+		safeMode    bool
+		annotations map[string]string
+		expected    bool
+	}{
+		{name: "safe mode disabled", expected: true},
+		{name: "safe mode", safeMode: true},
+		{name: "safe mode with annotation", safeMode: true, annotations: allowDestructiveActions, expected: true},
+		{
+			name:        "safe mode with annotation not true",
+			safeMode:    true,
+			annotations: map[string]string{v1alpha1.GroupVersion.Group + allowDestructiveActionsPath: "yes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testRedpanda()
+			rp.Annotations = tt.annotations
+			assert.Equal(t, tt.expected, destructiveActionAllowed(logr.Discard(), tt.safeMode, rp, "delete something"))
+		})
+	}
+}
+
+func TestSafeModeHelmReleaseDeletion(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		deleted     bool
+	}{
+		{name: "blocked"},
+		{name: "allowed by annotation", annotations: allowDestructiveActions, deleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hr := &helmv2beta1.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}}
+			rp := testRedpanda()
+			rp.Annotations = tt.annotations
+			rp.Status.HelmRelease = "redpanda"
+			r, recorder := newTestRedpandaReconciler(t, rp, hr)
+			r.SafeMode = true
+
+			_, err := r.reconcileDelete(context.Background(), rp)
+			if tt.deleted {
+				assert.ErrorContains(t, err, "wait for helm release deletion")
+				err = r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), &helmv2beta1.HelmRelease{})
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(hr), &helmv2beta1.HelmRelease{}))
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, "safe mode: would delete HelmRelease 'default/redpanda'")
+		})
+	}
+}
+
+func TestSafeModePVCDeletion(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		deleted     bool
+	}{
+		{name: "blocked"},
+		{name: "allowed by annotation", annotations: allowDestructiveActions, deleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "datadir-redpanda-1", Namespace: "default"}}
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "redpanda", Namespace: "default", Annotations: tt.annotations},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+			}
+			r, _ := newTestRedpandaReconciler(t, pvc)
+			d := &DecommissionReconciler{Client: r.Client, SafeMode: true}
+
+			pvcList := &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{*pvc}}
+			bound := map[string]bool{"datadir-redpanda-0": true, "datadir-redpanda-1": false}
+			require.NoError(t, d.tryToDeletePVC(ctrl.Log, context.Background(), sts, bound, pvcList))
+
+			err := r.Client.Get(context.Background(), client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+			if tt.deleted {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSafeModeNodePVCDeletion(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		deleted     bool
+	}{
+		{name: "dry run"},
+		{name: "allowed by annotation", annotations: allowDestructiveActions, deleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvHelmReleaseNameKey, "redpanda")
+
+			annotations := map[string]string{volume.AnnSelectedNode: "node-1"}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+				Name:        "datadir-redpanda-0",
+				Namespace:   "default",
+				Labels:      map[string]string{K8sNameLabelKey: "redpanda", K8sInstanceLabelKey: "redpanda"},
+				Annotations: annotations,
+			}}
+			r, recorder := newTestRedpandaReconciler(t, pvc)
+			n := &RedpandaNodePVCReconciler{Client: r.Client, SafeMode: true, EventRecorder: recorder}
+
+			_, err := n.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "node-1"}})
+			require.NoError(t, err)
+
+			err = r.Client.Get(context.Background(), client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+			if tt.deleted {
+				assert.True(t, apierrors.IsNotFound(err))
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, "safe mode: would delete PVC datadir-redpanda-0 of deleted Node node-1")
+		})
+	}
+}
+
+func TestSafeModeMigration(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		completed   bool
+	}{
+		{name: "blocked"},
+		{name: "allowed by annotation", annotations: allowDestructiveActions, completed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := testMigratingRedpanda()
+			rp.Annotations = tt.annotations
+			rp.Spec.ClusterSpec = &v1alpha1.RedpandaClusterSpec{Console: &v1alpha1.RedpandaConsole{Enabled: ptr.To(false)}}
+
+			objMeta := metav1.ObjectMeta{Name: "redpanda", Namespace: "default"}
+			cluster := &vectorizedv1alpha1.Cluster{ObjectMeta: objMeta}
+			disableRedpandaReconciliation(cluster)
+			console := &vectorizedv1alpha1.Console{ObjectMeta: objMeta}
+			disableConsoleReconciliation(console)
+			sts := &appsv1.StatefulSet{ObjectMeta: objMeta}
+			r, recorder := newTestRedpandaReconciler(t, rp.DeepCopy(), cluster, console, sts,
+				&corev1.Service{ObjectMeta: objMeta},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redpanda-external", Namespace: "default"}},
+				&corev1.ServiceAccount{ObjectMeta: objMeta},
+				&policyv1.PodDisruptionBudget{ObjectMeta: objMeta},
+			)
+			r.SafeMode = true
+			r.DisableMigrationOnCompletion = true
+
+			r.reconcileMigration(context.Background(), logr.Discard(), rp)
+			assert.Equal(t, tt.completed, migrationCompleted(rp))
+			assert.Equal(t, !tt.completed, rp.Spec.Migration.Enabled)
+
+			err := r.Client.Get(context.Background(), client.ObjectKeyFromObject(sts), &appsv1.StatefulSet{})
+			if tt.completed {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err, "the StatefulSet is not adopted")
+
+			var blocked bool
+			for len(recorder.Events) > 0 {
+				if e := <-recorder.Events; strings.Contains(e, "safe mode: would delete StatefulSet redpanda") {
+					blocked = true
+				}
+			}
+			assert.True(t, blocked)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	types2 "github.com/onsi/gomega/types"
@@ -829,6 +830,11 @@ var _ = Describe("RedPandaCluster controller", func() {
 
 	Context("Calling reconcile with restricted version", func() {
 		const allowedVersion = "v23.1.1"
+		allowedConstraint := func() *semver.Constraints {
+			c, err := semver.NewConstraint(allowedVersion)
+			Expect(err).NotTo(HaveOccurred())
+			return c
+		}
 		It("Should throw error due to restricted redpanda version", func() {
 			restrictedVersion := "v23.1.2"
 			key, redpandaCluster := getVersionedRedpanda("restricted-redpanda-negative", restrictedVersion)
@@ -839,7 +845,7 @@ var _ = Describe("RedPandaCluster controller", func() {
 				Scheme:                    scheme.Scheme,
 				AdminAPIClientFactory:     testAdminAPIFactory,
 				DecommissionWaitInterval:  100 * time.Millisecond,
-				RestrictToRedpandaVersion: allowedVersion,
+				RestrictToRedpandaVersion: allowedConstraint(),
 			}
 			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
 			Expect(err).To(Succeed())
@@ -868,7 +874,7 @@ var _ = Describe("RedPandaCluster controller", func() {
 				Scheme:                    scheme.Scheme,
 				AdminAPIClientFactory:     testAdminAPIFactory,
 				DecommissionWaitInterval:  100 * time.Millisecond,
-				RestrictToRedpandaVersion: allowedVersion,
+				RestrictToRedpandaVersion: allowedConstraint(),
 			}
 			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
 			Expect(err).To(Succeed())
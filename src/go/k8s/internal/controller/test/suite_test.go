@@ -160,6 +160,7 @@ var _ = BeforeSuite(func(suiteCtx SpecContext) {
 		Scheme:                   k8sManager.GetScheme(),
 		AdminAPIClientFactory:    testAdminAPIFactory,
 		DecommissionWaitInterval: 100 * time.Millisecond,
+		EventRecorder:            k8sManager.GetEventRecorderFor("Cluster"),
 	}).WithClusterDomain("cluster.local").WithConfiguratorSettings(resources.ConfiguratorSettings{
 		ConfiguratorBaseImage: "vectorized/configurator",
 		ConfiguratorTag:       "latest",
@@ -251,7 +252,7 @@ var _ = BeforeSuite(func(suiteCtx SpecContext) {
 		Scheme:          k8sManager.GetScheme(),
 		EventRecorder:   k8sManager.GetEventRecorderFor("RedpandaReconciler"),
 		RequeueHelmDeps: 10 * time.Second,
-	}).SetupWithManager(k8sManager)
+	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&redpanda.DecommissionReconciler{
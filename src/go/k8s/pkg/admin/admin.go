@@ -30,6 +30,13 @@ func (n *NoInternalAdminAPI) Error() string {
 	return "no internal admin API defined for cluster"
 }
 
+// NoExternalAdminAPI signal absence of the external admin API endpoint
+type NoExternalAdminAPI struct{}
+
+func (n *NoExternalAdminAPI) Error() string {
+	return "no external admin API defined for cluster"
+}
+
 // NewInternalAdminAPI is used to construct an admin API client that talks to the cluster via
 // the internal interface.
 func NewInternalAdminAPI(
@@ -74,7 +81,71 @@ func NewInternalAdminAPI(
 		return nil, fmt.Errorf("error creating admin api for cluster %s/%s using urls %v (tls=%v): %w", redpandaCluster.Namespace, redpandaCluster.Name, urls, tlsConfig != nil, err)
 	}
 
-	return adminAPI, nil
+	return NewInstrumentedAdminAPI(adminAPI, redpandaCluster.Namespace+"/"+redpandaCluster.Name), nil
+}
+
+// NewExternalAdminAPI is used to construct an admin API client that talks to
+// the cluster via the external interface, at the addresses reported in
+// Status.Nodes. Specific brokers are selected by the ordinal of their Pod. It
+// serves operators that cannot reach the brokers through the headless Service.
+func NewExternalAdminAPI(
+	ctx context.Context,
+	k8sClient client.Reader,
+	redpandaCluster *vectorizedv1alpha1.Cluster,
+	adminTLSProvider types.AdminTLSConfigProvider,
+	ordinals ...int32,
+) (AdminAPIClient, error) {
+	adminExternal := redpandaCluster.AdminAPIExternal()
+	if adminExternal == nil {
+		return nil, &NoExternalAdminAPI{}
+	}
+
+	var tlsConfig *tls.Config
+	if adminExternal.TLS.Enabled {
+		externalTLSProvider, ok := adminTLSProvider.(types.ExternalAdminTLSConfigProvider)
+		if !ok {
+			return nil, fmt.Errorf("no tls configuration available for external admin API of cluster %s/%s", redpandaCluster.Namespace, redpandaCluster.Name)
+		}
+		var err error
+		tlsConfig, err = externalTLSProvider.GetExternalTLSConfig(ctx, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("could not create tls configuration for external admin API: %w", err)
+		}
+	}
+
+	urls, err := externalAdminURLs(redpandaCluster, ordinals...)
+	if err != nil {
+		return nil, err
+	}
+
+	adminAPI, err := admin.NewAdminAPI(urls, admin.BasicCredentials{}, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating admin api for cluster %s/%s using urls %v (tls=%v): %w", redpandaCluster.Namespace, redpandaCluster.Name, urls, tlsConfig != nil, err)
+	}
+
+	return NewInstrumentedAdminAPI(adminAPI, redpandaCluster.Namespace+"/"+redpandaCluster.Name), nil
+}
+
+// externalAdminURLs returns the external admin API addresses of the given
+// brokers, or of all brokers if none is given.
+func externalAdminURLs(redpandaCluster *vectorizedv1alpha1.Cluster, ordinals ...int32) ([]string, error) {
+	nodes := redpandaCluster.Status.Nodes
+	if len(ordinals) == 0 {
+		if len(nodes.ExternalAdmin) == 0 {
+			return nil, fmt.Errorf("no external admin API address reported for cluster %s/%s", redpandaCluster.Namespace, redpandaCluster.Name)
+		}
+		return nodes.ExternalAdmin, nil
+	}
+
+	urls := make([]string, 0, len(ordinals))
+	for _, on := range ordinals {
+		address, ok := nodes.ExternalAdminByPod[fmt.Sprintf("%s-%d", redpandaCluster.Name, on)]
+		if !ok {
+			return nil, fmt.Errorf("no external admin API address reported for broker %d of cluster %s/%s", on, redpandaCluster.Namespace, redpandaCluster.Name)
+		}
+		urls = append(urls, address)
+	}
+	return urls, nil
 }
 
 // AdminAPIClient is a sub interface of the admin API containing what we need in the operator
@@ -99,6 +170,7 @@ type AdminAPIClient interface {
 	Brokers(ctx context.Context) ([]admin.Broker, error)
 	Broker(ctx context.Context, nodeID int) (admin.Broker, error)
 	DecommissionBroker(ctx context.Context, node int) error
+	DecommissionBrokerStatus(ctx context.Context, node int) (admin.DecommissionStatusResponse, error)
 	RecommissionBroker(ctx context.Context, node int) error
 
 	EnableMaintenanceMode(ctx context.Context, node int) error
@@ -122,3 +194,33 @@ type AdminAPIClientFactory func(
 ) (AdminAPIClient, error)
 
 var _ AdminAPIClientFactory = NewInternalAdminAPI
+
+// Names of the admin API client factories, see AdminAPIClientFactoryByName.
+const (
+	InternalAdminAPIClientFactory = "internal"
+	ExternalAdminAPIClientFactory = "external"
+)
+
+// AdminAPIClientFactoryByName returns the admin API client factory of the
+// given name, either internal or external.
+func AdminAPIClientFactoryByName(name string) (AdminAPIClientFactory, error) {
+	switch name {
+	case InternalAdminAPIClientFactory:
+		return NewInternalAdminAPI, nil
+	case ExternalAdminAPIClientFactory:
+		// the external addresses come from the cluster status, the internal
+		// FQDN is not needed
+		return func(
+			ctx context.Context,
+			k8sClient client.Reader,
+			redpandaCluster *vectorizedv1alpha1.Cluster,
+			_ string,
+			adminTLSProvider types.AdminTLSConfigProvider,
+			ordinals ...int32,
+		) (AdminAPIClient, error) {
+			return NewExternalAdminAPI(ctx, k8sClient, redpandaCluster, adminTLSProvider, ordinals...)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown admin API client factory %q, valid values are %q and %q", name, InternalAdminAPIClientFactory, ExternalAdminAPIClientFactory)
+	}
+}
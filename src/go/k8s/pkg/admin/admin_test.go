@@ -0,0 +1,75 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vectorizedv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/vectorized/v1alpha1"
+)
+
+func TestExternalAdminURLs(t *testing.T) {
+	cluster := &vectorizedv1alpha1.Cluster{}
+	cluster.Name = "redpanda"
+	// the list follows the order of the Pods, not their ordinals
+	cluster.Status.Nodes.ExternalAdmin = []string{"10.0.0.3:30644", "10.0.0.1:30644", "10.0.0.2:30644"}
+	cluster.Status.Nodes.ExternalAdminByPod = map[string]string{
+		"redpanda-0": "10.0.0.1:30644",
+		"redpanda-1": "10.0.0.2:30644",
+		"redpanda-2": "10.0.0.3:30644",
+	}
+
+	tests := []struct {
+		name     string
+		ordinals []int32
+		expected []string
+		wantErr  bool
+	}{
+		{name: "all brokers", expected: []string{"10.0.0.3:30644", "10.0.0.1:30644", "10.0.0.2:30644"}},
+		{name: "single broker", ordinals: []int32{1}, expected: []string{"10.0.0.2:30644"}},
+		{name: "unknown broker", ordinals: []int32{3}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls, err := externalAdminURLs(cluster, tt.ordinals...)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, urls)
+		})
+	}
+
+	_, err := externalAdminURLs(&vectorizedv1alpha1.Cluster{})
+	assert.Error(t, err)
+}
+
+func TestNewExternalAdminAPINoListener(t *testing.T) {
+	_, err := NewExternalAdminAPI(context.Background(), nil, &vectorizedv1alpha1.Cluster{}, nil)
+	var noExternal *NoExternalAdminAPI
+	assert.ErrorAs(t, err, &noExternal)
+}
+
+func TestAdminAPIClientFactoryByName(t *testing.T) {
+	for _, name := range []string{InternalAdminAPIClientFactory, ExternalAdminAPIClientFactory} {
+		factory, err := AdminAPIClientFactoryByName(name)
+		require.NoError(t, err, name)
+		assert.NotNil(t, factory, name)
+	}
+
+	_, err := AdminAPIClientFactoryByName("port-forward")
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	adminAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "redpanda_admin_api_request_duration_seconds",
+			Help:    "Latency of Admin API calls made by the operator",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"cluster", "endpoint"},
+	)
+	adminAPIRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redpanda_admin_api_request_errors_total",
+			Help: "Number of failed Admin API calls made by the operator",
+		}, []string{"cluster", "endpoint"},
+	)
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(adminAPIRequestDuration, adminAPIRequestErrors)
+}
+
+// instrumentedAdminAPI wraps an AdminAPIClient and records the latency and
+// errors of every call, labeled by cluster and endpoint
+type instrumentedAdminAPI struct {
+	client  AdminAPIClient
+	cluster string
+}
+
+var _ AdminAPIClient = &instrumentedAdminAPI{}
+
+// NewInstrumentedAdminAPI returns an AdminAPIClient that records Prometheus
+// metrics for each call made through client. The cluster label is usually
+// the namespaced name of the Redpanda cluster.
+func NewInstrumentedAdminAPI(client AdminAPIClient, cluster string) AdminAPIClient {
+	return &instrumentedAdminAPI{client: client, cluster: cluster}
+}
+
+func (i *instrumentedAdminAPI) observe(endpoint string, start time.Time, err error) {
+	adminAPIRequestDuration.WithLabelValues(i.cluster, endpoint).Observe(time.Since(start).Seconds())
+	if err != nil {
+		adminAPIRequestErrors.WithLabelValues(i.cluster, endpoint).Inc()
+	}
+}
+
+func (i *instrumentedAdminAPI) Config(ctx context.Context, includeDefaults bool) (admin.Config, error) {
+	start := time.Now()
+	res, err := i.client.Config(ctx, includeDefaults)
+	i.observe("Config", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) ClusterConfigStatus(ctx context.Context, sendToLeader bool) (admin.ConfigStatusResponse, error) {
+	start := time.Now()
+	res, err := i.client.ClusterConfigStatus(ctx, sendToLeader)
+	i.observe("ClusterConfigStatus", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) ClusterConfigSchema(ctx context.Context) (admin.ConfigSchema, error) {
+	start := time.Now()
+	res, err := i.client.ClusterConfigSchema(ctx)
+	i.observe("ClusterConfigSchema", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) PatchClusterConfig(ctx context.Context, upsert map[string]interface{}, remove []string) (admin.ClusterConfigWriteResult, error) {
+	start := time.Now()
+	res, err := i.client.PatchClusterConfig(ctx, upsert, remove)
+	i.observe("PatchClusterConfig", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) GetNodeConfig(ctx context.Context) (admin.NodeConfig, error) {
+	start := time.Now()
+	res, err := i.client.GetNodeConfig(ctx)
+	i.observe("GetNodeConfig", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) CreateUser(ctx context.Context, username, password, mechanism string) error {
+	start := time.Now()
+	err := i.client.CreateUser(ctx, username, password, mechanism)
+	i.observe("CreateUser", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) ListUsers(ctx context.Context) ([]string, error) {
+	start := time.Now()
+	res, err := i.client.ListUsers(ctx)
+	i.observe("ListUsers", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) DeleteUser(ctx context.Context, username string) error {
+	start := time.Now()
+	err := i.client.DeleteUser(ctx, username)
+	i.observe("DeleteUser", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) UpdateUser(ctx context.Context, username, password, mechanism string) error {
+	start := time.Now()
+	err := i.client.UpdateUser(ctx, username, password, mechanism)
+	i.observe("UpdateUser", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) GetFeatures(ctx context.Context) (admin.FeaturesResponse, error) {
+	start := time.Now()
+	res, err := i.client.GetFeatures(ctx)
+	i.observe("GetFeatures", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) SetLicense(ctx context.Context, license interface{}) error {
+	start := time.Now()
+	err := i.client.SetLicense(ctx, license)
+	i.observe("SetLicense", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) GetLicenseInfo(ctx context.Context) (admin.License, error) {
+	start := time.Now()
+	res, err := i.client.GetLicenseInfo(ctx)
+	i.observe("GetLicenseInfo", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) Brokers(ctx context.Context) ([]admin.Broker, error) {
+	start := time.Now()
+	res, err := i.client.Brokers(ctx)
+	i.observe("Brokers", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) Broker(ctx context.Context, nodeID int) (admin.Broker, error) {
+	start := time.Now()
+	res, err := i.client.Broker(ctx, nodeID)
+	i.observe("Broker", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) DecommissionBroker(ctx context.Context, node int) error {
+	start := time.Now()
+	err := i.client.DecommissionBroker(ctx, node)
+	i.observe("DecommissionBroker", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) DecommissionBrokerStatus(ctx context.Context, node int) (admin.DecommissionStatusResponse, error) {
+	start := time.Now()
+	res, err := i.client.DecommissionBrokerStatus(ctx, node)
+	i.observe("DecommissionBrokerStatus", start, err)
+	return res, err
+}
+
+func (i *instrumentedAdminAPI) RecommissionBroker(ctx context.Context, node int) error {
+	start := time.Now()
+	err := i.client.RecommissionBroker(ctx, node)
+	i.observe("RecommissionBroker", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) EnableMaintenanceMode(ctx context.Context, node int) error {
+	start := time.Now()
+	err := i.client.EnableMaintenanceMode(ctx, node)
+	i.observe("EnableMaintenanceMode", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) DisableMaintenanceMode(ctx context.Context, node int, useLeaderNode bool) error {
+	start := time.Now()
+	err := i.client.DisableMaintenanceMode(ctx, node, useLeaderNode)
+	i.observe("DisableMaintenanceMode", start, err)
+	return err
+}
+
+func (i *instrumentedAdminAPI) GetHealthOverview(ctx context.Context) (admin.ClusterHealthOverview, error) {
+	start := time.Now()
+	res, err := i.client.GetHealthOverview(ctx)
+	i.observe("GetHealthOverview", start, err)
+	return res, err
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdminAPI only implements the calls exercised below, any other call
+// panics through the nil embedded interface.
+type fakeAdminAPI struct {
+	AdminAPIClient
+	healthErr error
+}
+
+func (f *fakeAdminAPI) GetHealthOverview(context.Context) (admin.ClusterHealthOverview, error) {
+	return admin.ClusterHealthOverview{IsHealthy: f.healthErr == nil}, f.healthErr
+}
+
+func TestInstrumentedAdminAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		cluster    string
+		healthErr  error
+		wantErrors float64
+	}{
+		{name: "success", cluster: "default/healthy"},
+		{name: "failure", cluster: "default/unreachable", healthErr: errors.New("connection refused"), wantErrors: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := testutil.CollectAndCount(adminAPIRequestDuration)
+
+			client := NewInstrumentedAdminAPI(&fakeAdminAPI{healthErr: tt.healthErr}, tt.cluster)
+			health, err := client.GetHealthOverview(context.Background())
+			if tt.healthErr != nil {
+				require.ErrorIs(t, err, tt.healthErr)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, health.IsHealthy)
+			}
+
+			// every call is observed, one new series per cluster and endpoint
+			assert.Equal(t, series+1, testutil.CollectAndCount(adminAPIRequestDuration))
+			assert.Equal(t, tt.wantErrors, testutil.ToFloat64(adminAPIRequestErrors.WithLabelValues(tt.cluster, "GetHealthOverview")))
+		})
+	}
+}
@@ -429,6 +429,24 @@ func (m *MockAdminAPI) DecommissionBroker(_ context.Context, id int) error {
 	return m.SetBrokerStatus(id, admin.MembershipStatusDraining)
 }
 
+//nolint:goerr113 // test code
+func (m *MockAdminAPI) DecommissionBrokerStatus(_ context.Context, id int) (admin.DecommissionStatusResponse, error) {
+	m.Log.WithName("DecommissionBrokerStatus").WithValues("id", id).Info("called")
+	m.monitor.Lock()
+	defer m.monitor.Unlock()
+
+	for i := range m.brokers {
+		if m.brokers[i].NodeID != id {
+			continue
+		}
+		if m.brokers[i].MembershipStatus != admin.MembershipStatusDraining {
+			return admin.DecommissionStatusResponse{}, fmt.Errorf("node %d is not decommissioning", id)
+		}
+		return admin.DecommissionStatusResponse{}, nil
+	}
+	return admin.DecommissionStatusResponse{}, fmt.Errorf("node %d does not exists", id)
+}
+
 func (m *MockAdminAPI) RecommissionBroker(_ context.Context, id int) error {
 	m.Log.WithName("RecommissionBroker").WithValues("id", id).Info("called")
 	return m.SetBrokerStatus(id, admin.MembershipStatusActive)
@@ -0,0 +1,67 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+// Package constlabels adds constant labels, e.g. the operator mode, to every
+// metric of a registry, including the controller-runtime metrics that are
+// registered before the labels are known.
+package constlabels
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// registry registers with the wrapped registry and adds its labels to every
+// metric gathered from it.
+type registry struct {
+	metrics.RegistererGatherer
+	labels prometheus.Labels
+}
+
+// Wrap returns a registry that delegates to reg and adds the given labels to
+// every gathered metric. Metrics that already carry one of the labels keep
+// their own value.
+func Wrap(reg metrics.RegistererGatherer, labels prometheus.Labels) metrics.RegistererGatherer {
+	return &registry{RegistererGatherer: reg, labels: labels}
+}
+
+// Gather implements prometheus.Gatherer.
+func (r *registry) Gather() ([]*dto.MetricFamily, error) {
+	families, err := r.RegistererGatherer.Gather()
+	for _, family := range families {
+		for _, m := range family.Metric {
+			m.Label = addLabels(m.Label, r.labels)
+		}
+	}
+	return families, err
+}
+
+func addLabels(pairs []*dto.LabelPair, labels prometheus.Labels) []*dto.LabelPair {
+	for name, value := range labels {
+		if hasLabel(pairs, name) {
+			continue
+		}
+		n, v := name, value
+		pairs = append(pairs, &dto.LabelPair{Name: &n, Value: &v})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].GetName() < pairs[j].GetName() })
+	return pairs
+}
+
+func hasLabel(pairs []*dto.LabelPair, name string) bool {
+	for _, p := range pairs {
+		if p.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
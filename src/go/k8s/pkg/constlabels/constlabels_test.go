@@ -0,0 +1,48 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package constlabels_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/constlabels"
+)
+
+func TestWrap(t *testing.T) {
+	inner := prometheus.NewRegistry()
+	reconciles := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "reconcile_total"}, []string{"controller"})
+	inner.MustRegister(reconciles)
+	reconciles.WithLabelValues("redpanda").Inc()
+
+	reg := constlabels.Wrap(inner, prometheus.Labels{"operator_mode": "Namespaced-v2", "controller": "ignored"})
+
+	// registering through the wrapper registers with the wrapped registry
+	queued := prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"})
+	require.NoError(t, reg.Register(queued))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+
+	labels := map[string]map[string]string{}
+	for _, family := range families {
+		require.Len(t, family.Metric, 1)
+		labels[family.GetName()] = map[string]string{}
+		for _, pair := range family.Metric[0].Label {
+			labels[family.GetName()][pair.GetName()] = pair.GetValue()
+		}
+	}
+	assert.Equal(t, map[string]string{"controller": "redpanda", "operator_mode": "Namespaced-v2"}, labels["reconcile_total"])
+	assert.Equal(t, map[string]string{"controller": "ignored", "operator_mode": "Namespaced-v2"}, labels["queued"])
+}
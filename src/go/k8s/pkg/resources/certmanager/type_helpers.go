@@ -767,6 +767,30 @@ func (cc *ClusterCertificates) GetTLSConfig(
 	return &tlsConfig, nil
 }
 
+// GetExternalTLSConfig returns TLS config for adminAPI that can then be used
+// to connect to the external admin API listener of the current cluster. The
+// client certificate is only presented when that listener requires it, and the
+// system roots are trusted unless the node certificate is self-signed.
+func (cc *ClusterCertificates) GetExternalTLSConfig(
+	ctx context.Context, k8sClient client.Reader,
+) (*tls.Config, error) {
+	external := cc.pandaCluster.AdminAPIExternal()
+	if external == nil || !external.TLS.Enabled {
+		return nil, errNoTLSError
+	}
+	tlsConfig, err := cc.GetTLSConfig(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	if !external.TLS.RequireClientAuth {
+		tlsConfig.Certificates = nil
+	}
+	if !cc.adminAPI.selfSignedNodeCertificate {
+		tlsConfig.RootCAs = nil
+	}
+	return tlsConfig, nil
+}
+
 // KafkaClientBrokerTLS returns configuration to connect to kafka api with tls
 func (cc *ClusterCertificates) KafkaClientBrokerTLS(mountPoints *resourcetypes.TLSMountPoints) *config.ServerTLS {
 	if !cc.kafkaAPI.internalTLSEnabled {
@@ -0,0 +1,88 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// digestEncodings maps the supported digest algorithms to the format of their
+// hex encoded value.
+var digestEncodings = map[string]*regexp.Regexp{
+	"sha256": regexp.MustCompile(`^[a-f0-9]{64}$`),
+	"sha384": regexp.MustCompile(`^[a-f0-9]{96}$`),
+	"sha512": regexp.MustCompile(`^[a-f0-9]{128}$`),
+}
+
+// Image returns the configurator image reference, pinned by digest when
+// ConfiguratorDigest is set and by tag otherwise.
+func (s ConfiguratorSettings) Image() string {
+	if s.ConfiguratorDigest != "" {
+		return fmt.Sprintf("%s@%s", s.ConfiguratorBaseImage, s.ConfiguratorDigest)
+	}
+	return fmt.Sprintf("%s:%s", s.ConfiguratorBaseImage, s.ConfiguratorTag)
+}
+
+// ValidateImageDigest checks that digest is an OCI content digest with a
+// supported algorithm, e.g. sha256:<64 hex characters>. A digest of a
+// multi-arch image index pins the image for every architecture.
+func ValidateImageDigest(digest string) error {
+	algorithm, encoded, found := strings.Cut(digest, ":")
+	if !found {
+		return fmt.Errorf("invalid digest %q: expected <algorithm>:<hex>", digest)
+	}
+	format, ok := digestEncodings[algorithm]
+	if !ok {
+		return fmt.Errorf("invalid digest %q: unsupported algorithm %q", digest, algorithm)
+	}
+	if !format.MatchString(encoded) {
+		return fmt.Errorf("invalid digest %q: malformed %s value", digest, algorithm)
+	}
+	return nil
+}
+
+// ParseConfiguratorEnv parses NAME=VALUE pairs into environment variables for
+// the configurator container. Values may contain '=' and ','.
+func ParseConfiguratorEnv(pairs []string) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	for _, p := range pairs {
+		if p == "" {
+			continue
+		}
+		name, value, found := strings.Cut(p, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid environment variable %q: expected NAME=VALUE", p)
+		}
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+	return env, nil
+}
+
+// ParseResourceList parses a map of resource names to quantities, such as
+// {"cpu": "100m", "memory": "64Mi"}, validating every quantity.
+func ParseResourceList(m map[string]string) (corev1.ResourceList, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	list := corev1.ResourceList{}
+	for name, value := range m {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for resource %q: %w", value, name, err)
+		}
+		list[corev1.ResourceName(name)] = q
+	}
+	return list, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/pkg/resources"
+)
+
+func TestParseConfiguratorEnv(t *testing.T) {
+	env, err := resources.ParseConfiguratorEnv([]string{"HTTPS_PROXY=http://proxy:3128", "NO_PROXY=a,b=c", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+		{Name: "NO_PROXY", Value: "a,b=c"},
+	}, env)
+
+	_, err = resources.ParseConfiguratorEnv([]string{"MISSING_VALUE"})
+	assert.Error(t, err)
+
+	_, err = resources.ParseConfiguratorEnv([]string{"=value"})
+	assert.Error(t, err)
+}
+
+func TestParseResourceList(t *testing.T) {
+	list, err := resources.ParseResourceList(map[string]string{"cpu": "100m", "memory": "64Mi"})
+	require.NoError(t, err)
+	assert.True(t, list.Cpu().Equal(resource.MustParse("100m")))
+	assert.True(t, list.Memory().Equal(resource.MustParse("64Mi")))
+
+	list, err = resources.ParseResourceList(nil)
+	require.NoError(t, err)
+	assert.Nil(t, list)
+
+	_, err = resources.ParseResourceList(map[string]string{"memory": "lots"})
+	assert.Error(t, err)
+}
+
+func TestConfiguratorSettingsImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name     string
+		settings resources.ConfiguratorSettings
+		want     string
+	}{
+		{
+			name:     "tag",
+			settings: resources.ConfiguratorSettings{ConfiguratorBaseImage: "vectorized/configurator", ConfiguratorTag: "v23.2.1"},
+			want:     "vectorized/configurator:v23.2.1",
+		},
+		{
+			name:     "digest overrides tag",
+			settings: resources.ConfiguratorSettings{ConfiguratorBaseImage: "vectorized/configurator", ConfiguratorTag: "latest", ConfiguratorDigest: digest},
+			want:     "vectorized/configurator@" + digest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.settings.Image())
+		})
+	}
+}
+
+func TestValidateImageDigest(t *testing.T) {
+	tests := []struct {
+		digest  string
+		wantErr bool
+	}{
+		{digest: "sha256:" + strings.Repeat("0f", 32)},
+		{digest: "sha512:" + strings.Repeat("a", 128)},
+		{digest: strings.Repeat("a", 64), wantErr: true},
+		{digest: "md5:" + strings.Repeat("a", 32), wantErr: true},
+		{digest: "sha256:" + strings.Repeat("a", 63), wantErr: true},
+		{digest: "sha256:" + strings.Repeat("A", 64), wantErr: true},
+		{digest: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.digest, func(t *testing.T) {
+			err := resources.ValidateImageDigest(tt.digest)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
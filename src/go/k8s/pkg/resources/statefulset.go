@@ -82,7 +82,15 @@ var (
 type ConfiguratorSettings struct {
 	ConfiguratorBaseImage string
 	ConfiguratorTag       string
-	ImagePullPolicy       corev1.PullPolicy
+	// ConfiguratorDigest, when set, pins the configurator image by digest,
+	// e.g. sha256:<hex>, and takes precedence over ConfiguratorTag
+	ConfiguratorDigest string
+	ImagePullPolicy    corev1.PullPolicy
+	// Env is appended to the environment of the configurator container
+	Env []corev1.EnvVar
+	// Resources, when set, replaces the resources the configurator container
+	// otherwise inherits from the Redpanda container
+	Resources *corev1.ResourceRequirements
 }
 
 // StatefulSetResource is part of the reconciliation of redpanda.vectorized.io CRD
@@ -466,15 +474,12 @@ func (r *StatefulSetResource) obj(
 									Name:  "VALIDATE_MOUNTED_VOLUME",
 									Value: strconv.FormatBool(r.pandaCluster.Spec.InitialValidationForVolume != nil && *r.pandaCluster.Spec.InitialValidationForVolume),
 								},
-							}, r.pandaproxyEnvVars()...),
+							}, append(r.pandaproxyEnvVars(), r.configuratorSettings.Env...)...),
 							SecurityContext: &corev1.SecurityContext{
 								RunAsUser:  ptr.To(int64(userID)),
 								RunAsGroup: ptr.To(int64(groupID)),
 							},
-							Resources: corev1.ResourceRequirements{
-								Limits:   r.pandaCluster.Spec.Resources.Limits,
-								Requests: r.pandaCluster.Spec.Resources.Requests,
-							},
+							Resources: r.configuratorResources(),
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "config-dir",
@@ -881,8 +886,18 @@ func (r *StatefulSetResource) getPorts() []corev1.ContainerPort {
 	return ports
 }
 
+func (r *StatefulSetResource) configuratorResources() corev1.ResourceRequirements {
+	if r.configuratorSettings.Resources != nil {
+		return *r.configuratorSettings.Resources
+	}
+	return corev1.ResourceRequirements{
+		Limits:   r.pandaCluster.Spec.Resources.Limits,
+		Requests: r.pandaCluster.Spec.Resources.Requests,
+	}
+}
+
 func (r *StatefulSetResource) fullConfiguratorImage() string {
-	return fmt.Sprintf("%s:%s", r.configuratorSettings.ConfiguratorBaseImage, r.configuratorSettings.ConfiguratorTag)
+	return r.configuratorSettings.Image()
 }
 
 // Version returns the cluster version specified in the image tag of the
@@ -16,6 +16,7 @@ import (
 	"github.com/fluxcd/pkg/runtime/logger"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -137,6 +138,19 @@ func (r *SuperUsersResource) Key() types.NamespacedName {
 	return types.NamespacedName{Name: resourceNameTrim(r.object.GetName(), r.suffix), Namespace: r.object.GetNamespace()}
 }
 
+// AppliedSecret returns the existing SuperUser Secret, or nil when the
+// Secret does not exist yet.
+func (r *SuperUsersResource) AppliedSecret(ctx context.Context) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, r.Key(), &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not get SuperUser Secret %s: %w", r.Key(), err)
+	}
+	return &secret, nil
+}
+
 // GetUsername returns username used for Kafka SASL config that has prefix based on --superusers-prefix flag
 func (r *SuperUsersResource) GetUsername() string {
 	return r.username
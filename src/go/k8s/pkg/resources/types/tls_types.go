@@ -36,6 +36,13 @@ type AdminTLSConfigProvider interface {
 	GetTLSConfig(ctx context.Context, k8sClient client.Reader) (*tls.Config, error)
 }
 
+// ExternalAdminTLSConfigProvider returns TLS config for the external admin API
+// listener
+type ExternalAdminTLSConfigProvider interface {
+	AdminTLSConfigProvider
+	GetExternalTLSConfig(ctx context.Context, k8sClient client.Reader) (*tls.Config, error)
+}
+
 // TLSMountPoint defines paths to be mounted
 // We need 2 secrets and 2 mount points for each API endpoint that supports TLS and mTLS:
 // 1. The Node certs used by the API endpoint to sign requests
@@ -0,0 +1,117 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+// Package throttle detects the API server rate limiting the requests of the
+// operator, so that reconcilers can back off instead of adding to the load.
+package throttle
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var throttledRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "redpanda_operator_api_throttled_requests_total",
+		Help: "Number of requests to the Kubernetes API server rejected with 429 Too Many Requests",
+	}, []string{"method"},
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(throttledRequests)
+}
+
+// Monitor records the requests the API server rejects with 429 Too Many
+// Requests, either because of its max-inflight limits or API Priority and
+// Fairness, and reports sustained throttling. A nil Monitor never reports
+// throttling.
+type Monitor struct {
+	window    time.Duration
+	threshold int
+	backoff   time.Duration
+
+	mu        sync.Mutex
+	throttled []time.Time
+	now       func() time.Time
+}
+
+// NewMonitor returns a Monitor that considers throttling sustained once
+// threshold requests were throttled within window, and then postpones
+// reconciles by backoff. A threshold of 0 only records the metric.
+func NewMonitor(window time.Duration, threshold int, backoff time.Duration) *Monitor {
+	return &Monitor{
+		window:    window,
+		threshold: threshold,
+		backoff:   backoff,
+		now:       time.Now,
+	}
+}
+
+// WrapConfig makes the clients built from the given config report their
+// throttled requests to the Monitor.
+func (m *Monitor) WrapConfig(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &roundTripper{next: rt, monitor: m}
+	})
+}
+
+// Backoff returns the delay to postpone a reconcile by while throttling is
+// sustained, zero otherwise.
+func (m *Monitor) Backoff() time.Duration {
+	if m == nil || m.threshold <= 0 {
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune()
+	if len(m.throttled) < m.threshold {
+		return 0
+	}
+	return m.backoff
+}
+
+func (m *Monitor) observe(method string) {
+	throttledRequests.WithLabelValues(method).Inc()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.throttled = append(m.throttled, m.now())
+	m.prune()
+}
+
+// prune drops the throttled requests older than the window, m.mu must be
+// held.
+func (m *Monitor) prune() {
+	cutoff := m.now().Add(-m.window)
+	i := 0
+	for i < len(m.throttled) && m.throttled[i].Before(cutoff) {
+		i++
+	}
+	m.throttled = m.throttled[i:]
+}
+
+type roundTripper struct {
+	next    http.RoundTripper
+	monitor *Monitor
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		rt.monitor.observe(req.Method)
+	}
+	return resp, err
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.md
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0
+
+package throttle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestMonitorBackoff(t *testing.T) {
+	now := time.Now()
+	m := NewMonitor(time.Minute, 3, 30*time.Second)
+	m.now = func() time.Time { return now }
+
+	m.observe(http.MethodGet)
+	m.observe(http.MethodPatch)
+	assert.Zero(t, m.Backoff())
+
+	m.observe(http.MethodGet)
+	assert.Equal(t, 30*time.Second, m.Backoff())
+
+	// throttled requests leave the window
+	now = now.Add(2 * time.Minute)
+	assert.Zero(t, m.Backoff())
+
+	var disabled *Monitor
+	assert.Zero(t, disabled.Backoff())
+	assert.Zero(t, NewMonitor(time.Minute, 0, time.Second).Backoff())
+}
+
+func TestWrapConfig(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	m := NewMonitor(time.Minute, 1, time.Second)
+	cfg := &rest.Config{Host: server.URL}
+	m.WrapConfig(cfg)
+	transport, err := rest.TransportFor(cfg)
+	require.NoError(t, err)
+	httpClient := &http.Client{Transport: transport}
+
+	before := testutil.ToFloat64(throttledRequests.WithLabelValues(http.MethodGet))
+
+	resp, err := httpClient.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Zero(t, m.Backoff())
+
+	status = http.StatusTooManyRequests
+	resp, err = httpClient.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, time.Second, m.Backoff())
+	assert.Equal(t, before+1, testutil.ToFloat64(throttledRequests.WithLabelValues(http.MethodGet)))
+}
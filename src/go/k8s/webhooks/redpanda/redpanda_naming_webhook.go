@@ -0,0 +1,67 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-cluster-redpanda-com-v1alpha1-redpanda-naming,mutating=false,failurePolicy=fail,sideEffects=None,groups="cluster.redpanda.com",resources=redpandas,verbs=create;update,versions=v1alpha1,name=vredpandanaming.kb.io,admissionReviewVersions=v1
+
+// RedpandaNamingValidator denies Redpandas whose name or FullNameOverride
+// does not match the naming convention, so that the names of the generated
+// resources stay predictable
+type RedpandaNamingValidator struct {
+	// Pattern is the naming convention, any name is allowed when nil
+	Pattern *regexp.Regexp
+	Decoder *admission.Decoder
+}
+
+// Handle processes admission for Redpanda
+func (v *RedpandaNamingValidator) Handle(
+	_ context.Context,
+	req admission.Request, //nolint:gocritic // interface not require pointer
+) admission.Response {
+	if v.Pattern == nil {
+		return admission.Allowed("")
+	}
+
+	rp := &redpandav1alpha1.Redpanda{}
+	if err := v.Decoder.DecodeRaw(req.Object, rp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Existing Redpandas are only checked when FullNameOverride changes,
+	// the name is immutable and updates, e.g. removing the finalizer, must
+	// not be blocked by a convention introduced later.
+	checkName := req.Operation == admissionv1.Create
+	checkOverride := checkName
+	if req.Operation == admissionv1.Update {
+		old := &redpandav1alpha1.Redpanda{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		checkOverride = fullNameOverride(old) != fullNameOverride(rp)
+	}
+
+	if checkName && !v.Pattern.MatchString(rp.Name) {
+		return admission.Denied(fmt.Sprintf("redpanda name %q does not match the naming convention %q", rp.Name, v.Pattern.String()))
+	}
+	if override := fullNameOverride(rp); checkOverride && override != "" && !v.Pattern.MatchString(override) {
+		return admission.Denied(fmt.Sprintf("redpanda %s/%s fullNameOverride %q does not match the naming convention %q", rp.Namespace, rp.Name, override, v.Pattern.String()))
+	}
+	return admission.Allowed("")
+}
+
+func fullNameOverride(rp *redpandav1alpha1.Redpanda) string {
+	if rp.Spec.ClusterSpec == nil {
+		return ""
+	}
+	return rp.Spec.ClusterSpec.FullNameOverride
+}
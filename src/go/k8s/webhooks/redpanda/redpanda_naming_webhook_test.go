@@ -0,0 +1,110 @@
+package redpanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/webhooks/redpanda"
+)
+
+func TestRedpandaNamingValidator(t *testing.T) {
+	newRedpanda := func(name, fullNameOverride string) *redpandav1alpha1.Redpanda {
+		rp := &redpandav1alpha1.Redpanda{
+			TypeMeta:   metav1.TypeMeta{APIVersion: redpandav1alpha1.GroupVersion.String(), Kind: "Redpanda"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		}
+		if fullNameOverride != "" {
+			rp.Spec.ClusterSpec = &redpandav1alpha1.RedpandaClusterSpec{FullNameOverride: fullNameOverride}
+		}
+		return rp
+	}
+
+	tests := []struct {
+		name      string
+		pattern   string
+		operation admissionv1.Operation
+		old       *redpandav1alpha1.Redpanda
+		rp        *redpandav1alpha1.Redpanda
+		denied    string
+	}{
+		{
+			name:      "no pattern",
+			operation: admissionv1.Create,
+			rp:        newRedpanda("Redpanda", ""),
+		},
+		{
+			name:      "matching name",
+			pattern:   "^team-[a-z]+$",
+			operation: admissionv1.Create,
+			rp:        newRedpanda("team-a", ""),
+		},
+		{
+			name:      "invalid name",
+			pattern:   "^team-[a-z]+$",
+			operation: admissionv1.Create,
+			rp:        newRedpanda("redpanda", ""),
+			denied:    `redpanda name "redpanda" does not match the naming convention "^team-[a-z]+$"`,
+		},
+		{
+			name:      "invalid fullNameOverride",
+			pattern:   "^team-[a-z]+$",
+			operation: admissionv1.Create,
+			rp:        newRedpanda("team-a", "panda"),
+			denied:    `fullNameOverride "panda" does not match`,
+		},
+		{
+			name:      "update of existing invalid name",
+			pattern:   "^team-[a-z]+$",
+			operation: admissionv1.Update,
+			old:       newRedpanda("redpanda", ""),
+			rp:        newRedpanda("redpanda", ""),
+		},
+		{
+			name:      "update of fullNameOverride",
+			pattern:   "^team-[a-z]+$",
+			operation: admissionv1.Update,
+			old:       newRedpanda("team-a", ""),
+			rp:        newRedpanda("team-a", "panda"),
+			denied:    `fullNameOverride "panda" does not match`,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, redpandav1alpha1.AddToScheme(scheme))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: tt.operation}}
+			raw, err := json.Marshal(tt.rp)
+			require.NoError(t, err)
+			req.Object = runtime.RawExtension{Raw: raw}
+			if tt.old != nil {
+				raw, err = json.Marshal(tt.old)
+				require.NoError(t, err)
+				req.OldObject = runtime.RawExtension{Raw: raw}
+			}
+
+			v := &redpanda.RedpandaNamingValidator{Decoder: admission.NewDecoder(scheme)}
+			if tt.pattern != "" {
+				v.Pattern = regexp.MustCompile(tt.pattern)
+			}
+			resp := v.Handle(context.Background(), req)
+			if tt.denied == "" {
+				assert.True(t, resp.Allowed, resp.Result)
+				return
+			}
+			assert.False(t, resp.Allowed)
+			assert.Contains(t, resp.Result.Message, tt.denied)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterredpandacomv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/cluster.redpanda.com/v1alpha1"
+	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+)
+
+// ForceDeleteAnnotation allows deleting a Redpanda even though Topics still
+// point at its brokers.
+var ForceDeleteAnnotation = redpandav1alpha1.GroupVersion.Group + "/force-delete"
+
+// +kubebuilder:webhook:path=/validate-cluster-redpanda-com-v1alpha1-redpanda,mutating=false,failurePolicy=fail,sideEffects=None,groups="cluster.redpanda.com",resources=redpandas,verbs=delete,versions=v1alpha1,name=vredpanda.kb.io,admissionReviewVersions=v1
+
+// RedpandaDeletionValidator denies deleting Redpandas that still have Topics
+// pointing at their brokers
+type RedpandaDeletionValidator struct {
+	Client  client.Client
+	Decoder *admission.Decoder
+}
+
+// Handle processes admission for Redpanda
+func (v *RedpandaDeletionValidator) Handle(
+	ctx context.Context,
+	req admission.Request, //nolint:gocritic // interface not require pointer
+) admission.Response {
+	if req.Operation != admissionv1.Delete {
+		return admission.Allowed("")
+	}
+
+	rp := &redpandav1alpha1.Redpanda{}
+	if err := v.Decoder.DecodeRaw(req.OldObject, rp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if rp.Annotations[ForceDeleteAnnotation] == "true" {
+		return admission.Allowed("force delete requested")
+	}
+
+	var topics clusterredpandacomv1alpha1.TopicList
+	if err := v.Client.List(ctx, &topics); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	var blocking []string
+	for i := range topics.Items {
+		if topicReferencesRedpanda(&topics.Items[i], rp) {
+			blocking = append(blocking, topics.Items[i].Namespace+"/"+topics.Items[i].Name)
+		}
+	}
+	if len(blocking) == 0 {
+		return admission.Allowed("")
+	}
+
+	sort.Strings(blocking)
+	return admission.Denied(fmt.Sprintf("redpanda %s/%s still has topics pointing at it: %s; delete them first or set the %s=true annotation",
+		rp.Namespace, rp.Name, strings.Join(blocking, ", "), ForceDeleteAnnotation))
+}
+
+// topicReferencesRedpanda reports whether any of the Topic brokers points at
+// the Redpanda.
+func topicReferencesRedpanda(topic *clusterredpandacomv1alpha1.Topic, rp *redpandav1alpha1.Redpanda) bool {
+	if topic.Spec.KafkaAPISpec == nil {
+		return false
+	}
+	for _, broker := range topic.Spec.KafkaAPISpec.Brokers {
+		if rp.IsBrokerAddress(broker, topic.Namespace) {
+			return true
+		}
+	}
+	return false
+}
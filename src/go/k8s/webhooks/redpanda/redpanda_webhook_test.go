@@ -0,0 +1,94 @@
+package redpanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterredpandacomv1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/cluster.redpanda.com/v1alpha1"
+	redpandav1alpha1 "github.com/redpanda-data/redpanda-operator/src/go/k8s/api/redpanda/v1alpha1"
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/webhooks/redpanda"
+)
+
+func TestRedpandaDeletionValidator(t *testing.T) {
+	topic := func(namespace, name string, brokers ...string) client.Object {
+		return &clusterredpandacomv1alpha1.Topic{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: clusterredpandacomv1alpha1.TopicSpec{
+				KafkaAPISpec: &clusterredpandacomv1alpha1.KafkaAPISpec{Brokers: brokers},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		topics      []client.Object
+		allowed     bool
+	}{
+		{
+			name:    "no topics",
+			allowed: true,
+		},
+		{
+			name:    "topics for another cluster",
+			topics:  []client.Object{topic("default", "other", "other-0.other.default.svc.cluster.local.:9093")},
+			allowed: true,
+		},
+		{
+			name:    "topic in another namespace using short name",
+			topics:  []client.Object{topic("other", "orders", "redpanda-0.redpanda:9093")},
+			allowed: true,
+		},
+		{
+			name:    "topic pointing at broker pod",
+			topics:  []client.Object{topic("default", "orders", "redpanda-0.redpanda.default.svc.cluster.local.:9093")},
+			allowed: false,
+		},
+		{
+			name:    "topic pointing at service",
+			topics:  []client.Object{topic("apps", "payments", "redpanda.default.svc:9093")},
+			allowed: false,
+		},
+		{
+			name:        "force delete",
+			annotations: map[string]string{redpanda.ForceDeleteAnnotation: "true"},
+			topics:      []client.Object{topic("default", "orders", "redpanda-0.redpanda:9093")},
+			allowed:     true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, redpandav1alpha1.AddToScheme(scheme))
+	require.NoError(t, clusterredpandacomv1alpha1.AddToScheme(scheme))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := &redpandav1alpha1.Redpanda{
+				TypeMeta:   metav1.TypeMeta{APIVersion: redpandav1alpha1.GroupVersion.String(), Kind: "Redpanda"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "redpanda", Annotations: tt.annotations},
+			}
+			raw, err := json.Marshal(rp)
+			require.NoError(t, err)
+
+			v := &redpanda.RedpandaDeletionValidator{
+				Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.topics...).Build(),
+				Decoder: admission.NewDecoder(scheme),
+			}
+			resp := v.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Delete,
+				OldObject: runtime.RawExtension{Raw: raw},
+			}})
+			assert.Equal(t, tt.allowed, resp.Allowed, resp.Result)
+		})
+	}
+}
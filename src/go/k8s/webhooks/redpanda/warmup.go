@@ -0,0 +1,86 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WarmUpHandler admits the requests the wrapped Handler denies or fails to
+// process until Start is called and during the Grace period following it,
+// e.g. while the caches of the operator are still syncing, and logs them
+// instead. Once the grace period is over the responses of the wrapped Handler
+// are returned unchanged. The handler must be added to the manager, which
+// calls Start once the webhook server is running.
+//
+// The grace period fails open: writes that the validation would reject are
+// accepted while it lasts, so it must be kept short and only enabled where
+// blocking all writes of the resources during a restart of the operator is
+// worse than admitting an invalid one.
+type WarmUpHandler struct {
+	Handler admission.Handler
+	Grace   time.Duration
+	Log     logr.Logger
+
+	mu      sync.Mutex
+	started time.Time
+}
+
+var (
+	_ manager.Runnable               = &WarmUpHandler{}
+	_ manager.LeaderElectionRunnable = &WarmUpHandler{}
+)
+
+// Start starts the grace period. The manager starts its runnables after the
+// webhook server, so the grace period does not elapse while the server is
+// not serving yet.
+func (h *WarmUpHandler) Start(context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.started.IsZero() {
+		h.started = time.Now()
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, every replica serves the webhooks.
+func (h *WarmUpHandler) NeedLeaderElection() bool {
+	return false
+}
+
+func (h *WarmUpHandler) warmingUp() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.started.IsZero() || time.Now().Before(h.started.Add(h.Grace))
+}
+
+// Handle processes admission with the wrapped Handler
+func (h *WarmUpHandler) Handle(
+	ctx context.Context,
+	req admission.Request, //nolint:gocritic // interface not require pointer
+) admission.Response {
+	resp := h.Handler.Handle(ctx, req)
+	if resp.Allowed || !h.warmingUp() {
+		return resp
+	}
+
+	reason := ""
+	if resp.Result != nil {
+		reason = resp.Result.Message
+	}
+	h.Log.Info("admitting request during webhook warm-up", "operation", req.Operation, "kind", req.Kind.Kind, "namespace", req.Namespace, "name", req.Name, "code", resultCode(resp), "reason", reason)
+	return admission.Allowed("").WithWarnings(fmt.Sprintf("admitted during webhook warm-up, validation would have rejected it: %s", reason))
+}
+
+func resultCode(resp admission.Response) int32 {
+	if resp.Result == nil {
+		return http.StatusOK
+	}
+	return resp.Result.Code
+}
@@ -0,0 +1,51 @@
+package redpanda_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/redpanda-data/redpanda-operator/src/go/k8s/webhooks/redpanda"
+)
+
+func TestWarmUpHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     admission.Response
+		grace    time.Duration
+		started  bool
+		allowed  bool
+		warnings int
+	}{
+		{name: "allowed", resp: admission.Allowed(""), grace: time.Minute, started: true, allowed: true},
+		{name: "denied before start", resp: admission.Denied("invalid"), grace: time.Nanosecond, allowed: true, warnings: 1},
+		{name: "denied during warm-up", resp: admission.Denied("invalid"), grace: time.Minute, started: true, allowed: true, warnings: 1},
+		{name: "errored during warm-up", resp: admission.Errored(http.StatusInternalServerError, errors.New("cache not synced")), grace: time.Minute, started: true, allowed: true, warnings: 1},
+		{name: "denied after warm-up", resp: admission.Denied("invalid"), grace: time.Nanosecond, started: true},
+		{name: "errored after warm-up", resp: admission.Errored(http.StatusInternalServerError, errors.New("cache not synced")), grace: time.Nanosecond, started: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &redpanda.WarmUpHandler{
+				Handler: admission.HandlerFunc(func(context.Context, admission.Request) admission.Response { return tt.resp }),
+				Grace:   tt.grace,
+				Log:     logr.Discard(),
+			}
+			if tt.started {
+				require.NoError(t, h.Start(context.Background()))
+				time.Sleep(time.Millisecond)
+			}
+			resp := h.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create}})
+			assert.Equal(t, tt.allowed, resp.Allowed)
+			assert.Len(t, resp.Warnings, tt.warnings)
+		})
+	}
+}